@@ -56,6 +56,9 @@ func (r *repository) Find(_ context.Context, filter models.ActivityFilter) ([]mo
 		if filter.Project != nil && act.Project != *filter.Project {
 			continue
 		}
+		if !filter.MatchesProjectPrefix(act.Project) {
+			continue
+		}
 		if filter.Description != nil && act.Description != *filter.Description {
 			continue
 		}
@@ -57,6 +57,28 @@ func TestRepository_Find(t *testing.T) {
 			},
 			wantLen: 2,
 		},
+		{
+			name: "Filter by project prefix",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("Project"),
+			},
+			wantLen: 3,
+		},
+		{
+			name: "Filter by project prefix without match",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("ProjectA/"),
+			},
+			wantLen: 0,
+		},
+		{
+			name: "Filter by project prefix includes the root",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("ProjectA/"),
+				ProjectRoot:   &projectA,
+			},
+			wantLen: 2,
+		},
 		{
 			name: "Filter IsRunning",
 			filter: models.ActivityFilter{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"unicode/utf8"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/go-faster/errors"
@@ -107,6 +108,15 @@ func (r *ActivityRepository) Find(ctx context.Context, filter models.ActivityFil
 	if filter.Project != nil && *filter.Project != "" {
 		dataset = dataset.Where(goqu.Ex{"project": *filter.Project})
 	}
+	if filter.ProjectPrefix != nil && *filter.ProjectPrefix != "" {
+		// substr rather than LIKE, which would need % and _ escaped.
+		prefix := *filter.ProjectPrefix
+		matches := goqu.Or(goqu.L("substr(project, 1, ?) = ?", utf8.RuneCountInString(prefix), prefix))
+		if filter.ProjectRoot != nil {
+			matches = matches.Append(goqu.Ex{"project": *filter.ProjectRoot})
+		}
+		dataset = dataset.Where(matches)
+	}
 	if filter.Description != nil && *filter.Description != "" {
 		dataset = dataset.Where(goqu.I("description").Like("%" + *filter.Description + "%"))
 	}
@@ -207,6 +207,31 @@ func TestSQLiteRepository_Find(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "filter by project prefix",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("Side"),
+			},
+			expectedCount: 1,
+			verify: func(t *testing.T, acts []models.Activity) {
+				assert.Equal(t, "SideProject", acts[0].Project)
+			},
+		},
+		{
+			name: "filter by project prefix takes wildcards literally",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("T_c"),
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "filter by project prefix includes the root",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("Tock/"),
+				ProjectRoot:   new("Tock"),
+			},
+			expectedCount: 2,
+		},
 		{
 			name: "filter by partial description (Like clause)",
 			filter: models.ActivityFilter{
@@ -80,6 +80,9 @@ func matchesFilter(act models.Activity, filter models.ActivityFilter) bool {
 	if filter.Project != nil && act.Project != *filter.Project {
 		return false
 	}
+	if !filter.MatchesProjectPrefix(act.Project) {
+		return false
+	}
 	if filter.Description != nil && act.Description != *filter.Description {
 		return false
 	}
@@ -125,6 +125,13 @@ func TestRepository_Find(t *testing.T) {
 			},
 			wantCount: 2,
 		},
+		{
+			name: "filter by project prefix",
+			filter: models.ActivityFilter{
+				ProjectPrefix: new("Wo"),
+			},
+			wantCount: 2,
+		},
 		{
 			name: "filter by date range (Oct 15 only)",
 			filter: models.ActivityFilter{
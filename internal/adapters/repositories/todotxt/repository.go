@@ -46,6 +46,9 @@ func (r *repository) Find(_ context.Context, filter models.ActivityFilter) ([]mo
 		if filter.Project != nil && activity.Project != *filter.Project {
 			continue
 		}
+		if !filter.MatchesProjectPrefix(activity.Project) {
+			continue
+		}
 		if filter.Description != nil && activity.Description != *filter.Description {
 			continue
 		}
@@ -50,6 +50,11 @@ func TestRepository_SaveAndFind(t *testing.T) {
 	assert.Equal(t, activity2.Description, got[1].Description)
 	assert.Nil(t, got[1].EndTime)
 
+	got, err = repo.Find(ctx, models.ActivityFilter{ProjectPrefix: new("Client ")})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, activity1.Project, got[0].Project)
+
 	last, err := repo.FindLast(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, activity2.StartTime, last.StartTime)
@@ -0,0 +1,240 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// Ops of the records that are not about a change made through tock.
+const (
+	OpInit = "init" // written by Init
+	OpSync = "sync" // written by Synced
+)
+
+// Source reads the activities whose state the log vouches for.
+type Source interface {
+	List(ctx context.Context, filter models.ActivityFilter) ([]models.Activity, error)
+}
+
+// Record is one line of the audit log. Hash covers every other field,
+// including the hash of the previous record, so changing, removing or
+// reordering records breaks the chain from there on.
+type Record struct {
+	Seq     int       `json:"seq"`
+	Time    time.Time `json:"time"`
+	Op      string    `json:"op"`
+	Changes []Change  `json:"changes,omitempty"`
+	State   string    `json:"state"` // digest of all activities after the change
+	Prev    string    `json:"prev"`
+	Hash    string    `json:"hash"`
+}
+
+// Change is an activity before and after a change, as recorded in the log.
+type Change struct {
+	Before *Snapshot `json:"before,omitempty"`
+	After  *Snapshot `json:"after,omitempty"`
+}
+
+// Snapshot is an activity with times in UTC, so that a record encodes the
+// same way wherever it is verified.
+type Snapshot struct {
+	Project     string     `json:"project"`
+	Description string     `json:"description"`
+	Start       time.Time  `json:"start"`
+	End         *time.Time `json:"end,omitempty"`
+	Notes       string     `json:"notes,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+func newSnapshot(act *models.Activity) *Snapshot {
+	if act == nil {
+		return nil
+	}
+	snapshot := &Snapshot{
+		Project:     act.Project,
+		Description: act.Description,
+		Start:       act.StartTime.UTC(),
+		Notes:       act.Notes,
+		Tags:        act.Tags,
+	}
+	if act.EndTime != nil {
+		end := act.EndTime.UTC()
+		snapshot.End = &end
+	}
+	return snapshot
+}
+
+// Log is an append-only, hash-chained JSON Lines file of changes. Every
+// record also holds a digest of all activities after the change, read back
+// from the backend, so any change that did not go through tock shows up as
+// a difference between the data and the last record.
+type Log struct {
+	path   string
+	source Source
+	now    func() time.Time
+}
+
+// New returns the log at path for the activities of source, which must read
+// the same data as the service the log listens to.
+func New(path string, source Source) *Log {
+	return &Log{path: path, source: source, now: time.Now}
+}
+
+// Path returns the log file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Records returns every record, oldest first.
+func (l *Log) Records() ([]Record, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "open audit log")
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record Record
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, errors.Wrapf(err, "parse audit log line %d", line)
+		}
+		records = append(records, record)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read audit log")
+	}
+	return records, nil
+}
+
+// Init starts the log with the current state of the activities.
+func (l *Log) Init(ctx context.Context) (Record, error) {
+	records, err := l.Records()
+	if err != nil {
+		return Record{}, err
+	}
+	if len(records) > 0 {
+		return Record{}, errors.Errorf("audit log %s already exists", l.path)
+	}
+	return l.append(ctx, Record{Op: OpInit}, nil)
+}
+
+// Synced records the state of the activities after a sync changed them
+// without going through the activity service, for example by pulling the
+// changes made on another machine, so Verify vouches for the synced data.
+func (l *Log) Synced(ctx context.Context) (Record, error) {
+	records, err := l.Records()
+	if err != nil {
+		return Record{}, err
+	}
+	return l.append(ctx, Record{Op: OpSync}, records)
+}
+
+// ActivityChanged implements ports.ActivityChangeListener.
+func (l *Log) ActivityChanged(ctx context.Context, changes []models.ActivityChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	record := Record{Op: string(changes[0].Op)}
+	for _, change := range changes {
+		record.Changes = append(record.Changes, Change{Before: newSnapshot(change.Before), After: newSnapshot(change.After)})
+	}
+	records, err := l.Records()
+	if err != nil {
+		return err
+	}
+	_, err = l.append(ctx, record, records)
+	return err
+}
+
+func (l *Log) append(ctx context.Context, record Record, records []Record) (Record, error) {
+	state, err := l.state(ctx)
+	if err != nil {
+		return Record{}, err
+	}
+
+	record.Seq = 1
+	if len(records) > 0 {
+		last := records[len(records)-1]
+		record.Seq = last.Seq + 1
+		record.Prev = last.Hash
+	}
+	record.Time = l.now().UTC()
+	record.State = state
+	if record.Hash, err = recordHash(record); err != nil {
+		return Record{}, err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, errors.Wrap(err, "encode audit record")
+	}
+	if err = os.MkdirAll(filepath.Dir(l.path), 0750); err != nil {
+		return Record{}, errors.Wrap(err, "create audit log directory")
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return Record{}, errors.Wrap(err, "open audit log")
+	}
+	defer f.Close()
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		return Record{}, errors.Wrap(err, "write audit log")
+	}
+	return record, nil
+}
+
+// recordHash hashes the record without its own hash.
+func recordHash(record Record) (string, error) {
+	record.Hash = ""
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", errors.Wrap(err, "encode audit record")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// state digests all activities with their notes and tags, in start order.
+func (l *Log) state(ctx context.Context) (string, error) {
+	activities, err := l.source.List(ctx, models.ActivityFilter{})
+	if err != nil {
+		return "", errors.Wrap(err, "list activities")
+	}
+
+	lines := make([]string, len(activities))
+	for i, act := range activities {
+		end := ""
+		if act.EndTime != nil {
+			end = act.EndTime.UTC().Format(time.RFC3339Nano)
+		}
+		lines[i] = fmt.Sprintf("%s\x00%s\x00%q\x00%q\x00%q\x00%q",
+			act.StartTime.UTC().Format(time.RFC3339Nano), end, act.Project, act.Description, strings.TrimSpace(act.Notes), act.Tags)
+	}
+	sort.Strings(lines)
+
+	h := sha256.New()
+	for _, line := range lines {
+		fmt.Fprintln(h, line)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
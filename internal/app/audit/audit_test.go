@@ -0,0 +1,81 @@
+package audit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/audit"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+type staticSource struct {
+	activities []models.Activity
+}
+
+func (s *staticSource) List(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+	return s.activities, nil
+}
+
+func testActivity(description string, hour int) models.Activity {
+	start := time.Date(2026, 3, 2, hour, 0, 0, 0, time.FixedZone("CET", 3600))
+	end := start.Add(time.Hour)
+	return models.Activity{Project: "api", Description: description, StartTime: start, EndTime: &end}
+}
+
+func TestLogChainsRecords(t *testing.T) {
+	ctx := context.Background()
+	source := &staticSource{activities: []models.Activity{testActivity("tests", 9)}}
+	log := audit.New(filepath.Join(t.TempDir(), ".tock", "audit.jsonl"), source)
+
+	initRecord, err := log.Init(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, initRecord.Seq)
+	assert.Empty(t, initRecord.Prev)
+
+	added := testActivity("review", 11)
+	source.activities = append(source.activities, added)
+	require.NoError(t, log.ActivityChanged(ctx, []models.ActivityChange{{Op: models.ChangeAdd, After: &added}}))
+
+	records, err := log.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "add", records[1].Op)
+	assert.Equal(t, initRecord.Hash, records[1].Prev)
+	assert.NotEqual(t, initRecord.State, records[1].State)
+	require.Len(t, records[1].Changes, 1)
+	assert.Nil(t, records[1].Changes[0].Before)
+	assert.Equal(t, "review", records[1].Changes[0].After.Description)
+	assert.Equal(t, time.UTC, records[1].Changes[0].After.Start.Location())
+
+	_, err = log.Init(ctx)
+	require.Error(t, err, "init only starts a new log")
+}
+
+func TestLogStateIgnoresOrder(t *testing.T) {
+	ctx := context.Background()
+	a, b := testActivity("tests", 9), testActivity("review", 11)
+
+	first := audit.New(filepath.Join(t.TempDir(), "audit.jsonl"), &staticSource{activities: []models.Activity{a, b}})
+	second := audit.New(filepath.Join(t.TempDir(), "audit.jsonl"), &staticSource{activities: []models.Activity{b, a}})
+
+	r1, err := first.Init(ctx)
+	require.NoError(t, err)
+	r2, err := second.Init(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, r1.State, r2.State)
+}
+
+func TestLogRejectsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("{}\n", 2)+"garbage\n"), 0600))
+
+	_, err := audit.New(path, &staticSource{}).Records()
+	require.ErrorContains(t, err, "line 3")
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+)
+
+// ErrEmpty is returned by Verify when there is no log yet.
+var ErrEmpty = errors.New("the audit log is empty (run tock audit init)")
+
+// Result is the outcome of Verify.
+type Result struct {
+	Records int
+	Head    Record // the last record, whose hash vouches for the whole log
+	// BrokenAt is the first record that was changed, removed or inserted
+	// after it was written, or 0 when the chain is intact.
+	BrokenAt int
+	// DataChanged reports that the activities differ from the state in the
+	// last record, so they were changed without going through tock.
+	DataChanged bool
+}
+
+// OK reports whether the log is intact and matches the data.
+func (r Result) OK() bool {
+	return r.BrokenAt == 0 && !r.DataChanged
+}
+
+// Verify checks the hash chain of the log and compares the current
+// activities with the state in its last record.
+func (l *Log) Verify(ctx context.Context) (Result, error) {
+	records, err := l.Records()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(records) == 0 {
+		return Result{}, ErrEmpty
+	}
+
+	result := Result{Records: len(records), Head: records[len(records)-1]}
+	prev := ""
+	for i, record := range records {
+		hash, hashErr := recordHash(record)
+		if hashErr != nil {
+			return Result{}, hashErr
+		}
+		if record.Seq != i+1 || record.Prev != prev || record.Hash != hash {
+			result.BrokenAt = i + 1
+			break
+		}
+		prev = record.Hash
+	}
+
+	state, err := l.state(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	result.DataChanged = state != result.Head.State
+	return result, nil
+}
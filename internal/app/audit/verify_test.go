@@ -0,0 +1,106 @@
+package audit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/audit"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func newVerifiedLog(t *testing.T) (*audit.Log, *staticSource, string) {
+	t.Helper()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	source := &staticSource{activities: []models.Activity{testActivity("tests", 9)}}
+	log := audit.New(path, source)
+
+	_, err := log.Init(ctx)
+	require.NoError(t, err)
+	for _, description := range []string{"review", "deploy"} {
+		before := source.activities[0]
+		after := before
+		after.Description = description
+		source.activities = []models.Activity{after}
+		require.NoError(t, log.ActivityChanged(ctx, []models.ActivityChange{{Op: models.ChangeEdit, Before: &before, After: &after}}))
+	}
+	return log, source, path
+}
+
+func TestVerifyIntactLog(t *testing.T) {
+	log, _, _ := newVerifiedLog(t)
+
+	result, err := log.Verify(context.Background())
+	require.NoError(t, err)
+	assert.True(t, result.OK())
+	assert.Equal(t, 3, result.Records)
+	assert.Equal(t, 3, result.Head.Seq)
+}
+
+func TestVerifyDetectsChangedData(t *testing.T) {
+	log, source, _ := newVerifiedLog(t)
+	source.activities[0].Notes = "added by hand"
+
+	result, err := log.Verify(context.Background())
+	require.NoError(t, err)
+	assert.False(t, result.OK())
+	assert.True(t, result.DataChanged)
+	assert.Zero(t, result.BrokenAt)
+}
+
+func TestVerifyAcceptsSyncedData(t *testing.T) {
+	log, source, _ := newVerifiedLog(t)
+	source.activities = append(source.activities, testActivity("pulled", 11))
+
+	record, err := log.Synced(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, audit.OpSync, record.Op)
+	assert.Empty(t, record.Changes)
+
+	result, err := log.Verify(context.Background())
+	require.NoError(t, err)
+	assert.True(t, result.OK())
+	assert.Equal(t, 4, result.Head.Seq)
+}
+
+func TestVerifyDetectsAlteredLog(t *testing.T) {
+	tests := map[string]func(lines []string) []string{
+		"edited": func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], `"description":"review"`, `"description":"reviews"`, 1)
+			return lines
+		},
+		"removed": func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		},
+		"reordered": func(lines []string) []string {
+			lines[1], lines[2] = lines[2], lines[1]
+			return lines
+		},
+	}
+
+	for name, alter := range tests {
+		t.Run(name, func(t *testing.T) {
+			log, _, path := newVerifiedLog(t)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := alter(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+			result, err := log.Verify(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.BrokenAt)
+			assert.False(t, result.OK())
+		})
+	}
+}
+
+func TestVerifyEmptyLog(t *testing.T) {
+	_, err := audit.New(filepath.Join(t.TempDir(), "audit.jsonl"), &staticSource{}).Verify(context.Background())
+	require.ErrorIs(t, err, audit.ErrEmpty)
+}
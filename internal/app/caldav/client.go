@@ -0,0 +1,109 @@
+// Package caldav pushes activities to a CalDAV calendar collection.
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// Client stores calendar resources in one CalDAV collection. Only plain
+// WebDAV PUT and DELETE are needed, so any CalDAV server works.
+type Client struct {
+	collection *url.URL
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the collection URL. Basic authentication is
+// used when username is set.
+func NewClient(collectionURL, username, password string, httpClient *http.Client) (*Client, error) {
+	if collectionURL == "" {
+		return nil, errors.New("caldav collection URL is not set")
+	}
+	u, err := url.Parse(collectionURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse caldav URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("invalid caldav URL %q", collectionURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{collection: u, username: username, password: password, httpClient: httpClient}, nil
+}
+
+// Collection returns the normalized collection URL.
+func (c *Client) Collection() string {
+	return c.collection.String()
+}
+
+// Put creates or replaces the resource name with a calendar body.
+func (c *Client) Put(ctx context.Context, name, body string) error {
+	resp, err := c.do(ctx, http.MethodPut, name, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	default:
+		return statusError(http.MethodPut, name, resp)
+	}
+}
+
+// Delete removes the resource name. Resources that are already gone are fine.
+func (c *Client) Delete(ctx context.Context, name string) error {
+	resp, err := c.do(ctx, http.MethodDelete, name, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound, http.StatusGone:
+		return nil
+	default:
+		return statusError(http.MethodDelete, name, resp)
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, name string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.collection.JoinPath(name).String(), body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "build %s request", method)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s", method, name)
+	}
+	return resp, nil
+}
+
+func statusError(method, name string, resp *http.Response) error {
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	message := strings.TrimSpace(string(bytes.ToValidUTF8(detail, nil)))
+	if message == "" {
+		return errors.Errorf("%s %s: %s", method, name, resp.Status)
+	}
+	return errors.Errorf("%s %s: %s: %s", method, name, resp.Status, message)
+}
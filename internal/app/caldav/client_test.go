@@ -0,0 +1,119 @@
+package caldav_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/caldav"
+)
+
+// fakeCalDAV is an in-process stand-in for a CalDAV collection at /cal/.
+type fakeCalDAV struct {
+	mu        sync.Mutex
+	resources map[string]string
+	requests  []string
+}
+
+func newFakeCalDAV(t *testing.T) (*fakeCalDAV, *httptest.Server) {
+	t.Helper()
+	fake := &fakeCalDAV{resources: make(map[string]string)}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeCalDAV) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "me" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	name, ok := strings.CutPrefix(r.URL.Path, "/cal/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if r.Header.Get("Content-Type") != "text/calendar; charset=utf-8" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, existed := f.resources[name]
+		f.resources[name] = string(body)
+		if existed {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+	case http.MethodDelete:
+		if _, existed := f.resources[name]; !existed {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.resources, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeCalDAV) resource(name string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, ok := f.resources[name]
+	return body, ok
+}
+
+func (f *fakeCalDAV) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.resources)
+}
+
+func TestClientPutAndDelete(t *testing.T) {
+	fake, server := newFakeCalDAV(t)
+	client, err := caldav.NewClient(server.URL+"/cal", "me", "secret", server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/cal/", client.Collection())
+
+	require.NoError(t, client.Put(context.Background(), "a.ics", "BEGIN:VCALENDAR"))
+	body, ok := fake.resource("a.ics")
+	require.True(t, ok)
+	assert.Equal(t, "BEGIN:VCALENDAR", body)
+
+	require.NoError(t, client.Delete(context.Background(), "a.ics"))
+	require.NoError(t, client.Delete(context.Background(), "a.ics"), "deleting a missing resource is fine")
+	assert.Zero(t, fake.count())
+}
+
+func TestClientReportsHTTPErrors(t *testing.T) {
+	_, server := newFakeCalDAV(t)
+	client, err := caldav.NewClient(server.URL+"/cal/", "me", "wrong", server.Client())
+	require.NoError(t, err)
+
+	err = client.Put(context.Background(), "a.ics", "BEGIN:VCALENDAR")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+}
+
+func TestNewClientValidatesURL(t *testing.T) {
+	_, err := caldav.NewClient("", "", "", nil)
+	require.Error(t, err)
+
+	_, err = caldav.NewClient("ftp://example.com/cal", "", "", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package caldav
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// State remembers what was pushed to a collection, so later syncs only upload
+// changed activities and can delete the ones removed locally.
+type State struct {
+	Collection string           `json:"collection"`
+	Entries    map[string]Entry `json:"entries"` // by UID
+}
+
+// Entry is one activity stored in the collection.
+type Entry struct {
+	Resource    string    `json:"resource"`
+	Start       time.Time `json:"start"`
+	Fingerprint string    `json:"fingerprint"`
+	Sequence    int       `json:"sequence,omitempty"`
+	Modified    time.Time `json:"modified,omitzero"`
+}
+
+// LoadState reads the state file. A missing file, or one written for another
+// collection, yields an empty state for the collection.
+func LoadState(path, collection string) (*State, error) {
+	state := &State{Collection: collection, Entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read caldav state")
+	}
+
+	var stored State
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return nil, errors.Wrapf(err, "parse caldav state %s", path)
+	}
+	if stored.Collection == collection && stored.Entries != nil {
+		state.Entries = stored.Entries
+	}
+	return state, nil
+}
+
+// Save writes the state file atomically.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encode caldav state")
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.Wrap(err, "create caldav state directory")
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "write caldav state")
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return errors.Wrap(err, "replace caldav state")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package caldav_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/caldav"
+)
+
+func TestStateSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "caldav_state.json")
+
+	state, err := caldav.LoadState(path, "https://dav.example.com/cal/")
+	require.NoError(t, err)
+	assert.Empty(t, state.Entries)
+
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	state.Entries["uid@tock"] = caldav.Entry{Resource: "uid-tock.ics", Start: start, Fingerprint: "abc"}
+	require.NoError(t, state.Save(path))
+
+	loaded, err := caldav.LoadState(path, "https://dav.example.com/cal/")
+	require.NoError(t, err)
+	assert.Equal(t, state.Entries, loaded.Entries)
+
+	other, err := caldav.LoadState(path, "https://dav.example.com/other/")
+	require.NoError(t, err)
+	assert.Empty(t, other.Entries, "entries of another collection are not reused")
+	assert.Equal(t, "https://dav.example.com/other/", other.Collection)
+}
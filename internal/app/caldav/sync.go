@@ -0,0 +1,109 @@
+package caldav
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// Options selects what Sync pushes.
+type Options struct {
+	From         time.Time // activities starting before From are left alone; zero for no limit
+	To           time.Time // activities starting at or after To are left alone; zero for no limit
+	Now          time.Time // DTSTAMP of the uploaded events
+	CalendarName string
+	DryRun       bool // count the changes without touching the server or the state
+}
+
+// Result counts what Sync did, or would do in a dry run.
+type Result struct {
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Running   int // running activities are uploaded once they are stopped
+}
+
+// Sync makes the collection match the finished activities that start in the
+// range: new activities are created, changed ones replaced and entries whose
+// activity was removed locally are deleted. Entries outside the range are
+// kept. The state is updated as changes succeed, so it should be saved even
+// when Sync fails halfway.
+func Sync(ctx context.Context, client *Client, state *State, activities []models.Activity, opts Options) (Result, error) {
+	var result Result
+	seen := make(map[string]bool)
+	revisions := exportapp.NewRevisions()
+
+	sorted := models.SortActivitiesByStart(activities)
+	uids := exportapp.ActivityUIDs(sorted)
+	for i, act := range sorted {
+		if !inRange(act.StartTime, opts) {
+			continue
+		}
+		if act.EndTime == nil {
+			result.Running++
+			continue
+		}
+
+		uid := uids[i]
+		seen[uid] = true
+		fingerprint := exportapp.ActivityFingerprint(act)
+		entry, known := state.Entries[uid]
+		if known && entry.Fingerprint == fingerprint {
+			result.Unchanged++
+			continue
+		}
+		if known {
+			result.Updated++
+			revisions.Restore(uid, exportapp.Revision{Fingerprint: entry.Fingerprint, Sequence: entry.Sequence, Modified: entry.Modified})
+		} else {
+			result.Created++
+		}
+		if opts.DryRun {
+			continue
+		}
+
+		resource := resourceName(uid)
+		rev := revisions.Revise(uid, act, opts.Now)
+		body := exportapp.Generate(act, exportapp.WithNow(opts.Now), exportapp.WithCalendarName(opts.CalendarName),
+			exportapp.WithRevisions(revisions), exportapp.WithUID(uid))
+		if err := client.Put(ctx, resource, body); err != nil {
+			return result, errors.Wrapf(err, "upload %s: %s", act.Project, act.Description)
+		}
+		state.Entries[uid] = Entry{
+			Resource: resource, Start: act.StartTime, Fingerprint: fingerprint, Sequence: rev.Sequence, Modified: rev.Modified,
+		}
+	}
+
+	for uid, entry := range state.Entries {
+		if seen[uid] || !inRange(entry.Start, opts) {
+			continue
+		}
+		result.Deleted++
+		if opts.DryRun {
+			continue
+		}
+		if err := client.Delete(ctx, entry.Resource); err != nil {
+			return result, errors.Wrapf(err, "delete %s", entry.Resource)
+		}
+		delete(state.Entries, uid)
+	}
+	return result, nil
+}
+
+func inRange(start time.Time, opts Options) bool {
+	if !opts.From.IsZero() && start.Before(opts.From) {
+		return false
+	}
+	return opts.To.IsZero() || start.Before(opts.To)
+}
+
+// resourceName turns a UID into a file name that needs no escaping in a URL.
+func resourceName(uid string) string {
+	return strings.ReplaceAll(uid, "@", "-") + ".ics"
+}
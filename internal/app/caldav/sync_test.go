@@ -0,0 +1,119 @@
+package caldav_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/caldav"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func syncActivity(project, description string, start time.Time, length time.Duration) models.Activity {
+	end := start.Add(length)
+	return models.Activity{Project: project, Description: description, StartTime: start, EndTime: &end}
+}
+
+func TestSyncCreatesUpdatesAndDeletes(t *testing.T) {
+	fake, server := newFakeCalDAV(t)
+	client, err := caldav.NewClient(server.URL+"/cal/", "me", "secret", server.Client())
+	require.NoError(t, err)
+	state, err := caldav.LoadState(t.TempDir()+"/state.json", client.Collection())
+	require.NoError(t, err)
+
+	day := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)
+	first := syncActivity("core", "review", day.Add(9*time.Hour), time.Hour)
+	second := syncActivity("ops", "deploy", day.Add(11*time.Hour), 30*time.Minute)
+	running := models.Activity{Project: "core", Description: "coding", StartTime: day.Add(13 * time.Hour)}
+	opts := caldav.Options{Now: day.Add(20 * time.Hour), CalendarName: "Work"}
+
+	result, err := caldav.Sync(context.Background(), client, state, []models.Activity{first, second, running}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Created: 2, Running: 1}, result)
+	body, ok := fake.resource("20260314T090000Z-tock.ics")
+	require.True(t, ok)
+	assert.Contains(t, body, "UID:20260314T090000Z@tock\r\n")
+	assert.Contains(t, body, "X-WR-CALNAME:Work\r\n")
+	assert.Contains(t, body, "SEQUENCE:0\r\nDTSTART")
+	assert.Equal(t, 2, fake.count())
+
+	result, err = caldav.Sync(context.Background(), client, state, []models.Activity{first, second}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Unchanged: 2}, result)
+
+	second.Description = "deploy v2"
+	result, err = caldav.Sync(context.Background(), client, state, []models.Activity{second}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Updated: 1, Deleted: 1}, result)
+	_, ok = fake.resource("20260314T090000Z-tock.ics")
+	assert.False(t, ok)
+	body, _ = fake.resource("20260314T110000Z-tock.ics")
+	assert.Contains(t, body, "SUMMARY:ops: deploy v2")
+	assert.Contains(t, body, "LAST-MODIFIED:20260314T200000Z\r\nSEQUENCE:1\r\n", "the edit is the next revision")
+	assert.Len(t, state.Entries, 1)
+}
+
+func TestSyncKeepsActivitiesWithTheSameStartApart(t *testing.T) {
+	fake, server := newFakeCalDAV(t)
+	client, err := caldav.NewClient(server.URL+"/cal/", "me", "secret", server.Client())
+	require.NoError(t, err)
+	state, err := caldav.LoadState(t.TempDir()+"/state.json", client.Collection())
+	require.NoError(t, err)
+
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	activities := []models.Activity{
+		syncActivity("core", "review", start, time.Hour),
+		syncActivity("ops", "on call", start, 2*time.Hour),
+	}
+
+	result, err := caldav.Sync(context.Background(), client, state, activities, caldav.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Created: 2}, result)
+	assert.Equal(t, 2, fake.count())
+
+	result, err = caldav.Sync(context.Background(), client, state, activities, caldav.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Unchanged: 2}, result, "neither replaces the other")
+}
+
+func TestSyncLeavesEntriesOutsideTheRange(t *testing.T) {
+	fake, server := newFakeCalDAV(t)
+	client, err := caldav.NewClient(server.URL+"/cal/", "me", "secret", server.Client())
+	require.NoError(t, err)
+	state, err := caldav.LoadState(t.TempDir()+"/state.json", client.Collection())
+	require.NoError(t, err)
+
+	day := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)
+	earlier := syncActivity("core", "old", day.Add(-24*time.Hour), time.Hour)
+	today := syncActivity("core", "new", day.Add(9*time.Hour), time.Hour)
+
+	_, err = caldav.Sync(context.Background(), client, state, []models.Activity{earlier, today}, caldav.Options{})
+	require.NoError(t, err)
+	require.Equal(t, 2, fake.count())
+
+	// Only today is listed and synced; yesterday's event must survive.
+	result, err := caldav.Sync(context.Background(), client, state, []models.Activity{today},
+		caldav.Options{From: day, To: day.Add(24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Unchanged: 1}, result)
+	assert.Equal(t, 2, fake.count())
+}
+
+func TestSyncDryRunChangesNothing(t *testing.T) {
+	fake, server := newFakeCalDAV(t)
+	client, err := caldav.NewClient(server.URL+"/cal/", "me", "secret", server.Client())
+	require.NoError(t, err)
+	state, err := caldav.LoadState(t.TempDir()+"/state.json", client.Collection())
+	require.NoError(t, err)
+
+	act := syncActivity("core", "review", time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC), time.Hour)
+	result, err := caldav.Sync(context.Background(), client, state, []models.Activity{act}, caldav.Options{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, caldav.Result{Created: 1}, result)
+	assert.Zero(t, fake.count())
+	assert.Empty(t, fake.requests)
+	assert.Empty(t, state.Entries)
+}
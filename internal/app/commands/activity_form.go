@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+const (
+	formFieldProject = iota
+	formFieldDescription
+	formFieldStart
+	formFieldEnd
+	formFieldTags
+	formFieldNotes
+	formFieldCount
+)
+
+var runActivityFormProgram = func(model activityFormModel) (activityFormModel, error) {
+	program := tea.NewProgram(&model, tea.WithAltScreen())
+	finalModel, err := program.Run()
+	if err != nil {
+		return activityFormModel{}, errors.Wrap(err, "run activity form")
+	}
+	return *finalModel.(*activityFormModel), nil //nolint:errcheck // safe cast
+}
+
+// activityFormModel is a small multi-field editor for an activity. It can run
+// as its own program or be embedded in another model, which forwards key
+// messages to handleKey and checks submitted/canceled afterwards.
+type activityFormModel struct {
+	title     string
+	labels    [formFieldCount]string
+	values    [formFieldCount]string
+	focus     int
+	submitted bool
+	canceled  bool
+	err       string
+	// notes keeps the activity's original notes; the single-line field shows
+	// them flattened, so they are only replaced when that field is edited.
+	notes string
+	tf    *timeutil.Formatter
+	theme Theme
+}
+
+func newActivityFormModel(title string, activity models.Activity, tf *timeutil.Formatter, theme Theme) activityFormModel {
+	m := activityFormModel{title: title, notes: activity.Notes, tf: tf, theme: theme}
+	m.labels = [formFieldCount]string{
+		defaultText("form.project"),
+		defaultText("form.description"),
+		defaultText("form.start"),
+		defaultText("form.end"),
+		defaultText("form.tags"),
+		defaultText("form.notes"),
+	}
+
+	layout := tf.GetDisplayFormatWithDate()
+	m.values[formFieldProject] = activity.Project
+	m.values[formFieldDescription] = activity.Description
+	if !activity.StartTime.IsZero() {
+		m.values[formFieldStart] = activity.StartTime.Format(layout)
+	}
+	if activity.EndTime != nil {
+		m.values[formFieldEnd] = activity.EndTime.Format(layout)
+	}
+	m.values[formFieldTags] = strings.Join(activity.Tags, ", ")
+	m.values[formFieldNotes] = flattenNotes(activity.Notes)
+	return m
+}
+
+func flattenNotes(notes string) string {
+	return strings.ReplaceAll(notes, "\n", " ")
+}
+
+func (m *activityFormModel) Init() tea.Cmd { return nil }
+
+func (m *activityFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		m.handleKey(keyMsg)
+		if m.submitted || m.canceled {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *activityFormModel) handleKey(msg tea.KeyMsg) {
+	//nolint:exhaustive // bubbletea keyMsg.Type is not exhaustive
+	switch msg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.canceled = true
+	case tea.KeyCtrlS:
+		m.submit()
+	case tea.KeyEnter:
+		if m.focus == formFieldCount-1 {
+			m.submit()
+			return
+		}
+		m.focus++
+	case tea.KeyTab, tea.KeyDown:
+		m.focus = (m.focus + 1) % formFieldCount
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.focus = (m.focus + formFieldCount - 1) % formFieldCount
+	case tea.KeyBackspace, tea.KeyDelete:
+		if value := []rune(m.values[m.focus]); len(value) > 0 {
+			m.values[m.focus] = string(value[:len(value)-1])
+		}
+	case tea.KeyCtrlU:
+		m.values[m.focus] = ""
+	case tea.KeyRunes:
+		m.values[m.focus] += string(msg.Runes)
+	case tea.KeySpace:
+		m.values[m.focus] += " "
+	}
+}
+
+func (m *activityFormModel) submit() {
+	if _, err := m.request(); err != nil {
+		m.err = err.Error()
+		return
+	}
+	m.err = ""
+	m.submitted = true
+}
+
+// request converts the form values into an edit request. An empty end time
+// leaves the activity running.
+func (m *activityFormModel) request() (models.EditActivityRequest, error) {
+	req := models.EditActivityRequest{
+		Project:     strings.TrimSpace(m.values[formFieldProject]),
+		Description: strings.TrimSpace(m.values[formFieldDescription]),
+		Notes:       strings.TrimSpace(m.values[formFieldNotes]),
+	}
+	if m.values[formFieldNotes] == flattenNotes(m.notes) {
+		req.Notes = m.notes
+	}
+	if req.Project == "" || req.Description == "" {
+		return req, errors.New(defaultText("form.error.required"))
+	}
+
+	start, err := m.tf.ParseTimeWithDate(m.values[formFieldStart])
+	if err != nil {
+		return req, errors.Wrap(err, "parse start time")
+	}
+	req.StartTime = start
+
+	if endValue := strings.TrimSpace(m.values[formFieldEnd]); endValue != "" {
+		var end time.Time
+		end, err = m.tf.ParseTimeWithDate(endValue)
+		if err != nil {
+			return req, errors.Wrap(err, "parse end time")
+		}
+		if end.Before(start) {
+			return req, errors.New(defaultText("form.error.end_before_start"))
+		}
+		req.EndTime = &end
+	}
+
+	for tag := range strings.SplitSeq(m.values[formFieldTags], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			req.Tags = append(req.Tags, tag)
+		}
+	}
+	return req, nil
+}
+
+func (m *activityFormModel) View() string {
+	t := m.theme
+	s := strings.Builder{}
+	s.WriteString(lipgloss.NewStyle().Bold(true).Foreground(t.Highlight).Render(m.title))
+	s.WriteString("\n\n")
+
+	labelStyle := lipgloss.NewStyle().Width(14).Foreground(t.SubText)
+	for i := range formFieldCount {
+		cursor := "  "
+		value := m.values[i]
+		if i == m.focus {
+			cursor = "> "
+			value += lipgloss.NewStyle().Blink(true).Render("█")
+			s.WriteString(cursor + labelStyle.Foreground(t.Primary).Bold(true).Render(m.labels[i]) + value + "\n")
+			continue
+		}
+		s.WriteString(cursor + labelStyle.Render(m.labels[i]) + value + "\n")
+	}
+
+	if m.err != "" {
+		s.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(m.err) + "\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Foreground(t.Faint).Render(defaultText("form.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(s.String())
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func TestActivityFormModelPrefillsAndBuildsRequest(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	end := start.Add(90 * time.Minute)
+	form := newActivityFormModel("Edit", models.Activity{
+		Project:     "tock",
+		Description: "search",
+		StartTime:   start,
+		EndTime:     &end,
+		Tags:        []string{"cli", "tui"},
+		Notes:       "line one\nline two",
+	}, timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+
+	assert.Equal(t, "2026-03-14 09:00", form.values[formFieldStart])
+	assert.Equal(t, "2026-03-14 10:30", form.values[formFieldEnd])
+	assert.Equal(t, "cli, tui", form.values[formFieldTags])
+	assert.Equal(t, "line one line two", form.values[formFieldNotes])
+
+	req, err := form.request()
+	require.NoError(t, err)
+	assert.Equal(t, "tock", req.Project)
+	assert.True(t, req.StartTime.Equal(start))
+	require.NotNil(t, req.EndTime)
+	assert.True(t, req.EndTime.Equal(end))
+	assert.Equal(t, []string{"cli", "tui"}, req.Tags)
+	assert.Equal(t, "line one\nline two", req.Notes, "untouched notes keep their line breaks")
+
+	form.values[formFieldNotes] = "rewritten"
+	req, err = form.request()
+	require.NoError(t, err)
+	assert.Equal(t, "rewritten", req.Notes)
+}
+
+func TestActivityFormModelKeyHandling(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	form := newActivityFormModel("Edit", models.Activity{Project: "tock", Description: "x", StartTime: start},
+		timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+
+	form.focus = formFieldDescription
+	form.handleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	form.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("review")})
+	assert.Equal(t, "review", form.values[formFieldDescription])
+
+	form.handleKey(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, formFieldProject, form.focus)
+
+	form.handleKey(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.True(t, form.submitted)
+
+	req, err := form.request()
+	require.NoError(t, err)
+	assert.Nil(t, req.EndTime, "blank end keeps the activity running")
+}
+
+func TestActivityFormModelRejectsInvalidInput(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	form := newActivityFormModel("Edit", models.Activity{Project: "tock", Description: "x", StartTime: start},
+		timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+
+	form.values[formFieldEnd] = "2026-03-14 08:00"
+	form.submit()
+	assert.False(t, form.submitted)
+	assert.Equal(t, "end time cannot be before start time", form.err)
+
+	form.values[formFieldProject] = " "
+	form.submit()
+	assert.False(t, form.submitted)
+	assert.Contains(t, form.View(), "project and description are required")
+
+	form.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, form.canceled)
+}
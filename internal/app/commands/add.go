@@ -75,14 +75,22 @@ func runAdd(cmd *cobra.Command, opts *addOptions) error {
 
 	if opts.Project == "" || opts.Description == "" {
 		activities, _ := service.List(cmd.Context(), models.ActivityFilter{})
+		archived, err := rt.Archive.Hidden()
+		if err != nil {
+			return err
+		}
 
-		var err error
-		opts.Project, opts.Description, err = SelectActivityMetadata(activities, opts.Project, opts.Description, theme)
+		opts.Project, opts.Description, err = SelectActivityMetadata(activities, archived, opts.Project, opts.Description, theme)
 		if err != nil {
 			return errors.Wrap(err, "select activity metadata")
 		}
 	}
 
+	project, err := resolveProject(cmd, opts.Project)
+	if err != nil {
+		return err
+	}
+
 	startStr, err := resolveStartTime(opts.StartStr, theme)
 	if err != nil {
 		return err
@@ -113,7 +121,7 @@ func runAdd(cmd *cobra.Command, opts *addOptions) error {
 
 	req := models.AddActivityRequest{
 		Description: opts.Description,
-		Project:     opts.Project,
+		Project:     project,
 		StartTime:   startTime,
 		EndTime:     endTime,
 		Notes:       opts.Notes,
@@ -15,12 +15,13 @@ func normalizeAddDateTimeInput(tf *timeutil.Formatter, dayStr, input string) (st
 		return input, nil
 	}
 
-	if _, err := time.ParseInLocation("2006-01-02", dayStr, time.Local); err != nil {
+	day, err := tf.ParseDate(dayStr)
+	if err != nil {
 		return "", errors.Wrap(err, "parse day")
 	}
 
-	if _, err := tf.ParseTime(input); err == nil {
-		return dayStr + " " + input, nil
+	if _, err = tf.ParseTime(input); err == nil {
+		return day.Format(time.DateOnly) + " " + input, nil
 	}
 
 	return input, nil
@@ -43,7 +44,7 @@ func calculateEndTime(tf *timeutil.Formatter, startTime time.Time, endStr, durat
 		return time.Time{}, errors.New("end time or duration is required")
 	}
 
-	duration, err := time.ParseDuration(durationStr)
+	duration, err := tf.ParseDuration(durationStr)
 	if err != nil {
 		return time.Time{}, errors.Wrap(err, "parse duration")
 	}
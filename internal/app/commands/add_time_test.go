@@ -11,7 +11,7 @@ import (
 )
 
 func TestNormalizeAddDateTimeInput(t *testing.T) {
-	tf := timeutil.NewFormatter("24")
+	tf := timeutil.NewFormatter("24").At(time.Date(2026, time.April, 22, 12, 0, 0, 0, time.Local))
 
 	tests := []struct {
 		name    string
@@ -38,6 +38,12 @@ func TestNormalizeAddDateTimeInput(t *testing.T) {
 			input:  "",
 			want:   "",
 		},
+		{
+			name:   "natural day is resolved to a date",
+			dayStr: "monday",
+			input:  "09:30",
+			want:   "2026-04-20 09:30",
+		},
 		{
 			name:    "invalid day returns error",
 			dayStr:  "2026-99-99",
@@ -130,6 +136,14 @@ func TestCalculateEndTime(t *testing.T) {
 			durationStr: "1h30m",
 			want:        baseTime.Add(90 * time.Minute),
 		},
+		{
+			name:        "Duration provided (words)",
+			formatStr:   "24",
+			startTime:   baseTime,
+			endStr:      "",
+			durationStr: "90 min",
+			want:        baseTime.Add(90 * time.Minute),
+		},
 		{
 			name:        "Duration parsing error",
 			formatStr:   "24",
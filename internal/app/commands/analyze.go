@@ -16,30 +16,39 @@ import (
 	"github.com/kriuchkov/tock/internal/timeutil"
 )
 
+type analyzeOptions struct {
+	Days   int
+	Depth  int
+	Period string
+	Since  string
+}
+
 func NewAnalyzeCmd() *cobra.Command {
-	var (
-		days int
-	)
+	var opts analyzeOptions
 
 	cmd := &cobra.Command{
 		Use:   "analyze",
 		Short: "Analyze your productivity patterns",
 		Long:  defaultText("analyze.long"),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runAnalyzeCmd(cmd, days)
+			return runAnalyzeCmd(cmd, &opts)
 		},
 	}
 
-	cmd.Flags().IntVarP(&days, "days", "n", 30, defaultText("analyze.flag.days"))
+	cmd.Flags().IntVarP(&opts.Days, "days", "n", 30, defaultText("analyze.flag.days"))
+	cmd.Flags().IntVar(&opts.Depth, "depth", 0, defaultText("analyze.flag.depth"))
+	addPeriodFlags(cmd, &opts.Period, &opts.Since)
+	cmd.MarkFlagsMutuallyExclusive("days", "period", "since")
 
 	return cmd
 }
 
-func runAnalyzeCmd(cmd *cobra.Command, days int) error {
+func runAnalyzeCmd(cmd *cobra.Command, opts *analyzeOptions) error {
 	rt := getRuntime(cmd)
 	service := rt.ActivityService
 	out := cmd.OutOrStdout()
 
+	days := opts.Days
 	if days <= 0 {
 		days = 30
 	}
@@ -47,6 +56,17 @@ func runAnalyzeCmd(cmd *cobra.Command, days int) error {
 	end := time.Now()
 	start := end.AddDate(0, 0, -days)
 	filter := models.ActivityFilter{FromDate: &start, ToDate: &end}
+	if opts.Period != "" || opts.Since != "" {
+		var err error
+		filter, err = models.BuildActivityFilter(models.ActivityFilterOptions{
+			Period:        opts.Period,
+			Since:         opts.Since,
+			TimeFormatter: rt.TimeFormatter,
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	report, err := service.GetReport(cmd.Context(), filter)
 	if err != nil {
@@ -59,7 +79,59 @@ func runAnalyzeCmd(cmd *cobra.Command, days int) error {
 	}
 
 	stats := insights.AnalyzeActivities(report.Activities)
-	return renderAnalysis(out, stats, rt.Config, rt.TimeFormatter, getLocalizer(cmd))
+	if err = renderAnalysis(out, stats, rt.Config, rt.TimeFormatter, getLocalizer(cmd)); err != nil {
+		return err
+	}
+
+	durations := make(map[string]time.Duration, len(report.ByProject))
+	for name, projectReport := range report.ByProject {
+		durations[name] = projectReport.Duration
+	}
+	tree := insights.BuildProjectTree(durations, rt.Config.Projects.Separator)
+	insights.SortProjectTreeByDuration(tree)
+	return renderAnalysisProjects(out, tree, opts.Depth, report.TotalDuration, rt.Config, getLocalizer(cmd))
+}
+
+// renderAnalysisProjects prints the share of time of every project, rolled
+// up to its parents, down to depth levels when it is above 0.
+func renderAnalysisProjects(
+	out io.Writer,
+	tree []*insights.ProjectNode,
+	depth int,
+	total time.Duration,
+	cfg *config.Config,
+	loc interface{ Format(string, ...any) string },
+) error {
+	theme := GetTheme(cfg.Theme)
+	sectionStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).MarginTop(1)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.SubText).Width(25)
+	valueStyle := lipgloss.NewStyle().Foreground(theme.Text).Bold(true)
+
+	fmt.Fprintln(out, sectionStyle.Render(loc.Format("analyze.section.projects")))
+
+	var err error
+	insights.WalkProjectTree(tree, depth, func(node *insights.ProjectNode, level int, _ bool) {
+		if err != nil {
+			return
+		}
+		label := node.Path
+		if level > 1 {
+			label = strings.Repeat("  ", level-1) + node.Name
+		}
+		share := 0.0
+		if total > 0 {
+			share = float64(node.Duration) / float64(total) * 100
+		}
+		_, err = fmt.Fprintf(out, "%s %s %s\n",
+			labelStyle.Render(label),
+			valueStyle.Render(node.Duration.Round(time.Minute).String()),
+			lipgloss.NewStyle().Foreground(theme.SubText).Render(fmt.Sprintf("%.1f%%", share)))
+	})
+	if err != nil {
+		return errors.Wrap(err, "write project line")
+	}
+	fmt.Fprintln(out)
+	return nil
 }
 
 //nolint:funlen // render function is inherently long for output formatting
@@ -30,7 +30,7 @@ func TestRunAnalyzeCmdUsesCommandWriter(t *testing.T) {
 	var out bytes.Buffer
 	cmd.SetOut(&out)
 
-	err := runAnalyzeCmd(cmd, 7)
+	err := runAnalyzeCmd(cmd, &analyzeOptions{Days: 7})
 	require.NoError(t, err)
 	assert.Equal(t, "No activities found for analysis.\n", out.String())
 }
@@ -58,3 +58,22 @@ func TestRenderAnalysisLocalizesCanonicalDistributionKeys(t *testing.T) {
 	assert.Contains(t, out.String(), "Deep Focus (>1h)")
 	assert.Contains(t, out.String(), "3")
 }
+
+func TestRenderAnalysisProjectsRollsUpToDepth(t *testing.T) {
+	tree := insights.BuildProjectTree(map[string]time.Duration{
+		"acme/api":   3 * time.Hour,
+		"acme/web":   time.Hour,
+		"personal":   2 * time.Hour,
+		"acme/api/x": time.Hour,
+	}, "/")
+	insights.SortProjectTreeByDuration(tree)
+
+	var out bytes.Buffer
+	err := renderAnalysisProjects(&out, tree, 1, 7*time.Hour, &config.Config{}, localization.MustNew(localization.LanguageEnglish))
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Projects")
+	assert.Contains(t, out.String(), "acme")
+	assert.Contains(t, out.String(), "5h0m0s")
+	assert.Contains(t, out.String(), "71.4%")
+	assert.NotContains(t, out.String(), "api")
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cmdAudit,
+		Short: "Keep a tamper-evident log of changes",
+		Long:  defaultText("audit.long"),
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "init",
+		Short: defaultText("audit.init.short"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runAuditInitCmd(cmd) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "verify",
+		Short: defaultText("audit.verify.short"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runAuditVerifyCmd(cmd) },
+	})
+	return cmd
+}
+
+func runAuditInitCmd(cmd *cobra.Command) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	record, err := rt.Audit.Init(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(out, text(cmd, "audit.init.done", rt.Audit.Path(), record.Hash))
+	if !rt.Config.Audit.Enabled {
+		fmt.Fprint(out, text(cmd, "audit.init.enable_hint"))
+	}
+	return nil
+}
+
+func runAuditVerifyCmd(cmd *cobra.Command) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	result, err := rt.Audit.Verify(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	head := result.Head
+	if result.BrokenAt != 0 {
+		fmt.Fprint(out, text(cmd, "audit.verify.broken", result.BrokenAt))
+	}
+	if result.DataChanged {
+		fmt.Fprint(out, text(cmd, "audit.verify.data_changed", head.Seq, head.Time.Local().Format("2006-01-02 15:04")))
+	}
+	if !result.OK() {
+		return errors.New(defaultText("audit.verify.error.failed"))
+	}
+
+	fmt.Fprint(out, text(cmd, "audit.verify.ok", result.Records, head.Hash))
+	return nil
+}
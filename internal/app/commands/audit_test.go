@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/audit"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func TestRunAuditCmds(t *testing.T) {
+	activities := []models.Activity{mergeTestActivity("api", "tests", 9, 10)}
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return activities, nil },
+	}
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).Audit = audit.New(filepath.Join(t.TempDir(), "audit.jsonl"), service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runAuditInitCmd(cmd))
+	assert.Contains(t, out.String(), "Started audit log")
+	assert.Contains(t, out.String(), "Set audit.enabled: true")
+
+	out.Reset()
+	require.NoError(t, runAuditVerifyCmd(cmd))
+	assert.Contains(t, out.String(), "Audit log OK: 1 records, data matches")
+
+	activities[0].Description = "edited by hand"
+	out.Reset()
+	err := runAuditVerifyCmd(cmd)
+	require.ErrorContains(t, err, "audit verification failed")
+	assert.Contains(t, out.String(), "Activities were changed outside tock after record #1")
+}
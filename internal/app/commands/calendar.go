@@ -12,6 +12,7 @@ import (
 
 	"github.com/kriuchkov/tock/internal/app/insights"
 	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/app/projects"
 	"github.com/kriuchkov/tock/internal/config"
 	"github.com/kriuchkov/tock/internal/core/models"
 	"github.com/kriuchkov/tock/internal/core/ports"
@@ -32,20 +33,48 @@ var runCalendarProgram = func(model calendarModel) error {
 	return nil
 }
 
+type calendarOptions struct {
+	Date   string
+	Period string
+	Since  string
+}
+
 func NewCalendarCmd() *cobra.Command {
+	var opts calendarOptions
+
 	cmd := &cobra.Command{
 		Use:   "calendar",
 		Short: "Show interactive calendar view",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runCalendarCmd(cmd)
+			return runCalendarCmd(cmd, &opts)
 		},
 	}
+
+	cmd.Flags().StringVar(&opts.Date, "date", "", defaultText("calendar.flag.date"))
+	addPeriodFlags(cmd, &opts.Period, &opts.Since)
 	return cmd
 }
 
-func runCalendarCmd(cmd *cobra.Command) error {
+func runCalendarCmd(cmd *cobra.Command, opts *calendarOptions) error {
 	rt := getRuntime(cmd)
-	return runCalendarProgram(initialCalendarModel(rt.ActivityService, rt.Config, rt.TimeFormatter, getLocalizer(cmd), rt.TagColors))
+	model := initialCalendarModel(rt.ActivityService, rt.Config, rt.TimeFormatter, getLocalizer(cmd), rt.TagColors)
+	model.catalog = rt.Catalog
+
+	// The calendar opens on the first day of the selected date or range.
+	if opts.Date != "" || opts.Period != "" || opts.Since != "" {
+		filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{
+			Date:          opts.Date,
+			Period:        opts.Period,
+			Since:         opts.Since,
+			TimeFormatter: rt.TimeFormatter,
+		})
+		if err != nil {
+			return err
+		}
+		model.currentDate = *filter.FromDate
+		model.viewDate = *filter.FromDate
+	}
+	return runCalendarProgram(model)
 }
 
 type calendarModel struct {
@@ -56,6 +85,15 @@ type calendarModel struct {
 	viewDate     time.Time              // The month currently being viewed
 	monthReports map[int]*models.Report // Cache for daily reports in the month (day -> report)
 	dailyReports map[string]*models.Report
+	activities   []models.Activity // Activities fetched for the month window, before splitting by day
+	selected     int               // Index of the selected entry within the current day
+	selectedDay  string            // Day the selection belongs to; changing days resets it
+	selectedLine int               // Viewport line where the selected entry starts
+	mode         calendarMode
+	form         activityFormModel
+	formTarget   *models.Activity  // Activity being edited; nil when adding
+	catalog      *projects.Catalog // Checks the projects entered in the form; nil allows all
+	status       string
 	viewport     viewport.Model
 	ready        bool
 	width        int
@@ -74,20 +112,7 @@ func initialCalendarModel(
 	tagColors map[string]models.TagColor,
 ) calendarModel {
 	now := time.Now()
-	theme := GetTheme(cfg.Theme)
-	for tag, tc := range tagColors {
-		if theme.TagColors == nil {
-			theme.TagColors = make(map[string]TagColorStyle, len(tagColors))
-		}
-		ts := TagColorStyle{}
-		if tc.FG != "" {
-			ts.FG = lipgloss.Color(tc.FG)
-		}
-		if tc.BG != "" {
-			ts.BG = lipgloss.Color(tc.BG)
-		}
-		theme.TagColors[tag] = ts
-	}
+	theme := withTagColors(GetTheme(cfg.Theme), tagColors)
 	return calendarModel{
 		service:      service,
 		config:       cfg,
@@ -146,8 +171,16 @@ func (m *calendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case monthDataMsg:
 		m.monthReports = msg.monthReports
 		m.dailyReports = msg.dailyReports
+		m.activities = msg.activities
 		m.updateViewportContent()
 
+	case activityChangedMsg:
+		m.status = msg.status
+		if msg.err != nil {
+			m.status = msg.err.Error()
+		}
+		return m, m.fetchMonthData
+
 	case errMsg:
 		m.err = msg.err
 	}
@@ -189,18 +222,15 @@ func (m *calendarModel) renderCalendar() string {
 	b.WriteString(m.styles.Header.Render(header) + "\n\n")
 
 	// Weekday headers
-	for _, weekday := range localizedWeekdayShortNames(m.loc) {
+	weekStart := m.timeFormat.Calendar().WeekStart
+	for _, weekday := range localizedWeekdayShortNames(m.loc, weekStart) {
 		b.WriteString(m.styles.Weekday.Render(weekday))
 	}
 	b.WriteString("\n")
 
 	// Calendar grid
 	firstDay := time.Date(m.viewDate.Year(), m.viewDate.Month(), 1, 0, 0, 0, 0, time.Local)
-	weekday := int(firstDay.Weekday())
-	if weekday == 0 {
-		weekday = 7
-	}
-	weekday-- // Mon=0
+	weekday := (int(firstDay.Weekday()) - int(weekStart) + 7) % 7 // column of the first day
 
 	// Padding
 	for range weekday {
@@ -269,13 +299,18 @@ func (m *calendarModel) renderDetails() string {
 		detailsWidth = m.width - 4
 	}
 
+	content := m.viewport.View()
+	if m.mode == calendarModeForm {
+		content = m.form.View()
+	}
+
 	return lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(m.theme.Faint).
 		Padding(0, 1).
 		Width(detailsWidth).
 		Height(m.height - 2).
-		Render(m.viewport.View())
+		Render(content)
 }
 
 func (m *calendarModel) updateViewportContent() {
@@ -284,7 +319,8 @@ func (m *calendarModel) updateViewportContent() {
 	var b strings.Builder
 
 	dateStr := formatLocalizedLongDate(m.loc, m.currentDate)
-	b.WriteString(m.styles.DetailsHeader.Render(dateStr) + "\n\n")
+	b.WriteString(m.styles.DetailsHeader.Render(dateStr) + "\n")
+	b.WriteString(m.renderStatusLine() + "\n")
 
 	if !ok || report == nil || report.TotalDuration == 0 {
 		b.WriteString(lipgloss.NewStyle().Foreground(m.styles.Weekday.GetForeground()).Render(m.loc.Text("calendar.no_events")))
@@ -292,14 +328,17 @@ func (m *calendarModel) updateViewportContent() {
 		return
 	}
 
-	activities := make([]models.Activity, len(report.Activities))
-	copy(activities, report.Activities)
-	sort.Slice(activities, func(i, j int) bool {
-		return activities[i].StartTime.Before(activities[j].StartTime)
-	})
-
+	activities := m.dayActivities()
+	if day := insights.DateKey(m.currentDate); day != m.selectedDay {
+		m.selectedDay = day
+		m.selected = 0
+	}
+	m.selected = min(max(m.selected, 0), len(activities)-1)
 	for i, act := range activities {
-		m.renderActivityEntry(&b, act, i == len(activities)-1)
+		if i == m.selected {
+			m.selectedLine = strings.Count(b.String(), "\n")
+		}
+		m.renderActivityEntry(&b, act, i == len(activities)-1, i == m.selected)
 	}
 
 	totalFormat := m.config.Calendar.TimeTotalFormat
@@ -418,7 +457,7 @@ func (m *calendarModel) renderTagLine(act models.Activity) string {
 }
 
 // renderActivityEntry writes one activity block (rows 1–4 + spacer) into b.
-func (m *calendarModel) renderActivityEntry(b *strings.Builder, act models.Activity, isLast bool) {
+func (m *calendarModel) renderActivityEntry(b *strings.Builder, act models.Activity, isLast, isSelected bool) {
 	startFormat := m.config.Calendar.TimeStartFormat
 	if startFormat == "" {
 		startFormat = m.timeFormat.GetDisplayFormat()
@@ -437,11 +476,16 @@ func (m *calendarModel) renderActivityEntry(b *strings.Builder, act models.Activ
 		}
 	}
 
+	dot := m.styles.Dot.Render("●")
+	if isSelected {
+		dot = lipgloss.NewStyle().Foreground(m.theme.Highlight).Bold(true).Render("▶")
+	}
+
 	// Row 1: time | dot | project [tags]
 	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
 		m.styles.Time.Width(9).Align(lipgloss.Right).Render(start),
 		"  ",
-		m.styles.Dot.Render("●"),
+		dot,
 		"  ",
 		m.renderTagLine(act),
 	) + "\n")
@@ -553,6 +597,7 @@ func wrapText(text string, maxWidth int) []string {
 type monthDataMsg struct {
 	monthReports map[int]*models.Report
 	dailyReports map[string]*models.Report
+	activities   []models.Activity
 }
 
 type errMsg struct{ err error }
@@ -582,7 +627,7 @@ func (m *calendarModel) fetchMonthData() tea.Msg {
 	}
 
 	data := insights.BuildMonthData(report.Activities, year, month, time.Now())
-	return monthDataMsg{monthReports: data.MonthReports, dailyReports: data.DailyReports}
+	return monthDataMsg{monthReports: data.MonthReports, dailyReports: data.DailyReports, activities: report.Activities}
 }
 
 func (m *calendarModel) reportForDate(date time.Time) (*models.Report, bool) {
@@ -593,6 +638,10 @@ func (m *calendarModel) reportForDate(date time.Time) (*models.Report, bool) {
 // getWeeklyDuration calculates the total duration for the current week (Monday to Sunday)
 // based on the selected date. Fetches directly from service to handle cross-month weeks.
 func (m *calendarModel) handleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if cmd, handled := m.handleEditKeyMsg(msg); handled {
+		return cmd, true
+	}
+
 	switch msg.String() {
 	case "q", "ctrl+c", "esc":
 		return tea.Quit, true
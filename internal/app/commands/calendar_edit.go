@@ -0,0 +1,333 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-faster/errors"
+
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const calendarDefaultAddHour = 9
+
+type calendarMode int
+
+const (
+	calendarModeBrowse calendarMode = iota
+	calendarModeForm
+	calendarModeConfirmDelete
+)
+
+// activityChangedMsg reports the outcome of a change made from the calendar.
+// The month data is refetched after every change, successful or not.
+type activityChangedMsg struct {
+	status string
+	err    error
+}
+
+// handleEditKeyMsg handles selection and editing keys. While a form or a
+// delete confirmation is open it consumes every key.
+func (m *calendarModel) handleEditKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch m.mode {
+	case calendarModeForm:
+		return m.handleFormKey(msg), true
+	case calendarModeConfirmDelete:
+		return m.handleConfirmDeleteKey(msg), true
+	case calendarModeBrowse:
+	}
+
+	switch msg.String() {
+	case "tab":
+		m.moveSelection(1)
+		return nil, true
+	case "shift+tab":
+		m.moveSelection(-1)
+		return nil, true
+	case "a":
+		m.openForm(nil)
+		return nil, true
+	}
+
+	act, ok := m.selectedActivity()
+	if !ok {
+		return nil, false
+	}
+
+	switch msg.String() {
+	case "e":
+		stored, err := m.storedActivity(act)
+		if err != nil {
+			m.status = err.Error()
+			m.updateViewportContent()
+			return nil, true
+		}
+		m.openForm(&stored)
+		return nil, true
+	case "d":
+		m.mode = calendarModeConfirmDelete
+		m.updateViewportContent()
+		return nil, true
+	case "c":
+		m.focusDate(time.Now())
+		return m.continueActivity(act), true
+	}
+	return nil, false
+}
+
+func (m *calendarModel) handleFormKey(msg tea.KeyMsg) tea.Cmd {
+	m.form.handleKey(msg)
+	if !m.form.canceled && !m.form.submitted {
+		return nil
+	}
+
+	target := m.formTarget
+	m.mode = calendarModeBrowse
+	m.formTarget = nil
+	if m.form.canceled {
+		m.updateViewportContent()
+		return nil
+	}
+
+	req, err := m.form.request()
+	if err != nil {
+		m.status = err.Error()
+		m.updateViewportContent()
+		return nil
+	}
+
+	// A project that an edit keeps is not checked again, so activities from
+	// before the catalog can still be edited.
+	if target == nil || req.Project != target.Project {
+		if req.Project, err = resolveCatalogProject(m.catalog, m.loc, req.Project); err != nil {
+			m.status = err.Error()
+			m.updateViewportContent()
+			return nil
+		}
+	}
+
+	// An empty end time starts a timer, which only makes sense from today on.
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if target == nil && req.EndTime == nil && req.StartTime.Before(today) {
+		m.status = m.loc.Text("calendar.error.end_required")
+		m.updateViewportContent()
+		return nil
+	}
+
+	m.focusDate(req.StartTime)
+	if target != nil {
+		return m.editActivity(*target, req)
+	}
+	return m.addActivity(req)
+}
+
+func (m *calendarModel) handleConfirmDeleteKey(msg tea.KeyMsg) tea.Cmd {
+	m.mode = calendarModeBrowse
+	act, ok := m.selectedActivity()
+	if !ok || msg.String() != "y" {
+		m.updateViewportContent()
+		return nil
+	}
+
+	return func() tea.Msg {
+		stored, err := m.storedActivity(act)
+		if err != nil {
+			return activityChangedMsg{err: err}
+		}
+		if err = m.service.Remove(context.Background(), stored); err != nil {
+			return activityChangedMsg{err: errors.Wrap(err, "remove activity")}
+		}
+		return activityChangedMsg{status: m.loc.Format("calendar.status.removed", act.Project, act.Description)}
+	}
+}
+
+func (m *calendarModel) editActivity(target models.Activity, req models.EditActivityRequest) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := m.service.Edit(context.Background(), target, req)
+		if err != nil {
+			return activityChangedMsg{err: errors.Wrap(err, "edit activity")}
+		}
+		return activityChangedMsg{status: m.loc.Format("calendar.status.updated", updated.Project, updated.Description)}
+	}
+}
+
+// addActivity adds a finished activity, or starts a new one when the form's
+// end time was left empty on today's date.
+func (m *calendarModel) addActivity(req models.EditActivityRequest) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			added *models.Activity
+			err   error
+		)
+		if req.EndTime == nil {
+			added, err = m.service.Start(context.Background(), models.StartActivityRequest{
+				Description: req.Description,
+				Project:     req.Project,
+				StartTime:   req.StartTime,
+				Notes:       req.Notes,
+				Tags:        req.Tags,
+			})
+		} else {
+			added, err = m.service.Add(context.Background(), models.AddActivityRequest{
+				Description: req.Description,
+				Project:     req.Project,
+				StartTime:   req.StartTime,
+				EndTime:     *req.EndTime,
+				Notes:       req.Notes,
+				Tags:        req.Tags,
+			})
+		}
+		if err != nil {
+			return activityChangedMsg{err: errors.Wrap(err, "add activity")}
+		}
+		return activityChangedMsg{status: m.loc.Format("calendar.status.added", added.Project, added.Description)}
+	}
+}
+
+func (m *calendarModel) continueActivity(act models.Activity) tea.Cmd {
+	return func() tea.Msg {
+		started, err := m.service.Start(context.Background(), models.StartActivityRequest{
+			Description: act.Description,
+			Project:     act.Project,
+			StartTime:   time.Now(),
+		})
+		if err != nil {
+			return activityChangedMsg{err: errors.Wrap(err, "start activity")}
+		}
+		return activityChangedMsg{status: m.loc.Format("calendar.status.started", started.Project, started.Description)}
+	}
+}
+
+func (m *calendarModel) openForm(target *models.Activity) {
+	title := m.loc.Format("calendar.form.add", formatLocalizedLongDate(m.loc, m.currentDate))
+	act := m.newActivityTemplate()
+	if target != nil {
+		title = m.loc.Text("calendar.form.edit")
+		act = *target
+	}
+
+	m.form = newActivityFormModel(title, act, m.timeFormat, m.theme)
+	m.formTarget = target
+	m.mode = calendarModeForm
+	m.status = ""
+}
+
+// newActivityTemplate prefills an added entry right after the last activity of
+// the selected day, or at the start of the working day when there is no room.
+func (m *calendarModel) newActivityTemplate() models.Activity {
+	start := time.Date(m.currentDate.Year(), m.currentDate.Month(), m.currentDate.Day(),
+		calendarDefaultAddHour, 0, 0, 0, time.Local)
+	nextDay := time.Date(m.currentDate.Year(), m.currentDate.Month(), m.currentDate.Day()+1, 0, 0, 0, 0, time.Local)
+	if day := m.dayActivities(); len(day) > 0 {
+		if last := day[len(day)-1]; last.EndTime != nil && last.EndTime.Before(nextDay) {
+			start = *last.EndTime
+		}
+	}
+	end := start.Add(time.Hour)
+	return models.Activity{StartTime: start, EndTime: &end}
+}
+
+func (m *calendarModel) moveSelection(delta int) {
+	count := len(m.dayActivities())
+	if count == 0 {
+		return
+	}
+	m.selected = (m.selected + delta + count) % count
+	m.status = ""
+	m.updateViewportContent()
+
+	if m.selectedLine < m.viewport.YOffset || m.selectedLine >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(m.selectedLine)
+	}
+}
+
+func (m *calendarModel) focusDate(date time.Time) {
+	m.currentDate = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	if m.currentDate.Year() != m.viewDate.Year() || m.currentDate.Month() != m.viewDate.Month() {
+		m.viewDate = m.currentDate
+	}
+}
+
+// dayActivities returns the selected day's entries in display order. Entries
+// are clipped to the day, so use selectedActivity to get the stored activity.
+func (m *calendarModel) dayActivities() []models.Activity {
+	report, ok := m.reportForDate(m.currentDate)
+	if !ok || report == nil {
+		return nil
+	}
+	return models.SortActivitiesByStart(report.Activities)
+}
+
+// selectedActivity returns the full activity behind the selected entry. An
+// entry that crosses midnight is split per day, so the fetched activity that
+// covers it is looked up instead of using the clipped copy.
+func (m *calendarModel) selectedActivity() (models.Activity, bool) {
+	day := m.dayActivities()
+	if m.selected < 0 || m.selected >= len(day) {
+		return models.Activity{}, false
+	}
+	segment := day[m.selected]
+
+	var original *models.Activity
+	for i, act := range m.activities {
+		if act.Project != segment.Project || act.Description != segment.Description {
+			continue
+		}
+		if act.StartTime.After(segment.StartTime) || (act.EndTime != nil && act.EndTime.Before(segment.StartTime)) {
+			continue
+		}
+		if original == nil || act.StartTime.After(original.StartTime) {
+			original = &m.activities[i]
+		}
+	}
+	if original == nil {
+		return segment, true
+	}
+	return *original, true
+}
+
+// storedActivity looks up the activity as stored. The calendar shows a report
+// clipped to the fetched weeks, so an activity crossing their edges carries
+// clipped times that would miss, or hit the wrong, stored entry.
+func (m *calendarModel) storedActivity(act models.Activity) (models.Activity, error) {
+	from := act.StartTime
+	to := from.Add(time.Minute)
+	activities, err := m.service.List(context.Background(), models.ActivityFilter{FromDate: &from, ToDate: &to})
+	if err != nil {
+		return models.Activity{}, errors.Wrap(err, "find activity")
+	}
+
+	var stored *models.Activity
+	for i, candidate := range activities {
+		if candidate.Project != act.Project || candidate.Description != act.Description {
+			continue
+		}
+		if candidate.StartTime.After(from) {
+			continue
+		}
+		if stored == nil || candidate.StartTime.After(stored.StartTime) {
+			stored = &activities[i]
+		}
+	}
+	if stored == nil {
+		return models.Activity{}, errors.Wrapf(coreErrors.ErrActivityNotFound, "%s: %s", act.Project, act.Description)
+	}
+	return *stored, nil
+}
+
+func (m *calendarModel) renderStatusLine() string {
+	if m.mode == calendarModeConfirmDelete {
+		if act, ok := m.selectedActivity(); ok {
+			return lipgloss.NewStyle().Foreground(m.theme.Highlight).Bold(true).
+				Render(m.loc.Format("calendar.confirm_delete", act.Project, act.Description))
+		}
+	}
+	if m.status == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(m.theme.SubText).Render(m.status)
+}
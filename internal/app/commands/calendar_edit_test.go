@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/app/projects"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func calendarEditFixture() []models.Activity {
+	day := time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local)
+	end1 := day.Add(10 * time.Hour)
+	end2 := day.Add(26 * time.Hour) // crosses midnight
+	return []models.Activity{
+		{Project: "tock", Description: "planning", StartTime: day.Add(9 * time.Hour), EndTime: &end1},
+		{Project: "ops", Description: "on call", StartTime: day.Add(22 * time.Hour), EndTime: &end2},
+	}
+}
+
+func newCalendarEditModel(t *testing.T, service *stubActivityResolver) calendarModel {
+	t.Helper()
+	if service.getReportFn == nil {
+		service.getReportFn = func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{Activities: calendarEditFixture()}, nil
+		}
+	}
+	if service.listFn == nil {
+		service.listFn = func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return calendarEditFixture(), nil
+		}
+	}
+
+	model := initialCalendarModel(service, &config.Config{}, timeutil.NewFormatter("24"),
+		localization.MustNew(localization.LanguageEnglish), nil)
+	model.width = 100
+	model.height = 40
+	model.ready = true
+	model.viewport = viewport.New(60, 30)
+	model.viewDate = time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local)
+	model.currentDate = model.viewDate
+
+	msg := model.fetchMonthData()
+	model.Update(msg)
+	return model
+}
+
+func keyPress(s string) tea.KeyMsg {
+	switch s {
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "ctrl+s":
+		return tea.KeyMsg{Type: tea.KeyCtrlS}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestCalendarSelectionUsesFullActivityForSplitEntries(t *testing.T) {
+	model := newCalendarEditModel(t, &stubActivityResolver{})
+
+	act, ok := model.selectedActivity()
+	require.True(t, ok)
+	assert.Equal(t, "planning", act.Description)
+
+	model.handleKeyMsg(keyPress("tab"))
+	act, ok = model.selectedActivity()
+	require.True(t, ok)
+	assert.Equal(t, "on call", act.Description)
+
+	model.handleKeyMsg(keyPress("l"))
+	act, ok = model.selectedActivity()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.April, 6, 22, 0, 0, 0, time.Local), act.StartTime,
+		"next-day segment resolves to the stored activity")
+}
+
+func TestCalendarEditSubmitsThroughService(t *testing.T) {
+	var (
+		got    models.Activity
+		gotReq models.EditActivityRequest
+	)
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		editFn: func(_ context.Context, act models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+			got, gotReq = act, req
+			return &models.Activity{Project: req.Project, Description: req.Description}, nil
+		},
+	})
+
+	cmd, handled := model.handleKeyMsg(keyPress("e"))
+	require.True(t, handled)
+	assert.Nil(t, cmd)
+	assert.Equal(t, calendarModeForm, model.mode)
+	assert.Contains(t, model.renderDetails(), "Edit activity")
+
+	model.handleKeyMsg(keyPress("q"))
+	assert.Equal(t, calendarModeForm, model.mode, "keys go to the form while it is open")
+	model.handleKeyMsg(keyPress("ctrl+s"))
+	assert.Equal(t, calendarModeBrowse, model.mode)
+
+	cmd, _ = model.handleKeyMsg(keyPress("ctrl+s"))
+	assert.Nil(t, cmd)
+
+	model.handleKeyMsg(keyPress("e"))
+	model.form.values[formFieldDescription] = "roadmap"
+	cmd, _ = model.handleKeyMsg(keyPress("ctrl+s"))
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	changed, ok := msg.(activityChangedMsg)
+	require.True(t, ok)
+	require.NoError(t, changed.err)
+	assert.Equal(t, "planning", got.Description)
+	assert.Equal(t, "roadmap", gotReq.Description)
+
+	_, refresh := model.Update(msg)
+	require.NotNil(t, refresh)
+	assert.Equal(t, "Updated tock | roadmap", model.status)
+}
+
+func TestCalendarEditUsesStoredActivityForClippedEntries(t *testing.T) {
+	// The report is clipped to the fetched weeks; the stored activity started
+	// before them and ends after them.
+	windowStart := time.Date(2026, time.March, 18, 0, 0, 0, 0, time.Local)
+	windowEnd := time.Date(2026, time.May, 15, 0, 0, 0, 0, time.Local)
+	storedEnd := windowEnd.Add(time.Hour)
+	stored := models.Activity{
+		Project: "ops", Description: "migration",
+		StartTime: windowStart.Add(-time.Hour), EndTime: &storedEnd,
+	}
+	clipped := stored
+	clipped.StartTime = windowStart
+	clipped.EndTime = &windowEnd
+
+	var edited, removed models.Activity
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{Activities: []models.Activity{clipped}}, nil
+		},
+		listFn: func(_ context.Context, filter models.ActivityFilter) ([]models.Activity, error) {
+			assert.Equal(t, windowStart, *filter.FromDate)
+			return []models.Activity{stored}, nil
+		},
+		editFn: func(_ context.Context, act models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+			edited = act
+			return &models.Activity{Project: req.Project, Description: req.Description}, nil
+		},
+		removeFn: func(_ context.Context, act models.Activity) error {
+			removed = act
+			return nil
+		},
+	})
+	model.currentDate = windowStart
+	model.viewDate = windowStart
+	model.updateViewportContent()
+
+	model.handleKeyMsg(keyPress("e"))
+	require.Equal(t, calendarModeForm, model.mode)
+	assert.Equal(t, "2026-03-17 23:00", model.form.values[formFieldStart])
+	cmd, _ := model.handleKeyMsg(keyPress("ctrl+s"))
+	require.NotNil(t, cmd)
+	cmd()
+	assert.Equal(t, stored.StartTime, edited.StartTime)
+	assert.Equal(t, storedEnd, *edited.EndTime)
+
+	model.currentDate = windowStart
+	model.handleKeyMsg(keyPress("d"))
+	cmd, _ = model.handleKeyMsg(keyPress("y"))
+	require.NotNil(t, cmd)
+	cmd()
+	assert.Equal(t, stored.StartTime, removed.StartTime)
+}
+
+func TestCalendarAddUsesSelectedDay(t *testing.T) {
+	var got models.AddActivityRequest
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		addFn: func(_ context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+			got = req
+			return &models.Activity{Project: req.Project, Description: req.Description}, nil
+		},
+	})
+
+	model.handleKeyMsg(keyPress("l"))
+	model.handleKeyMsg(keyPress("a"))
+	require.Equal(t, calendarModeForm, model.mode)
+	assert.Contains(t, model.form.title, "07 April 2026")
+	assert.Nil(t, model.formTarget)
+	model.form.values[formFieldProject] = "tock"
+	model.form.values[formFieldDescription] = "review"
+
+	cmd, _ := model.handleKeyMsg(keyPress("ctrl+s"))
+	require.NotNil(t, cmd)
+	changed, ok := cmd().(activityChangedMsg)
+	require.True(t, ok)
+	require.NoError(t, changed.err)
+
+	assert.Equal(t, time.Date(2026, time.April, 7, 2, 0, 0, 0, time.Local), got.StartTime,
+		"new entry starts where the day's last activity ended")
+	assert.Equal(t, time.Hour, got.EndTime.Sub(got.StartTime))
+}
+
+func TestCalendarFormChecksNewProjectsAgainstCatalog(t *testing.T) {
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		addFn: func(context.Context, models.AddActivityRequest) (*models.Activity, error) {
+			t.Fatal("a project outside the catalog must not be added")
+			return nil, nil
+		},
+	})
+	model.catalog = projects.NewCatalog([]string{"tock"}, nil, true)
+
+	model.handleKeyMsg(keyPress("a"))
+	model.form.values[formFieldProject] = "tokc"
+	model.form.values[formFieldDescription] = "review"
+
+	cmd, _ := model.handleKeyMsg(keyPress("ctrl+s"))
+	assert.Nil(t, cmd)
+	assert.Equal(t, calendarModeBrowse, model.mode)
+	assert.Equal(t, `project "tokc" is not in the project catalog: did you mean "tock"?`, model.status)
+}
+
+func TestCalendarAddRequiresEndTimeOnPastDays(t *testing.T) {
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		startFn: func(context.Context, models.StartActivityRequest) (*models.Activity, error) {
+			t.Fatal("a past entry must not start a timer")
+			return nil, nil
+		},
+	})
+
+	model.handleKeyMsg(keyPress("a"))
+	model.form.values[formFieldProject] = "tock"
+	model.form.values[formFieldDescription] = "review"
+	model.form.values[formFieldEnd] = ""
+
+	cmd, _ := model.handleKeyMsg(keyPress("ctrl+s"))
+	assert.Nil(t, cmd)
+	assert.Equal(t, calendarModeBrowse, model.mode)
+	assert.Contains(t, model.status, "Set an end time")
+}
+
+func TestCalendarDeleteRequiresConfirmation(t *testing.T) {
+	removed := 0
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		removeFn: func(context.Context, models.Activity) error {
+			removed++
+			return nil
+		},
+	})
+
+	model.handleKeyMsg(keyPress("d"))
+	assert.Equal(t, calendarModeConfirmDelete, model.mode)
+	assert.Contains(t, model.viewport.View(), "Delete tock | planning? (y/n)")
+
+	cmd, _ := model.handleKeyMsg(keyPress("n"))
+	assert.Nil(t, cmd)
+	assert.Equal(t, calendarModeBrowse, model.mode)
+
+	model.handleKeyMsg(keyPress("d"))
+	cmd, _ = model.handleKeyMsg(keyPress("y"))
+	require.NotNil(t, cmd)
+	changed, ok := cmd().(activityChangedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "Removed tock | planning", changed.status)
+	assert.Equal(t, 1, removed)
+}
+
+func TestCalendarContinueStartsSelectedActivity(t *testing.T) {
+	var got models.StartActivityRequest
+	model := newCalendarEditModel(t, &stubActivityResolver{
+		startFn: func(_ context.Context, req models.StartActivityRequest) (*models.Activity, error) {
+			got = req
+			return &models.Activity{Project: req.Project, Description: req.Description}, nil
+		},
+	})
+
+	model.handleKeyMsg(keyPress("tab"))
+	cmd, handled := model.handleKeyMsg(keyPress("c"))
+	require.True(t, handled)
+	require.NotNil(t, cmd)
+	cmd()
+
+	assert.Equal(t, "ops", got.Project)
+	assert.Equal(t, "on call", got.Description)
+	assert.True(t, sameDay(model.currentDate, time.Now()))
+}
+
+func TestCalendarEditKeysIgnoredOnEmptyDay(t *testing.T) {
+	model := newCalendarEditModel(t, &stubActivityResolver{})
+	model.currentDate = time.Date(2026, time.April, 20, 0, 0, 0, 0, time.Local)
+	model.updateViewportContent()
+
+	for _, key := range []string{"e", "d", "c"} {
+		_, handled := model.handleKeyMsg(keyPress(key))
+		assert.False(t, handled, key)
+	}
+	assert.Equal(t, calendarModeBrowse, model.mode)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -55,7 +55,7 @@ func (m *calendarModel) renderProductivityStats() string {
 	b.WriteString(m.styles.Header.Width(40).Render(m.loc.Text("calendar.sidebar.productivity")) + "\n\n")
 	daysInMonth := time.Date(m.viewDate.Year(), m.viewDate.Month()+1, 0, 0, 0, 0, 0, time.Local).Day()
 	stats := insights.ComputeProductivityStats(m.monthReports, daysInMonth)
-	weekly := insights.BuildWeeklyActivityData(m.dailyReports, m.currentDate)
+	weekly := insights.BuildWeeklyActivityData(m.dailyReports, m.currentDate, m.timeFormat.Calendar().WeekStart)
 
 	fmt.Fprintf(&b, m.loc.Text("calendar.sidebar.total"), m.styles.Duration.Render(stats.TotalDuration.Round(time.Minute).String()))
 	fmt.Fprintf(&b, m.loc.Text("calendar.sidebar.avg_day"), m.styles.Duration.Render(stats.AvgDuration.Round(time.Minute).String()))
@@ -91,7 +91,7 @@ func (m *calendarModel) renderProductivityStats() string {
 
 func (m *calendarModel) renderWeeklyActivity() string {
 	var b strings.Builder
-	weekly := insights.BuildWeeklyActivityData(m.dailyReports, m.currentDate)
+	weekly := insights.BuildWeeklyActivityData(m.dailyReports, m.currentDate, m.timeFormat.Calendar().WeekStart)
 
 	b.WriteString(m.styles.Header.Width(40).Render(m.loc.Text("calendar.sidebar.weekly_activity")) + "\n\n")
 
@@ -173,19 +173,26 @@ func (m *calendarModel) renderTopProjects(maxHeight int) string {
 	// Top Projects
 	b.WriteString(m.styles.Header.Width(40).Render(m.loc.Text("calendar.sidebar.top_projects")) + "\n")
 
-	projects := insights.AggregateProjectDurations(m.monthReports)
+	durations := make(map[string]time.Duration)
+	for _, project := range insights.AggregateProjectDurations(m.monthReports) {
+		durations[project.Name] = project.Duration
+	}
+	tree := insights.BuildProjectTree(durations, m.config.Projects.Separator)
+	insights.SortProjectTreeByDuration(tree)
 
 	maxProjDuration := time.Duration(0)
-	if len(projects) > 0 {
-		maxProjDuration = projects[0].Duration
+	if len(tree) > 0 {
+		maxProjDuration = tree[0].Duration
 	}
 
 	maxProjects := min((maxHeight-1)/3, 5)
 
-	for i, project := range projects {
-		if i >= maxProjects {
-			break
+	shown := 0
+	insights.WalkProjectTree(tree, 0, func(project *insights.ProjectNode, depth int, _ bool) {
+		if shown >= maxProjects {
+			return
 		}
+		shown++
 
 		bar := ""
 		if maxProjDuration > 0 {
@@ -197,11 +204,16 @@ func (m *calendarModel) renderTopProjects(maxHeight int) string {
 			}
 		}
 
-		fmt.Fprintf(&b, "%s\n", m.tagColorStyle(m.styles.Project, project.Name, tagColorScopeTopProject).Render(project.Name))
-		fmt.Fprintf(&b, "%s %s\n",
-			m.tagBarStyle(lipgloss.NewStyle().Foreground(m.theme.Primary), project.Name, tagColorScopeTopProject).Render(bar),
+		label := project.Path
+		if depth > 1 {
+			label = project.Name
+		}
+		indent := strings.Repeat("  ", depth-1)
+		fmt.Fprintf(&b, "%s%s\n", indent, m.tagColorStyle(m.styles.Project, project.Path, tagColorScopeTopProject).Render(label))
+		fmt.Fprintf(&b, "%s%s %s\n", indent,
+			m.tagBarStyle(lipgloss.NewStyle().Foreground(m.theme.Primary), project.Path, tagColorScopeTopProject).Render(bar),
 			m.styles.Duration.Render(project.Duration.Round(time.Minute).String()))
 		b.WriteString("\n")
-	}
+	})
 	return b.String()
 }
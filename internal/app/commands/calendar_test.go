@@ -33,10 +33,41 @@ func TestRunCalendarCmdInvokesProgram(t *testing.T) {
 	}
 
 	cmd := newTestCLICommand(&stubActivityResolver{})
-	require.NoError(t, runCalendarCmd(cmd))
+	require.NoError(t, runCalendarCmd(cmd, &calendarOptions{}))
 	assert.True(t, called)
 }
 
+func TestRunCalendarCmdOpensOnPeriodStart(t *testing.T) {
+	runner := runCalendarProgram
+	t.Cleanup(func() { runCalendarProgram = runner })
+
+	var opened time.Time
+	runCalendarProgram = func(model calendarModel) error {
+		opened = model.currentDate
+		assert.Equal(t, model.currentDate, model.viewDate)
+		return nil
+	}
+
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	require.NoError(t, runCalendarCmd(cmd, &calendarOptions{Period: "last-month"}))
+	thisMonth := time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, thisMonth.AddDate(0, -1, 0), opened)
+
+	require.Error(t, runCalendarCmd(cmd, &calendarOptions{Period: "last-month", Date: "2026-04-01"}))
+}
+
+func TestCalendarGridFollowsWeekStart(t *testing.T) {
+	loc := localization.MustNew(localization.LanguageEnglish)
+	tf := timeutil.NewFormatter("24").WithCalendar(timeutil.Calendar{WeekStart: time.Sunday})
+	model := initialCalendarModel(&stubActivityResolver{}, &config.Config{}, tf, loc, nil)
+	model.viewDate = time.Date(2026, time.March, 1, 0, 0, 0, 0, time.Local) // a Sunday
+	model.currentDate = model.viewDate
+
+	view := model.renderCalendar()
+	assert.Regexp(t, `Su\s+Mo\s+Tu\s+We\s+Th\s+Fr\s+Sa`, view)
+	assert.Regexp(t, `│\s+1\s+2\s+3\s+4\s+5\s+6\s+7\s+│`, view)
+}
+
 func TestReportModelUpdateViewportContentLocalizedEmptyState(t *testing.T) {
 	loc := localization.MustNew(localization.LanguageEnglish)
 	model := initialCalendarModel(&stubActivityResolver{}, &config.Config{}, timeutil.NewFormatter("24"), loc, nil)
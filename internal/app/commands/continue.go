@@ -37,12 +37,12 @@ func NewContinueCmd() *cobra.Command {
 				return nil, cobra.ShellCompDirectiveNoFileComp
 			}
 
-			svc, err := getServiceForCompletion(cmd)
+			rt, err := getRuntimeForCompletion(cmd)
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
 			}
 
-			activities, err := svc.GetRecent(cmd.Context(), defaultRecentActivitiesForContinuation)
+			activities, err := rt.ActivityService.GetRecent(cmd.Context(), defaultRecentActivitiesForContinuation)
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
 			}
@@ -103,11 +103,15 @@ func runContinueCmd(cmd *cobra.Command, args []string, opts *continueOptions) er
 	if opts.Project != "" {
 		newProject = opts.Project
 	}
+	newProject, err = resolveProject(cmd, newProject)
+	if err != nil {
+		return err
+	}
 
 	startTime := time.Now()
 	if opts.At != "" {
 		var parseErr error
-		startTime, parseErr = tf.ParseTime(opts.At)
+		startTime, parseErr = tf.ParseTimeWithDate(opts.At)
 		if parseErr != nil {
 			return errors.Wrap(parseErr, "parse time")
 		}
@@ -11,10 +11,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
 )
 
 type currentCmdActivity struct {
 	models.Activity
+
+	Key string
 }
 
 type currentOptions struct {
@@ -81,6 +84,11 @@ func runCurrentCmd(cmd *cobra.Command, opt *currentOptions) error {
 		return nil
 	}
 
+	keys, err := runningActivityKeys(ctx, service, activities)
+	if err != nil {
+		return err
+	}
+
 	if opt.Format != "" {
 		parsedTemplate, parseErr := template.New("current").Parse(opt.Format + "\n")
 		if parseErr != nil {
@@ -88,7 +96,8 @@ func runCurrentCmd(cmd *cobra.Command, opt *currentOptions) error {
 		}
 
 		for _, activity := range activities {
-			if err = parsedTemplate.Execute(out, currentCmdActivity{Activity: activity}); err != nil {
+			key := keys[activity.StartTime.UnixNano()]
+			if err = parsedTemplate.Execute(out, currentCmdActivity{Activity: activity, Key: key}); err != nil {
 				return errors.Wrap(err, "execute format template")
 			}
 		}
@@ -102,7 +111,8 @@ func runCurrentCmd(cmd *cobra.Command, opt *currentOptions) error {
 		duration := time.Since(activity.StartTime).Round(time.Second)
 		_, _ = fmt.Fprintf(
 			w,
-			"%s\t%s\t%s\t%s\n",
+			"%s\t%s\t%s\t%s\t%s\n",
+			keys[activity.StartTime.UnixNano()],
 			activity.StartTime.Format(tf.GetDisplayFormatWithDate()),
 			activity.Description,
 			activity.Project,
@@ -115,3 +125,21 @@ func runCurrentCmd(cmd *cobra.Command, opt *currentOptions) error {
 	}
 	return nil
 }
+
+// runningActivityKeys returns the keys of running activities, which number
+// them among all activities of their day, for tock stop KEY.
+func runningActivityKeys(ctx context.Context, service ports.ActivityResolver, running []models.Activity) (map[int64]string, error) {
+	first := running[0].StartTime
+	for _, activity := range running[1:] {
+		if activity.StartTime.Before(first) {
+			first = activity.StartTime
+		}
+	}
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.Local)
+
+	activities, err := service.List(ctx, models.ActivityFilter{FromDate: &from})
+	if err != nil {
+		return nil, errors.Wrap(err, "list activities")
+	}
+	return models.ActivitySequenceIDs(activities), nil
+}
@@ -52,3 +52,33 @@ func TestRunCurrentCmdEmptyWritesNotice(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "No currently running activities.\n", out.String())
 }
+
+func TestRunCurrentCmdShowsKeys(t *testing.T) {
+	day := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.Local)
+	end := day.Add(9 * time.Hour)
+	done := models.Activity{Project: "tock", Description: "standup", StartTime: day.Add(8 * time.Hour), EndTime: &end}
+	onCall := models.Activity{Project: "ops", Description: "on-call", StartTime: day.Add(8 * time.Hour).Add(30 * time.Minute)}
+	focus := models.Activity{Project: "tock", Description: "review", StartTime: day.Add(10 * time.Hour)}
+	service := &stubActivityResolver{
+		listFn: func(_ context.Context, filter models.ActivityFilter) ([]models.Activity, error) {
+			if filter.IsRunning != nil {
+				return []models.Activity{onCall, focus}, nil
+			}
+			require.NotNil(t, filter.FromDate)
+			assert.Equal(t, day, *filter.FromDate)
+			return []models.Activity{done, onCall, focus}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runCurrentCmd(cmd, &currentOptions{Format: "{{.Key}} {{.Description}}"}))
+	assert.Equal(t, "2026-03-14-02 on-call\n2026-03-14-03 review\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runCurrentCmd(cmd, &currentOptions{}))
+	assert.Contains(t, out.String(), "Key")
+	assert.Contains(t, out.String(), "2026-03-14-03")
+}
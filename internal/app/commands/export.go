@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/config"
 	"github.com/kriuchkov/tock/internal/core/models"
 )
 
@@ -23,8 +24,16 @@ type exportOptions struct {
 	Format      string
 	Path        string
 	Stdout      bool
+	Timeline    bool
 	From        string
 	To          string
+	Period      string
+	Since       string
+	Columns     string
+	Delimiter   string
+	Decimal     string
+	Timezone    string
+	Header      bool
 }
 
 func NewExportCmd() *cobra.Command {
@@ -47,11 +56,19 @@ func NewExportCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opt.Format, "fmt", "txt", defaultText("export.flag.format"))
 	cmd.Flags().StringVarP(&opt.Path, "path", "o", "", defaultText("export.flag.path"))
 	cmd.Flags().BoolVar(&opt.Stdout, "stdout", false, defaultText("export.flag.stdout"))
+	cmd.Flags().BoolVar(&opt.Timeline, "timeline", false, defaultText("export.flag.timeline"))
 	cmd.Flags().StringVar(&opt.From, "from", "", defaultText("export.flag.from"))
 	cmd.Flags().StringVar(&opt.To, "to", "", defaultText("export.flag.to"))
+	addPeriodFlags(cmd, &opt.Period, &opt.Since)
+	cmd.Flags().StringVar(&opt.Columns, "columns", "", defaultText("export.flag.columns"))
+	cmd.Flags().StringVar(&opt.Delimiter, "delimiter", "", defaultText("export.flag.delimiter"))
+	cmd.Flags().StringVar(&opt.Decimal, "decimal", "", defaultText("export.flag.decimal"))
+	cmd.Flags().StringVar(&opt.Timezone, "timezone", "", defaultText("export.flag.timezone"))
+	cmd.Flags().BoolVar(&opt.Header, "header", true, defaultText("export.flag.header"))
 
 	_ = cmd.RegisterFlagCompletionFunc("project", projectRegisterFlagCompletion)
 	_ = cmd.RegisterFlagCompletionFunc("description", descriptionRegisterFlagCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("columns", csvColumnsRegisterFlagCompletion)
 	return cmd
 }
 
@@ -60,14 +77,18 @@ func runExportCmd(cmd *cobra.Command, opt *exportOptions) error {
 	out := cmd.OutOrStdout()
 
 	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{
-		Now:         time.Now(),
-		Today:       opt.Today,
-		Yesterday:   opt.Yesterday,
-		Date:        opt.Date,
-		From:        opt.From,
-		To:          opt.To,
-		Project:     opt.Project,
-		Description: opt.Description,
+		Now:              time.Now(),
+		Today:            opt.Today,
+		Yesterday:        opt.Yesterday,
+		Date:             opt.Date,
+		From:             opt.From,
+		To:               opt.To,
+		Project:          opt.Project,
+		ProjectSeparator: rt.Config.Projects.Separator,
+		Description:      opt.Description,
+		Period:           opt.Period,
+		Since:            opt.Since,
+		TimeFormatter:    rt.TimeFormatter,
 	})
 
 	if err != nil {
@@ -80,7 +101,26 @@ func runExportCmd(cmd *cobra.Command, opt *exportOptions) error {
 	}
 
 	format := strings.ToLower(strings.TrimSpace(opt.Format))
-	output, err := exportapp.RenderOutput(format, report, rt.TimeFormatter)
+	render := exportapp.RenderOutput
+	if opt.Timeline {
+		render = exportapp.RenderTimeline
+		if !cmd.Flags().Changed("format") && !cmd.Flags().Changed("fmt") {
+			format = "html"
+		}
+	}
+	renderOpts := []exportapp.Option{
+		exportapp.WithTagColors(rt.TagColors),
+		exportapp.WithTemplatesDir(rt.Config.Export.TemplatesDir),
+	}
+	if format == "csv" {
+		csvOpts, csvErr := exportCSVOptions(cmd, opt, rt.Config.Export.CSV)
+		if csvErr != nil {
+			return csvErr
+		}
+		renderOpts = append(renderOpts, exportapp.WithCSVOptions(csvOpts))
+	}
+
+	output, err := render(format, report, rt.TimeFormatter, renderOpts...)
 	if err != nil {
 		return errors.Wrap(err, "render output")
 	}
@@ -113,6 +153,57 @@ func runExportCmd(cmd *cobra.Command, opt *exportOptions) error {
 	return nil
 }
 
+// exportCSVOptions merges the export.csv config with the command line; flags
+// that were set win over the config.
+func exportCSVOptions(cmd *cobra.Command, opt *exportOptions, cfg config.CSVConfig) (exportapp.CSVOptions, error) {
+	pick := func(flag, configured string) string {
+		if flag != "" {
+			return flag
+		}
+		return configured
+	}
+
+	var result exportapp.CSVOptions
+	var err error
+	if columns := pick(opt.Columns, cfg.Columns); columns != "" {
+		if result.Columns, err = exportapp.ParseCSVColumns(columns); err != nil {
+			return result, errors.Wrap(err, "parse --columns")
+		}
+	}
+	if delimiter := pick(opt.Delimiter, cfg.Delimiter); delimiter != "" {
+		if result.Delimiter, err = exportapp.ParseCSVDelimiter(delimiter); err != nil {
+			return result, errors.Wrap(err, "parse --delimiter")
+		}
+	}
+	if result.Decimal, err = exportapp.ParseCSVDecimal(pick(opt.Decimal, cfg.Decimal)); err != nil {
+		return result, errors.Wrap(err, "parse --decimal")
+	}
+	if timezone := pick(opt.Timezone, cfg.Timezone); timezone != "" {
+		if result.Location, err = time.LoadLocation(timezone); err != nil {
+			return result, errors.Wrapf(err, "load timezone %s", timezone)
+		}
+	}
+
+	header := cfg.Header
+	if cmd.Flags().Changed("header") {
+		header = opt.Header
+	}
+	result.NoHeader = !header
+	return result, nil
+}
+
+func csvColumnsRegisterFlagCompletion(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	completions := make([]string, 0, len(exportapp.CSVColumns))
+	for _, column := range exportapp.CSVColumns {
+		completions = append(completions, prefix+column)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
 func writeExportFile(outputDir, format string, content []byte) (string, error) {
 	if outputDir == "" {
 		return "", errors.New("output path is empty")
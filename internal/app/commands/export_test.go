@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	appruntime "github.com/kriuchkov/tock/internal/app/runtime"
+	"github.com/kriuchkov/tock/internal/config"
 	"github.com/kriuchkov/tock/internal/core/models"
 )
 
@@ -50,6 +51,27 @@ func TestRunExportCmdStdoutUsesCommandWriter(t *testing.T) {
 	assert.Equal(t, time.Date(2026, time.March, 14, 10, 0, 0, 0, time.Local).Format(time.RFC3339), payload[0]["start_time"])
 }
 
+func TestRunExportCmdTimelineDefaultsToHTMLPage(t *testing.T) {
+	end := time.Date(2026, time.March, 14, 10, 45, 0, 0, time.Local)
+	service := &stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			activity := models.Activity{
+				Project:   "tock",
+				StartTime: time.Date(2026, time.March, 14, 10, 0, 0, 0, time.Local),
+				EndTime:   &end,
+			}
+			return &models.Report{Activities: []models.Activity{activity}}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runExportCmd(cmd, &exportOptions{Format: "txt", Timeline: true, Stdout: true}))
+	assert.Contains(t, out.String(), `id="tip"`, "the timeline page, not the html report")
+}
+
 func TestGetDefaultExportDirUsesRuntimeDataPathForSQLite(t *testing.T) {
 	cmd := newTestCLICommand(&stubActivityResolver{})
 	rt := getRuntime(cmd)
@@ -115,3 +137,40 @@ func TestRunExportCmdRejectsInvalidDateFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestRunExportCmdCSVUsesConfigAndFlags(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	service := &stubActivityResolver{
+		getReportFn: func(_ context.Context, _ models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{Activities: []models.Activity{
+				{Project: "tock", Description: "csv", StartTime: start, EndTime: &end, Tags: []string{"dev"}},
+			}}, nil
+		},
+	}
+
+	cmd := NewExportCmd()
+	base := newTestCLICommand(service)
+	cmd.SetContext(base.Context())
+	rt := getRuntime(cmd)
+	rt.Config.Export.CSV = config.CSVConfig{Columns: "project,duration_hours", Delimiter: ";", Decimal: ",", Header: true}
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.Flags().Parse([]string{"--format", "csv", "--stdout"}))
+	require.NoError(t, runExportCmd(cmd, &exportOptions{Format: "csv", Stdout: true, Header: true}))
+	assert.Equal(t, "project;duration_hours\ntock;1,50\n", out.String())
+
+	out.Reset()
+	require.NoError(t, cmd.Flags().Parse([]string{"--header=false"}))
+	require.NoError(t, runExportCmd(cmd, &exportOptions{
+		Format:   "csv",
+		Stdout:   true,
+		Columns:  "id,weekday,start,tags",
+		Timezone: "Asia/Tokyo",
+	}))
+	assert.Equal(t, "2026-03-14-01;Saturday;2026-03-14T19:00:00+09:00;dev\n", out.String())
+
+	err := runExportCmd(cmd, &exportOptions{Format: "csv", Stdout: true, Columns: "project,bogus"})
+	require.ErrorContains(t, err, `unknown csv column "bogus"`)
+}
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+const heatmapCellWidth = 2
+
+// heatmapGlyphs are indexed by intensity level, 0 being a day without activity.
+var heatmapGlyphs = [insights.HeatLevels + 1]string{"·", "░", "▒", "▓", "█"}
+
+var runHeatmapProgram = func(model heatmapModel) error {
+	program := tea.NewProgram(&model, tea.WithAltScreen())
+	_, err := program.Run()
+	if err != nil {
+		return errors.Wrap(err, "run program")
+	}
+	return nil
+}
+
+type heatmapOptions struct {
+	Year    int
+	Project string
+	Tag     string
+	Plain   bool
+}
+
+func NewHeatmapCmd() *cobra.Command {
+	var opts heatmapOptions
+
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show a yearly heatmap of daily totals",
+		Long:  defaultText("heatmap.long"),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runHeatmapCmd(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Year, "year", 0, defaultText("heatmap.flag.year"))
+	cmd.Flags().StringVarP(&opts.Project, "project", "p", "", defaultText("heatmap.flag.project"))
+	cmd.Flags().StringVar(&opts.Tag, "tag", "", defaultText("heatmap.flag.tag"))
+	cmd.Flags().BoolVar(&opts.Plain, "plain", false, defaultText("heatmap.flag.plain"))
+	_ = cmd.RegisterFlagCompletionFunc("project", projectRegisterFlagCompletion)
+	return cmd
+}
+
+func runHeatmapCmd(cmd *cobra.Command, opts *heatmapOptions) error {
+	rt := getRuntime(cmd)
+
+	year := opts.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	weekStart := rt.TimeFormatter.Calendar().WeekStart
+	model := initialHeatmapModel(rt.ActivityService, rt.Config, getLocalizer(cmd), weekStart, year, opts.Project, opts.Tag)
+	if !opts.Plain {
+		return runHeatmapProgram(model)
+	}
+
+	data, err := loadYearData(cmd.Context(), rt.ActivityService, year, opts.Project, opts.Tag)
+	if err != nil {
+		return err
+	}
+	model.data = data
+	return model.renderPlain(cmd.OutOrStdout())
+}
+
+// loadYearData fetches one calendar year and aggregates it into daily totals.
+// The project filter is applied by the backend, the tag filter here.
+func loadYearData(
+	ctx context.Context,
+	service ports.ActivityResolver,
+	year int,
+	project, tag string,
+) (insights.YearData, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+	filter := models.ActivityFilter{FromDate: &from, ToDate: &to}
+	if project != "" {
+		filter.Project = &project
+	}
+
+	report, err := service.GetReport(ctx, filter)
+	if err != nil {
+		return insights.YearData{}, errors.Wrap(err, "get report")
+	}
+
+	activities := report.Activities
+	if tag != "" {
+		activities = slices.DeleteFunc(slices.Clone(activities), func(act models.Activity) bool {
+			return !slices.Contains(act.Tags, tag)
+		})
+	}
+	return insights.BuildYearData(activities, year, time.Now()), nil
+}
+
+type heatmapDataMsg struct {
+	data insights.YearData
+}
+
+type heatmapModel struct {
+	service   ports.ActivityResolver
+	loc       *localization.Localizer
+	theme     Theme
+	weekStart time.Weekday
+	year      int
+	project   string
+	tag       string
+	data      insights.YearData
+	loaded    bool
+	err       error
+}
+
+func initialHeatmapModel(
+	service ports.ActivityResolver,
+	cfg *config.Config,
+	loc *localization.Localizer,
+	weekStart time.Weekday,
+	year int,
+	project, tag string,
+) heatmapModel {
+	return heatmapModel{
+		service:   service,
+		loc:       loc,
+		theme:     GetTheme(cfg.Theme),
+		weekStart: weekStart,
+		year:      year,
+		project:   project,
+		tag:       tag,
+	}
+}
+
+func (m *heatmapModel) Init() tea.Cmd {
+	return m.fetchYearData
+}
+
+func (m *heatmapModel) fetchYearData() tea.Msg {
+	data, err := loadYearData(context.Background(), m.service, m.year, m.project, m.tag)
+	if err != nil {
+		return errMsg{err}
+	}
+	return heatmapDataMsg{data: data}
+}
+
+func (m *heatmapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case heatmapDataMsg:
+		m.data = msg.data
+		m.loaded = true
+	case errMsg:
+		m.err = msg.err
+	case tea.KeyMsg:
+		return m, m.handleKeyMsg(msg)
+	}
+	return m, nil
+}
+
+func (m *heatmapModel) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return tea.Quit
+	case "left", "h", "p":
+		m.year--
+		return m.fetchYearData
+	case "right", "l", "n":
+		m.year++
+		return m.fetchYearData
+	case "t":
+		m.year = time.Now().Year()
+		return m.fetchYearData
+	}
+	return nil
+}
+
+func (m *heatmapModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v", m.err)
+	}
+	if !m.loaded {
+		return m.loc.Format("calendar.initializing")
+	}
+
+	help := lipgloss.NewStyle().Foreground(m.theme.Faint).Render(m.loc.Text("heatmap.help"))
+	return lipgloss.NewStyle().Margin(1, 2).Render(m.render(false) + "\n\n" + help)
+}
+
+func (m *heatmapModel) renderPlain(w io.Writer) error {
+	_, err := fmt.Fprintln(w, m.render(true))
+	return err
+}
+
+// render draws the year as one column per week (starting on the
+// configured week start) and one row
+// per weekday, followed by a legend and a summary. Plain output has no colors.
+func (m *heatmapModel) render(plain bool) string {
+	bold := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Highlight)
+	faint := lipgloss.NewStyle().Foreground(m.theme.Faint)
+	if plain {
+		bold, faint = lipgloss.NewStyle(), lipgloss.NewStyle()
+	}
+
+	year := m.data.Year
+	start := insights.StartOfWeek(time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local), m.weekStart)
+	weeks := heatmapWeekIndex(start, time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)) + 1
+	labelWidth := 3
+
+	var b strings.Builder
+	b.WriteString(bold.Render(m.title()) + "\n\n")
+	b.WriteString(strings.Repeat(" ", labelWidth) + faint.Render(m.monthLabels(start, weeks)) + "\n")
+
+	for weekday := range 7 {
+		label := ""
+		if weekday%2 == 0 && weekday < 6 {
+			label = localizedWeekdayShort(m.loc, (m.weekStart+time.Weekday(weekday))%7)
+		}
+		var row strings.Builder
+		row.WriteString(faint.Render(fmt.Sprintf("%-*s", labelWidth, label)))
+		for week := range weeks {
+			date := start.AddDate(0, 0, week*7+weekday)
+			if date.Year() != year {
+				row.WriteString(strings.Repeat(" ", heatmapCellWidth))
+				continue
+			}
+			row.WriteString(m.renderCell(m.data.Level(date), plain) + " ")
+		}
+		b.WriteString(strings.TrimRight(row.String(), " ") + "\n")
+	}
+
+	b.WriteString("\n" + m.renderLegend(plain) + "\n")
+	stats := m.data.Stats
+	b.WriteString(faint.Render(m.loc.Format("heatmap.summary",
+		formatDurationCompact(stats.TotalDuration),
+		stats.ActiveDays,
+		stats.LongestStreak,
+		formatDurationCompact(stats.MaxDailyDuration),
+	)))
+	return b.String()
+}
+
+func (m *heatmapModel) title() string {
+	title := m.loc.Format("heatmap.title", m.data.Year)
+	var filters []string
+	if m.project != "" {
+		filters = append(filters, m.project)
+	}
+	if m.tag != "" {
+		filters = append(filters, "#"+m.tag)
+	}
+	if len(filters) > 0 {
+		title += " (" + strings.Join(filters, ", ") + ")"
+	}
+	return title
+}
+
+// monthLabels places each month's short name above the week it starts in,
+// skipping a label when it would overlap the previous one.
+func (m *heatmapModel) monthLabels(start time.Time, weeks int) string {
+	line := []rune(strings.Repeat(" ", weeks*heatmapCellWidth))
+	next := 0
+	for month := time.January; month <= time.December; month++ {
+		col := heatmapWeekIndex(start, time.Date(m.data.Year, month, 1, 0, 0, 0, 0, time.Local)) * heatmapCellWidth
+		name := []rune(localizedMonthShortName(m.loc, month))
+		if col < next || col+len(name) > len(line) {
+			continue
+		}
+		copy(line[col:], name)
+		next = col + len(name) + 1
+	}
+	return strings.TrimRight(string(line), " ")
+}
+
+func (m *heatmapModel) renderCell(level int, plain bool) string {
+	glyph := heatmapGlyphs[level]
+	if plain {
+		return glyph
+	}
+	if level == 0 {
+		return lipgloss.NewStyle().Foreground(m.theme.Faint).Render(glyph)
+	}
+	return lipgloss.NewStyle().Foreground(m.theme.Heat[level-1]).Render(glyph)
+}
+
+func (m *heatmapModel) renderLegend(plain bool) string {
+	cells := make([]string, 0, len(heatmapGlyphs))
+	for level := range heatmapGlyphs {
+		cells = append(cells, m.renderCell(level, plain))
+	}
+	return m.loc.Text("heatmap.less") + " " + strings.Join(cells, " ") + " " + m.loc.Text("heatmap.more")
+}
+
+// heatmapWeekIndex returns the grid column of date. Days are counted in UTC so
+// DST changes do not shift the result.
+func heatmapWeekIndex(start, date time.Time) int {
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours()/24) / 7
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func heatmapTestActivities() []models.Activity {
+	start := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.Local)
+	end := start.Add(4 * time.Hour)
+	otherStart := time.Date(2026, time.March, 3, 9, 0, 0, 0, time.Local)
+	otherEnd := otherStart.Add(time.Hour)
+	return []models.Activity{
+		{Project: "tock", Description: "heatmap", StartTime: start, EndTime: &end, Tags: []string{"dev"}},
+		{Project: "tock", Description: "review", StartTime: otherStart, EndTime: &otherEnd},
+	}
+}
+
+func TestRunHeatmapCmdPlainPrintsGridWithoutColors(t *testing.T) {
+	var gotFilter models.ActivityFilter
+	cmd := newTestCLICommand(&stubActivityResolver{
+		getReportFn: func(_ context.Context, filter models.ActivityFilter) (*models.Report, error) {
+			gotFilter = filter
+			return &models.Report{Activities: heatmapTestActivities()}, nil
+		},
+	})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	require.NoError(t, runHeatmapCmd(cmd, &heatmapOptions{Year: 2026, Project: "tock", Plain: true}))
+
+	require.NotNil(t, gotFilter.FromDate)
+	require.NotNil(t, gotFilter.ToDate)
+	require.NotNil(t, gotFilter.Project)
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local), *gotFilter.FromDate)
+	assert.Equal(t, time.Date(2027, time.January, 1, 0, 0, 0, 0, time.Local), *gotFilter.ToDate)
+	assert.Equal(t, "tock", *gotFilter.Project)
+
+	output := out.String()
+	assert.NotContains(t, output, "\x1b[")
+	assert.Contains(t, output, "Activity in 2026 (tock)")
+	assert.Contains(t, output, "Jan")
+	assert.Contains(t, output, "Dec")
+	assert.Contains(t, output, "Less · ░ ▒ ▓ █ More")
+	assert.Contains(t, output, "Total: 5h • Active days: 2 • Longest streak: 2 days • Busiest day: 4h")
+
+	lines := strings.Split(output, "\n")
+	var monday string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Mo ") {
+			monday = line
+		}
+	}
+	require.NotEmpty(t, monday)
+	assert.Equal(t, 52, strings.Count(monday, "·")+strings.Count(monday, "█"), "29 Dec 2025 is left blank")
+	assert.Equal(t, 1, strings.Count(monday, "█"))
+}
+
+func TestRunHeatmapCmdFollowsWeekStart(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{Activities: heatmapTestActivities()}, nil
+		},
+	})
+	rt := getRuntime(cmd)
+	rt.TimeFormatter = rt.TimeFormatter.WithCalendar(timeutil.Calendar{WeekStart: time.Sunday})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	require.NoError(t, runHeatmapCmd(cmd, &heatmapOptions{Year: 2026, Plain: true}))
+
+	var labels []string
+	var sunday string
+	for _, line := range strings.Split(out.String(), "\n") {
+		for _, label := range []string{"Su ", "Mo ", "Tu ", "We ", "Th ", "Fr ", "Sa "} {
+			if strings.HasPrefix(line, label) {
+				labels = append(labels, strings.TrimSpace(label))
+			}
+		}
+		if strings.HasPrefix(line, "Su ") {
+			sunday = line
+		}
+	}
+	assert.Equal(t, []string{"Su", "Tu", "Th"}, labels)
+	assert.Equal(t, 52, strings.Count(sunday, "·"), "28 Dec 2025 is left blank")
+}
+
+func TestRunHeatmapCmdFiltersByTag(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{Activities: heatmapTestActivities()}, nil
+		},
+	})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	require.NoError(t, runHeatmapCmd(cmd, &heatmapOptions{Year: 2026, Tag: "dev", Plain: true}))
+	assert.Contains(t, out.String(), "Activity in 2026 (#dev)")
+	assert.Contains(t, out.String(), "Total: 4h • Active days: 1")
+}
+
+func TestRunHeatmapCmdStartsProgramForCurrentYear(t *testing.T) {
+	runner := runHeatmapProgram
+	t.Cleanup(func() { runHeatmapProgram = runner })
+
+	called := false
+	runHeatmapProgram = func(model heatmapModel) error {
+		called = true
+		assert.Equal(t, time.Now().Year(), model.year)
+		assert.NotNil(t, model.service)
+		return nil
+	}
+
+	require.NoError(t, runHeatmapCmd(newTestCLICommand(&stubActivityResolver{}), &heatmapOptions{}))
+	assert.True(t, called)
+}
+
+func TestHeatmapModelNavigatesYears(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{Activities: heatmapTestActivities()}, nil
+		},
+	})
+	rt := getRuntime(cmd)
+	model := initialHeatmapModel(rt.ActivityService, rt.Config, getLocalizer(cmd), time.Monday, 2026, "", "")
+	model.Update(model.Init()())
+	assert.Contains(t, model.View(), "Activity in 2026")
+
+	require.NotNil(t, model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}))
+	assert.Equal(t, 2027, model.year)
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyLeft})
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	assert.Equal(t, 2025, model.year)
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Equal(t, time.Now().Year(), model.year)
+}
+
+func TestHeatmapWeekIndexIgnoresDST(t *testing.T) {
+	start := time.Date(2025, time.December, 29, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, 0, heatmapWeekIndex(start, time.Date(2026, time.January, 4, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, 1, heatmapWeekIndex(start, time.Date(2026, time.January, 5, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, 52, heatmapWeekIndex(start, time.Date(2026, time.December, 31, 0, 0, 0, 0, time.Local)))
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/journal"
+)
+
+const defaultHistoryLimit = 20
+
+func NewHistoryCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the journal of changes",
+		Long:  defaultText("history.long"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runHistoryCmd(cmd, limit) },
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", defaultHistoryLimit, defaultText("history.flag.limit"))
+	return cmd
+}
+
+func runHistoryCmd(cmd *cobra.Command, limit int) error {
+	rt := getRuntime(cmd)
+	if rt.Journal == nil {
+		return errors.New(defaultText("journal.error.disabled"))
+	}
+
+	state, err := rt.Journal.State()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if len(state.Entries) == 0 {
+		fmt.Fprint(out, text(cmd, "history.empty"))
+		return nil
+	}
+
+	entries := slices.Clone(state.Entries)
+	slices.Reverse(entries)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	layout := "2006-01-02 " + rt.TimeFormatter.GetDisplayFormat()
+	for _, entry := range entries {
+		line := fmt.Sprintf("#%-4d %s  %s", entry.Seq, entry.Time.Local().Format(layout), journalEntrySummary(entry))
+		if state.IsUndone(entry.Seq) {
+			line += "  " + text(cmd, "history.undone")
+		}
+		fmt.Fprintln(out, line)
+	}
+	return nil
+}
+
+// journalEntrySummary describes an entry like a commit message subject,
+// e.g. "stop api: fix tests", or "undo #12".
+func journalEntrySummary(entry journal.Entry) string {
+	if entry.Op == journal.OpUndo || entry.Op == journal.OpRedo {
+		return fmt.Sprintf("%s #%d", entry.Op, entry.Target)
+	}
+	if len(entry.Changes) == 0 {
+		return entry.Op
+	}
+
+	act := entry.Changes[0].Subject()
+	summary := fmt.Sprintf("%s %s: %s", entry.Op, act.Project, act.Description)
+	if more := len(entry.Changes) - 1; more > 0 {
+		summary += fmt.Sprintf(" (+%d)", more)
+	}
+	return summary
+}
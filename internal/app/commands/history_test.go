@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/journal"
+)
+
+func TestRunHistoryCmdListsNewestFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	lines := undoTestJournal + `{"seq":3,"time":"2026-03-02T11:00:00Z","op":"undo","target":2}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(lines), 0600))
+
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	getRuntime(cmd).Journal = journal.New(path)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runHistoryCmd(cmd, 2))
+
+	got := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, got, 2)
+	assert.Contains(t, got[0], "#3")
+	assert.Contains(t, got[0], "undo #2")
+	assert.Contains(t, got[1], "#2")
+	assert.Contains(t, got[1], "remove api: review  (undone)")
+}
+
+func TestRunHistoryCmdEmptyJournal(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	getRuntime(cmd).Journal = journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runHistoryCmd(cmd, 0))
+	assert.Equal(t, "The journal is empty\n", out.String())
+}
@@ -19,9 +19,15 @@ import (
 	"github.com/kriuchkov/tock/internal/timeutil"
 )
 
+type icalCmdOptions struct {
+	Path   string
+	Open   bool
+	Period string
+	Since  string
+}
+
 func NewICalCmd() *cobra.Command {
-	var outputDir string
-	var openApp bool
+	var opts icalCmdOptions
 
 	cmd := &cobra.Command{
 		Use:   "ical [key or date]",
@@ -29,21 +35,34 @@ func NewICalCmd() *cobra.Command {
 		Long:  defaultText("ical.long"),
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runICalCmd(cmd, args, outputDir, openApp)
+			return runICalCmd(cmd, args, &opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&outputDir, "path", "", defaultText("ical.flag.path"))
-	cmd.Flags().BoolVar(&openApp, "open", false, defaultText("ical.flag.open"))
+	cmd.Flags().StringVar(&opts.Path, "path", "", defaultText("ical.flag.path"))
+	cmd.Flags().BoolVar(&opts.Open, "open", false, defaultText("ical.flag.open"))
+	addPeriodFlags(cmd, &opts.Period, &opts.Since)
+	cmd.AddCommand(newICalServeCmd())
 	return cmd
 }
 
-func runICalCmd(cmd *cobra.Command, args []string, outputDir string, openApp bool) error {
+func runICalCmd(cmd *cobra.Command, args []string, opts *icalCmdOptions) error {
 	out := cmd.OutOrStdout()
+	outputDir, openApp := opts.Path, opts.Open
 	if openApp && runtime.GOOS != "darwin" {
 		return errors.New(text(cmd, "ical.error.open_macos_only"))
 	}
 
+	if opts.Period != "" || opts.Since != "" {
+		if len(args) > 0 {
+			return errors.New(text(cmd, "ical.error.period_with_key"))
+		}
+		if outputDir == "" && !openApp {
+			return errors.New(text(cmd, "ical.error.path_required"))
+		}
+		return handlePeriodExport(cmd, out, opts)
+	}
+
 	if len(args) == 0 {
 		if outputDir == "" && !openApp {
 			return errors.New(text(cmd, "ical.error.path_required"))
@@ -71,10 +90,20 @@ func runICalCmd(cmd *cobra.Command, args []string, outputDir string, openApp boo
 		if seqErr != nil {
 			return errors.New(text(cmd, "ical.error.activity_not_found", ref.Sequence, len(activities)))
 		}
-		return handleSingleExport(out, activity, keyOrDate, outputDir, openApp)
+		uid := exportapp.ActivityUIDs(models.SortActivitiesByStart(activities))[ref.Sequence-1]
+		opts := append(icalOptions(cmd), exportapp.WithUID(uid))
+		return handleSingleExport(out, activity, keyOrDate, outputDir, openApp, opts...)
 	}
 
-	return handleBulkExport(out, activities, keyOrDate, outputDir, openApp)
+	return handleBulkExport(out, activities, keyOrDate, outputDir, openApp, icalOptions(cmd)...)
+}
+
+// icalOptions names exported calendars after export.ical.calendar_name.
+func icalOptions(cmd *cobra.Command) []exportapp.Option {
+	if cfg := getRuntime(cmd).Config; cfg != nil && cfg.Export.ICal.CalendarName != "" {
+		return []exportapp.Option{exportapp.WithCalendarName(cfg.Export.ICal.CalendarName)}
+	}
+	return nil
 }
 
 func handleFullExport(cmd *cobra.Command, out io.Writer, outputDir string, openApp bool) error {
@@ -90,7 +119,7 @@ func handleFullExport(cmd *cobra.Command, out io.Writer, outputDir string, openA
 		return nil
 	}
 
-	combinedContent := exportapp.CombinedCalendar(activities)
+	combinedContent := exportapp.CombinedCalendar(activities, icalOptions(cmd)...)
 
 	// Use configured filename or default
 	fileName := ""
@@ -139,6 +168,32 @@ func handleFullExport(cmd *cobra.Command, out io.Writer, outputDir string, openA
 	return nil
 }
 
+// handlePeriodExport writes the activities of --period or --since to one
+// file named after the first and last day of the range.
+func handlePeriodExport(cmd *cobra.Command, out io.Writer, opts *icalCmdOptions) error {
+	rt := getRuntime(cmd)
+	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{
+		Period:        opts.Period,
+		Since:         opts.Since,
+		TimeFormatter: rt.TimeFormatter,
+	})
+	if err != nil {
+		return err
+	}
+
+	report, err := rt.ActivityService.GetReport(cmd.Context(), filter)
+	if err != nil {
+		return errors.Wrap(err, "generate report")
+	}
+
+	last := time.Now()
+	if filter.ToDate != nil {
+		last = filter.ToDate.AddDate(0, 0, -1)
+	}
+	key := filter.FromDate.Format(time.DateOnly) + "_" + last.Format(time.DateOnly)
+	return handleBulkExport(out, models.SortActivitiesByStart(report.Activities), key, opts.Path, opts.Open, icalOptions(cmd)...)
+}
+
 func getActivitiesForDate(cmd *cobra.Command, date time.Time) ([]models.Activity, error) {
 	service := getRuntime(cmd).ActivityService
 
@@ -155,8 +210,10 @@ func getActivitiesForDate(cmd *cobra.Command, date time.Time) ([]models.Activity
 	return models.SortActivitiesByStart(report.Activities), nil
 }
 
-func handleSingleExport(out io.Writer, activity models.Activity, key string, outputDir string, openApp bool) error {
-	content := exportapp.Generate(activity, key)
+func handleSingleExport(
+	out io.Writer, activity models.Activity, key string, outputDir string, openApp bool, opts ...exportapp.Option,
+) error {
+	content := exportapp.Generate(activity, opts...)
 
 	//nolint:nestif,gocritic // straightforward logic
 	if outputDir != "" {
@@ -192,18 +249,20 @@ func handleSingleExport(out io.Writer, activity models.Activity, key string, out
 
 		fmt.Fprintln(out, defaultText("ical.opened.single"))
 	} else {
-		fmt.Fprintln(out, content)
+		fmt.Fprint(out, content)
 	}
 	return nil
 }
 
-func handleBulkExport(out io.Writer, activities []models.Activity, dateKey string, outputDir string, openApp bool) error {
+func handleBulkExport(
+	out io.Writer, activities []models.Activity, dateKey string, outputDir string, openApp bool, opts ...exportapp.Option,
+) error {
 	if len(activities) == 0 {
 		fmt.Fprintln(out, defaultText("ical.empty_date"))
 		return nil
 	}
 
-	combinedContent := exportapp.CombinedCalendar(activities)
+	combinedContent := exportapp.CombinedCalendar(activities, opts...)
 
 	if openApp {
 		f, err := os.CreateTemp("", fmt.Sprintf("tock-%s-*.ics", dateKey))
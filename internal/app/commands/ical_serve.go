@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+const defaultICalListenAddr = "127.0.0.1:8765"
+
+type icalServeOptions struct {
+	Listen   string
+	Projects []string
+	Tags     []string
+}
+
+func newICalServeCmd() *cobra.Command {
+	var opt icalServeOptions
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: defaultText("ical.serve.short"),
+		Long:  defaultText("ical.serve.long"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runICalServeCmd(cmd, &opt) },
+	}
+
+	cmd.Flags().StringVar(&opt.Listen, "listen", defaultICalListenAddr, defaultText("ical.serve.flag.listen"))
+	cmd.Flags().StringSliceVarP(&opt.Projects, "project", "p", nil, defaultText("ical.serve.flag.project"))
+	cmd.Flags().StringSliceVar(&opt.Tags, "tag", nil, defaultText("ical.serve.flag.tag"))
+	_ = cmd.RegisterFlagCompletionFunc("project", projectRegisterFlagCompletion)
+	return cmd
+}
+
+func runICalServeCmd(cmd *cobra.Command, opt *icalServeOptions) error {
+	feed := &icalFeed{
+		service:  getRuntime(cmd).ActivityService,
+		options:  append(icalOptions(cmd), exportapp.WithRevisions(exportapp.NewRevisions())),
+		projects: opt.Projects,
+		tags:     opt.Tags,
+		now:      time.Now,
+	}
+
+	listener, err := net.Listen("tcp", opt.Listen)
+	if err != nil {
+		return errors.Wrapf(err, "listen on %s", opt.Listen)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Handler:           feed,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx) //nolint:contextcheck // the serve context is already done
+	}()
+
+	fmt.Fprint(cmd.OutOrStdout(), text(cmd, "ical.serve.listening", "http://"+listener.Addr().String()+"/tock.ics"))
+	if err = server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "serve calendar")
+	}
+	return nil
+}
+
+// icalFeed serves the activities as a calendar that apps can subscribe to.
+// The projects and tags of the command can be narrowed down per subscription
+// with repeated ?project= and ?tag= query parameters.
+type icalFeed struct {
+	service  ports.ActivityResolver
+	options  []exportapp.Option
+	projects []string
+	tags     []string
+	now      func() time.Time
+}
+
+func (f *icalFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" && !strings.HasSuffix(r.URL.Path, ".ics") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	activities, err := f.service.List(r.Context(), models.ActivityFilter{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	query := r.URL.Query()
+	activities = filterFeedActivities(activities, f.projects, f.tags)
+	activities = filterFeedActivities(activities, query["project"], query["tag"])
+
+	etag := feedETag(activities)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body := exportapp.CombinedCalendar(activities, append(slices.Clone(f.options), exportapp.WithNow(f.now()))...)
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write([]byte(body))
+}
+
+// filterFeedActivities keeps activities in one of the projects that have at
+// least one of the tags. Empty lists keep everything.
+func filterFeedActivities(activities []models.Activity, projects, tags []string) []models.Activity {
+	if len(projects) == 0 && len(tags) == 0 {
+		return activities
+	}
+
+	var result []models.Activity
+	for _, act := range activities {
+		if len(projects) > 0 && !slices.Contains(projects, act.Project) {
+			continue
+		}
+		if len(tags) > 0 && !slices.ContainsFunc(act.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			continue
+		}
+		result = append(result, act)
+	}
+	return result
+}
+
+// feedETag is a weak validator of the activities: DTSTAMP changes with every
+// response, but the events themselves only change with the data.
+func feedETag(activities []models.Activity) string {
+	h := sha256.New()
+	for _, act := range models.SortActivitiesByStart(activities) {
+		fmt.Fprintln(h, exportapp.ActivityFingerprint(act))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+func etagMatches(header, etag string) bool {
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
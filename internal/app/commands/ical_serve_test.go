@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func newTestICalFeed(activities *[]models.Activity) *icalFeed {
+	return &icalFeed{
+		service: &stubActivityResolver{
+			listFn: func(_ context.Context, _ models.ActivityFilter) ([]models.Activity, error) {
+				return *activities, nil
+			},
+		},
+		options: []exportapp.Option{exportapp.WithRevisions(exportapp.NewRevisions())},
+		now:     func() time.Time { return time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestICalFeedServesCalendarWithETag(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	activities := []models.Activity{{Project: "core", Description: "review", StartTime: start, EndTime: &end}}
+	feed := newTestICalFeed(&activities)
+
+	rec := httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tock.ics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "SUMMARY:core: review")
+	assert.Contains(t, rec.Body.String(), "SEQUENCE:0\r\n")
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/tock.ics", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	activities[0].Description = "renamed"
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), "SUMMARY:core: renamed")
+	assert.Contains(t, rec.Body.String(), "SEQUENCE:1\r\n", "an edit is a new revision")
+}
+
+func TestICalFeedFiltersByQuery(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	activities := []models.Activity{
+		{Project: "core", Description: "a", StartTime: start, EndTime: &end, Tags: []string{"client"}},
+		{Project: "core", Description: "b", StartTime: start.Add(2 * time.Hour)},
+		{Project: "ops", Description: "c", StartTime: start.Add(4 * time.Hour), Tags: []string{"client"}},
+	}
+	feed := newTestICalFeed(&activities)
+
+	rec := httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?project=core&tag=client", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SUMMARY:core: a")
+	assert.NotContains(t, rec.Body.String(), "SUMMARY:core: b")
+	assert.NotContains(t, rec.Body.String(), "SUMMARY:ops: c")
+
+	feed.projects = []string{"ops"}
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tock.ics?tag=client", nil))
+	assert.NotContains(t, rec.Body.String(), "SUMMARY:core: a")
+	assert.Contains(t, rec.Body.String(), "SUMMARY:ops: c")
+}
+
+func TestICalFeedRejectsOtherPathsAndMethods(t *testing.T) {
+	var activities []models.Activity
+	feed := newTestICalFeed(&activities)
+
+	rec := httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/favicon.ico", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/tock.ics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+}
@@ -14,7 +14,7 @@ import (
 
 func TestRunICalCmdRequiresPathForBulkExport(t *testing.T) {
 	cmd := newTestCLICommand(&stubActivityResolver{})
-	err := runICalCmd(cmd, []string{"2026-03-14"}, "", false)
+	err := runICalCmd(cmd, []string{"2026-03-14"}, &icalCmdOptions{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "output directory (--path) is required")
 }
@@ -56,7 +56,7 @@ func TestRunICalCmdSingleExportWritesSelectedActivity(t *testing.T) {
 	var out bytes.Buffer
 	cmd.SetOut(&out)
 
-	err := runICalCmd(cmd, []string{"2026-03-14-02"}, "", false)
+	err := runICalCmd(cmd, []string{"2026-03-14-02"}, &icalCmdOptions{})
 	require.NoError(t, err)
 	assert.Contains(t, out.String(), "BEGIN:VCALENDAR")
 	assert.Contains(t, out.String(), "SUMMARY:ops: second")
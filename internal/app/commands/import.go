@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/importing"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const importFormatICal = "ical"
+
+type importOptions struct {
+	Format    string
+	Today     bool
+	Yesterday bool
+	Date      string
+	From      string
+	To        string
+	Period    string
+	Since     string
+	Project   string
+	Tags      []string
+	DryRun    bool
+}
+
+func NewImportCmd() *cobra.Command {
+	var opt importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import activities from a calendar file",
+		Long:  defaultText("import.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE:  func(cmd *cobra.Command, args []string) error { return runImportCmd(cmd, args[0], &opt) },
+	}
+
+	cmd.Flags().StringVarP(&opt.Format, "format", "m", "", defaultText("import.flag.format"))
+	cmd.Flags().BoolVar(&opt.Today, "today", false, defaultText("import.flag.today"))
+	cmd.Flags().BoolVar(&opt.Yesterday, "yesterday", false, defaultText("import.flag.yesterday"))
+	cmd.Flags().StringVar(&opt.Date, "date", "", defaultText("import.flag.date"))
+	cmd.Flags().StringVar(&opt.From, "from", "", defaultText("import.flag.from"))
+	cmd.Flags().StringVar(&opt.To, "to", "", defaultText("import.flag.to"))
+	addPeriodFlags(cmd, &opt.Period, &opt.Since)
+	cmd.Flags().StringVarP(&opt.Project, "project", "p", "", defaultText("import.flag.project"))
+	cmd.Flags().StringSliceVar(&opt.Tags, "tag", nil, defaultText("import.flag.tag"))
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, defaultText("import.flag.dry_run"))
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{importFormatICal}, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.RegisterFlagCompletionFunc("project", projectRegisterFlagCompletion)
+	return cmd
+}
+
+func runImportCmd(cmd *cobra.Command, path string, opt *importOptions) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	if err := checkImportFormat(opt.Format, path); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{
+		Now:           now,
+		Today:         opt.Today,
+		Yesterday:     opt.Yesterday,
+		Date:          opt.Date,
+		From:          opt.From,
+		To:            opt.To,
+		Period:        opt.Period,
+		Since:         opt.Since,
+		TimeFormatter: rt.TimeFormatter,
+	})
+	if err != nil {
+		return errors.Wrap(err, "build date range")
+	}
+	var from time.Time
+	to := now
+	if filter.FromDate != nil {
+		from = *filter.FromDate
+	}
+	if filter.ToDate != nil {
+		to = *filter.ToDate
+	}
+
+	mapOpts, err := importMapOptions(rt.Config.Import.ICal, opt)
+	if err != nil {
+		return err
+	}
+
+	events, err := readICalEvents(cmd.InOrStdin(), path)
+	if err != nil {
+		return err
+	}
+	events, err = importing.ExpandEvents(events, from, to)
+	if err != nil {
+		return errors.Wrap(err, "expand recurring events")
+	}
+
+	existing, err := existingStarts(cmd, events)
+	if err != nil {
+		return err
+	}
+
+	// Every project is checked against the catalog before anything is
+	// added, so an unknown one does not leave the import half done.
+	skipped := make(map[importing.SkipReason]int)
+	var reqs []models.AddActivityRequest
+	for _, ev := range events {
+		req, reason := importing.MapEvent(ev, mapOpts)
+		if reason == importing.SkipNone && existing[req.StartTime.Unix()] {
+			reason = importing.SkipDuplicate
+		}
+		if reason != importing.SkipNone {
+			skipped[reason]++
+			continue
+		}
+		existing[req.StartTime.Unix()] = true
+
+		if req.Project, err = resolveProject(cmd, req.Project); err != nil {
+			return errors.Wrapf(err, "import %q", req.Description)
+		}
+		reqs = append(reqs, req)
+	}
+
+	imported := 0
+	for _, req := range reqs {
+		if !opt.DryRun {
+			if _, err = rt.ActivityService.Add(cmd.Context(), req); err != nil {
+				return errors.Wrapf(err, "add %q", req.Description)
+			}
+		}
+		imported++
+		fmt.Fprintln(out, formatImportedActivity(req, rt.TimeFormatter.GetDisplayFormat()))
+	}
+
+	if opt.DryRun {
+		fmt.Fprint(out, text(cmd, "import.dry_run", imported, filepath.Base(path)))
+	} else {
+		fmt.Fprint(out, text(cmd, "import.done", imported, filepath.Base(path)))
+	}
+	if summary := skipSummary(skipped); summary != "" {
+		fmt.Fprint(out, text(cmd, "import.skipped", summary))
+	}
+	return nil
+}
+
+// checkImportFormat validates --format, or detects it from the file extension.
+// iCalendar is the only format so far.
+func checkImportFormat(format, path string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".ics", ".ical", ".ifb", ".icalendar":
+			return nil
+		default:
+			return errors.Errorf("cannot detect the format of %s, use --format ical", path)
+		}
+	}
+	if format != importFormatICal && format != "ics" {
+		return errors.Errorf("unsupported import format: %s (use ical)", format)
+	}
+	return nil
+}
+
+// readICalEvents reads the calendar from path, or from stdin for "-".
+func readICalEvents(stdin io.Reader, path string) ([]importing.Event, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "open calendar")
+		}
+		defer f.Close()
+		r = f
+	}
+
+	events, err := importing.ParseICal(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse calendar")
+	}
+	return events, nil
+}
+
+// importMapOptions combines import.ical from the config with the flags.
+func importMapOptions(cfg config.ICalImportConfig, opt *importOptions) (importing.MapOptions, error) {
+	mapOpts := importing.MapOptions{
+		DefaultProject: cfg.Project,
+		Tags:           append(append([]string{}, cfg.Tags...), opt.Tags...),
+		Attendee:       cfg.Attendee,
+	}
+	if opt.Project != "" {
+		mapOpts.DefaultProject = opt.Project
+	}
+
+	for i, rule := range cfg.Rules {
+		mapped := importing.Rule{
+			Category:    rule.Category,
+			Project:     rule.Project,
+			Description: rule.Description,
+			Tags:        rule.Tags,
+			Skip:        rule.Skip,
+		}
+		if rule.Match != "" {
+			re, err := regexp.Compile(rule.Match)
+			if err != nil {
+				return mapOpts, errors.Wrapf(err, "import.ical.rules[%d].match", i)
+			}
+			mapped.Match = re
+		}
+		mapOpts.Rules = append(mapOpts.Rules, mapped)
+	}
+	return mapOpts, nil
+}
+
+// existingStarts returns the start times of tracked activities in the span of
+// the events, so importing the same calendar twice adds nothing.
+func existingStarts(cmd *cobra.Command, events []importing.Event) (map[int64]bool, error) {
+	starts := make(map[int64]bool)
+	if len(events) == 0 {
+		return starts, nil
+	}
+
+	from, to := events[0].Start, events[0].End
+	for _, ev := range events {
+		if ev.End.After(to) {
+			to = ev.End
+		}
+	}
+	to = to.Add(time.Second)
+
+	activities, err := getRuntime(cmd).ActivityService.List(cmd.Context(), models.ActivityFilter{FromDate: &from, ToDate: &to})
+	if err != nil {
+		return nil, errors.Wrap(err, "list activities")
+	}
+	for _, act := range activities {
+		starts[act.StartTime.Unix()] = true
+	}
+	return starts, nil
+}
+
+func formatImportedActivity(req models.AddActivityRequest, layout string) string {
+	line := fmt.Sprintf("  %s %s-%s  %s: %s", req.StartTime.Format(time.DateOnly),
+		req.StartTime.Format(layout), req.EndTime.Format(layout), req.Project, req.Description)
+	if len(req.Tags) > 0 {
+		line += " #" + strings.Join(req.Tags, " #")
+	}
+	return line
+}
+
+func skipSummary(skipped map[importing.SkipReason]int) string {
+	reasons := make([]string, 0, len(skipped))
+	for reason := range skipped {
+		reasons = append(reasons, string(reason))
+	}
+	sort.Strings(reasons)
+
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%d %s", skipped[importing.SkipReason(reason)], reason)
+	}
+	return strings.Join(parts, ", ")
+}
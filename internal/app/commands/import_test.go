@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/projects"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const importTestCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:standup
+DTSTART:20260302T090000
+DTEND:20260302T091500
+SUMMARY:Standup
+RRULE:FREQ=DAILY;COUNT=3
+END:VEVENT
+BEGIN:VEVENT
+UID:acme
+DTSTART:20260302T140000
+DTEND:20260302T150000
+SUMMARY:[acme] Kickoff
+CATEGORIES:Customer
+END:VEVENT
+BEGIN:VEVENT
+UID:declined
+DTSTART:20260303T110000
+DTEND:20260303T120000
+SUMMARY:Optional sync
+ATTENDEE;PARTSTAT=DECLINED:mailto:me@example.com
+END:VEVENT
+BEGIN:VEVENT
+UID:focus
+DTSTART:20260303T130000
+DTEND:20260303T160000
+SUMMARY:Focus
+TRANSP:TRANSPARENT
+END:VEVENT
+END:VCALENDAR
+`
+
+func writeImportCalendar(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "meetings.ics")
+	require.NoError(t, os.WriteFile(path, []byte(importTestCalendar), 0600))
+	return path
+}
+
+func TestRunImportCmdAddsMappedActivities(t *testing.T) {
+	existingStart := time.Date(2026, time.March, 4, 9, 0, 0, 0, time.Local)
+	var added []models.AddActivityRequest
+	service := &stubActivityResolver{
+		listFn: func(_ context.Context, filter models.ActivityFilter) ([]models.Activity, error) {
+			require.NotNil(t, filter.FromDate)
+			require.NotNil(t, filter.ToDate)
+			return []models.Activity{{Project: "team", Description: "Standup", StartTime: existingStart}}, nil
+		},
+		addFn: func(_ context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+			added = append(added, req)
+			return &models.Activity{Project: req.Project, Description: req.Description, StartTime: req.StartTime}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).Config.Import.ICal = config.ICalImportConfig{
+		Attendee: "me@example.com",
+		Rules: []config.ICalImportRule{
+			{Match: `^\[(\w+)\] (.+)$`, Project: "$1", Description: "$2", Tags: []string{"client"}},
+			{Match: "(?i)standup", Project: "team"},
+		},
+	}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	err := runImportCmd(cmd, writeImportCalendar(t), &importOptions{From: "2026-03-01", To: "2026-03-31", Tags: []string{"meeting"}})
+	require.NoError(t, err)
+
+	require.Len(t, added, 3)
+	assert.Equal(t, "team", added[0].Project)
+	assert.Equal(t, time.Date(2026, time.March, 2, 9, 0, 0, 0, time.Local), added[0].StartTime)
+	assert.Equal(t, "acme", added[1].Project)
+	assert.Equal(t, "Kickoff", added[1].Description)
+	assert.Equal(t, []string{"meeting", "client", "Customer"}, added[1].Tags)
+	assert.Equal(t, time.Date(2026, time.March, 3, 9, 0, 0, 0, time.Local), added[2].StartTime)
+
+	assert.Contains(t, out.String(), "2026-03-02 14:00-15:00  acme: Kickoff #meeting #client #Customer")
+	assert.Contains(t, out.String(), "Imported 3 activities from meetings.ics")
+	assert.Contains(t, out.String(), "Skipped: 1 declined, 1 duplicate, 1 transparent")
+}
+
+func TestRunImportCmdDryRunDoesNotSave(t *testing.T) {
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return nil, nil },
+		addFn: func(context.Context, models.AddActivityRequest) (*models.Activity, error) {
+			t.Fatal("dry run must not add activities")
+			return nil, nil
+		},
+	}
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	err := runImportCmd(cmd, writeImportCalendar(t), &importOptions{Date: "2026-03-02", Project: "calls", DryRun: true})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "calls: Standup")
+	assert.Contains(t, out.String(), "Customer: [acme] Kickoff")
+	assert.Contains(t, out.String(), "Would import 2 activities from meetings.ics (dry run)")
+}
+
+func TestRunImportCmdRejectsUnknownFormats(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+
+	err := runImportCmd(cmd, "meetings.csv", &importOptions{})
+	require.ErrorContains(t, err, "cannot detect the format of meetings.csv")
+
+	err = runImportCmd(cmd, "meetings.ics", &importOptions{Format: "csv"})
+	require.ErrorContains(t, err, "unsupported import format: csv (use ical)")
+}
+
+func TestRunImportCmdChecksProjectsBeforeAdding(t *testing.T) {
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return nil, nil },
+		addFn: func(context.Context, models.AddActivityRequest) (*models.Activity, error) {
+			t.Fatal("nothing may be added when a project is not in the catalog")
+			return nil, nil
+		},
+	}
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).Catalog = projects.NewCatalog([]string{"calls"}, nil, true)
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := runImportCmd(cmd, writeImportCalendar(t), &importOptions{Date: "2026-03-02", Project: "calls"})
+	require.ErrorContains(t, err, `project "Customer" is not in the project catalog`)
+}
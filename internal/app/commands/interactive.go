@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"slices"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,10 +13,17 @@ import (
 	"github.com/kriuchkov/tock/internal/core/models"
 )
 
-func SelectActivityMetadata(activities []models.Activity, project, description string, theme Theme) (string, string, error) {
+// SelectActivityMetadata asks for the project and description that are
+// still empty. Projects for which archived returns true are not offered.
+func SelectActivityMetadata(
+	activities []models.Activity,
+	archived func(string) bool,
+	project, description string,
+	theme Theme,
+) (string, string, error) {
 	if project == "" {
 		var err error
-		project, err = selectProject(activities, theme)
+		project, err = selectProject(activities, archived, theme)
 		if err != nil {
 			return "", "", err
 		}
@@ -30,8 +39,8 @@ func SelectActivityMetadata(activities []models.Activity, project, description s
 	return project, description, nil
 }
 
-func selectProject(activities []models.Activity, theme Theme) (string, error) {
-	projects := models.UniqueProjects(activities)
+func selectProject(activities []models.Activity, archived func(string) bool, theme Theme) (string, error) {
+	projects := slices.DeleteFunc(models.UniqueProjects(activities), archived)
 	newOption := defaultText("interactive.new_project_option")
 	options := append([]string{newOption}, projects...)
 
@@ -189,6 +198,7 @@ type simpleListModel struct {
 	title    string
 	all      []string
 	filtered []string
+	matches  []int
 	filter   string
 	cursor   int
 	selected string
@@ -236,19 +246,74 @@ func (m *simpleListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *simpleListModel) applyFilter() {
-	if m.filter == "" {
-		m.filtered = m.all
-	} else {
-		lowFilter := strings.ToLower(m.filter)
-		filtered := make([]string, 0, len(m.all))
-		for _, item := range m.all {
-			if strings.Contains(strings.ToLower(item), lowFilter) {
-				filtered = append(filtered, item)
+	m.matches = fuzzyFilter(m.all, m.filter)
+	filtered := make([]string, 0, len(m.matches))
+	for _, idx := range m.matches {
+		filtered = append(filtered, m.all[idx])
+	}
+	m.filtered = filtered
+	m.cursor = 0
+}
+
+// fuzzyFilter returns the indexes of items matching query, best matches first.
+// Items with equal scores keep their original order, so an empty query returns
+// every item unchanged.
+func fuzzyFilter(items []string, query string) []int {
+	type scored struct {
+		index int
+		score int
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	results := make([]scored, 0, len(items))
+	for i, item := range items {
+		score, ok := fuzzyScore(item, terms)
+		if ok {
+			results = append(results, scored{index: i, score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].score < results[j].score })
+
+	indexes := make([]int, len(results))
+	for i, r := range results {
+		indexes[i] = r.index
+	}
+	return indexes
+}
+
+// fuzzyScore matches every term against candidate as an ordered subsequence of
+// runes. Contiguous matches score zero; otherwise each skipped rune inside the
+// matched span adds one, so lower scores are tighter matches.
+func fuzzyScore(candidate string, terms []string) (int, bool) {
+	haystack := []rune(strings.ToLower(candidate))
+	lowered := string(haystack)
+
+	total := 0
+	for _, term := range terms {
+		if strings.Contains(lowered, term) {
+			continue
+		}
+
+		needle := []rune(term)
+		first, pos := -1, 0
+		for i := 0; i < len(haystack) && pos < len(needle); i++ {
+			if haystack[i] != needle[pos] {
+				continue
+			}
+			if first < 0 {
+				first = i
+			}
+			pos++
+			if pos == len(needle) {
+				total += i - first + 1 - len(needle)
 			}
 		}
-		m.filtered = filtered
+		if pos < len(needle) {
+			return 0, false
+		}
 	}
-	m.cursor = 0
+	return total, true
 }
 
 func (m *simpleListModel) View() string {
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyFilterRanksTighterMatchesFirst(t *testing.T) {
+	items := []string{"a--x--b", "a-x-b", "ab", "ba"}
+	assert.Equal(t, []int{2, 1, 0}, fuzzyFilter(items, "ab"))
+}
+
+func TestFuzzyFilterMatchesAllTermsAndKeepsOrderOnTies(t *testing.T) {
+	items := []string{
+		"backend review pull request",
+		"tock release notes",
+		"tock review",
+	}
+
+	assert.Equal(t, []int{0, 2}, fuzzyFilter(items, "review"))
+	assert.Equal(t, []int{1, 2}, fuzzyFilter(items, "TOCK re"))
+	assert.Equal(t, []int{1}, fuzzyFilter(items, "tock rlnts"))
+}
+
+func TestFuzzyFilterEmptyQueryKeepsOrder(t *testing.T) {
+	items := []string{"b", "a", "c"}
+	assert.Equal(t, []int{0, 1, 2}, fuzzyFilter(items, "  "))
+}
+
+func TestFuzzyScoreRequiresEveryTerm(t *testing.T) {
+	_, ok := fuzzyScore("Tock Release", []string{"tock", "zzz"})
+	assert.False(t, ok)
+
+	score, ok := fuzzyScore("Tock Release", []string{"trl"})
+	assert.True(t, ok)
+	assert.Equal(t, 5, score)
+}
+
+func TestSimpleListModelFiltersFuzzily(t *testing.T) {
+	m := &simpleListModel{all: []string{"Frontend", "Backend", "Ops"}, filtered: []string{"Frontend", "Backend", "Ops"}}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("bknd")})
+	assert.Equal(t, []string{"Backend"}, m.filtered)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "Backend", m.selected)
+}
@@ -7,16 +7,14 @@ import (
 	"github.com/kriuchkov/tock/internal/app/localization"
 )
 
-func localizedWeekdayShortNames(loc *localization.Localizer) []string {
-	return []string{
-		loc.Text("date.weekday_short.mon"),
-		loc.Text("date.weekday_short.tue"),
-		loc.Text("date.weekday_short.wed"),
-		loc.Text("date.weekday_short.thu"),
-		loc.Text("date.weekday_short.fri"),
-		loc.Text("date.weekday_short.sat"),
-		loc.Text("date.weekday_short.sun"),
+// localizedWeekdayShortNames returns the short weekday names of a week that
+// starts on weekStart.
+func localizedWeekdayShortNames(loc *localization.Localizer, weekStart time.Weekday) []string {
+	names := make([]string, 0, 7)
+	for day := range 7 {
+		names = append(names, localizedWeekdayShort(loc, (weekStart+time.Weekday(day))%7))
 	}
+	return names
 }
 
 func localizedWeekdayShort(loc *localization.Localizer, weekday time.Weekday) string {
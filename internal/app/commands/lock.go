@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/periodlock"
+)
+
+func NewLockCmd() *cobra.Command {
+	var until string
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Lock a closed period against changes",
+		Long:  defaultText("lock.long"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			force, _ := cmd.Flags().GetBool("force")
+			return runLockCmd(cmd, until, force)
+		},
+	}
+
+	cmd.Flags().StringVar(&until, "until", "", defaultText("lock.flag.until"))
+	return cmd
+}
+
+func NewUnlockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock",
+		Short: "Remove the period lock",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			force, _ := cmd.Flags().GetBool("force")
+			return runUnlockCmd(cmd, force)
+		},
+	}
+}
+
+// runLockCmd shows the lock, or sets it when until is given. Moving the lock
+// back opens days that were closed, so it needs force like any other change
+// to them.
+func runLockCmd(cmd *cobra.Command, until string, force bool) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	current, err := rt.Lock.Until()
+	if err != nil {
+		return err
+	}
+
+	if until == "" {
+		if current.IsZero() {
+			fmt.Fprint(out, text(cmd, "lock.none"))
+			return nil
+		}
+		fmt.Fprint(out, text(cmd, "lock.status", current.Format(periodlock.DateLayout)))
+		return nil
+	}
+
+	day, err := rt.TimeFormatter.ParseDate(until)
+	if err != nil {
+		return err
+	}
+	if day.Before(current) && !force {
+		return errors.New(text(cmd, "lock.error.open", current.Format(periodlock.DateLayout)))
+	}
+	if err = rt.Lock.Set(day); err != nil {
+		return err
+	}
+	fmt.Fprint(out, text(cmd, "lock.done", day.Format(periodlock.DateLayout)))
+	return nil
+}
+
+// runUnlockCmd removes the lock. Like moving it back, this opens closed days,
+// so it needs force.
+func runUnlockCmd(cmd *cobra.Command, force bool) error {
+	rt := getRuntime(cmd)
+	current, err := rt.Lock.Until()
+	if err != nil {
+		return err
+	}
+	if current.IsZero() {
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, "lock.none"))
+		return nil
+	}
+	if !force {
+		return errors.New(text(cmd, "lock.error.open", current.Format(periodlock.DateLayout)))
+	}
+
+	if _, err = rt.Lock.Clear(); err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), text(cmd, "unlock.done"))
+	return nil
+}
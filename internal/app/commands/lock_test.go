@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/periodlock"
+)
+
+func TestRunLockAndUnlockCmds(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	getRuntime(cmd).Lock = periodlock.New(filepath.Join(t.TempDir(), ".tock", "lock"))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runLockCmd(cmd, "", false))
+	assert.Equal(t, "No period is locked\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runLockCmd(cmd, "2026-09-30", false))
+	assert.Equal(t, "Locked activities up to and including 2026-09-30\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runLockCmd(cmd, "", false))
+	assert.Equal(t, "Activities are locked up to and including 2026-09-30\n", out.String())
+
+	require.ErrorContains(t, runLockCmd(cmd, "2026-08-31", false), "pass --force to open locked days")
+	require.NoError(t, runLockCmd(cmd, "2026-08-31", true))
+	require.ErrorContains(t, runLockCmd(cmd, "30.09.2026", false), "expected YYYY-MM-DD")
+
+	out.Reset()
+	err := runUnlockCmd(cmd, false)
+	require.EqualError(t, err, "activities are locked up to and including 2026-08-31: pass --force to open locked days")
+	require.NoError(t, runUnlockCmd(cmd, true))
+	assert.Equal(t, "Removed the period lock\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runUnlockCmd(cmd, false))
+	assert.Equal(t, "No period is locked\n", out.String())
+}
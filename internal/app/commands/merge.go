@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/merging"
+	appruntime "github.com/kriuchkov/tock/internal/app/runtime"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+var openActivityService = appruntime.OpenActivityService
+
+type mergeOptions struct {
+	Backend string
+	Policy  string
+	DryRun  bool
+}
+
+func NewMergeCmd() *cobra.Command {
+	var opt mergeOptions
+
+	cmd := &cobra.Command{
+		Use:   "merge OTHER-FILE",
+		Short: "Merge activities from another data file",
+		Long:  defaultText("merge.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE:  func(cmd *cobra.Command, args []string) error { return runMergeCmd(cmd, args[0], &opt) },
+	}
+
+	cmd.Flags().StringVar(&opt.Backend, "backend", "", defaultText("merge.flag.backend"))
+	cmd.Flags().StringVar(&opt.Policy, "policy", "", defaultText("merge.flag.policy"))
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, defaultText("merge.flag.dry_run"))
+
+	_ = cmd.RegisterFlagCompletionFunc("policy", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, len(merging.Policies))
+		for i, policy := range merging.Policies {
+			names[i] = string(policy)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func runMergeCmd(cmd *cobra.Command, path string, opt *mergeOptions) error {
+	rt := getRuntime(cmd)
+	ctx := cmd.Context()
+	out := cmd.OutOrStdout()
+
+	policy, err := merging.ParsePolicy(opt.Policy)
+	if err != nil {
+		return err
+	}
+
+	backend := opt.Backend
+	if backend == "" {
+		backend = rt.Backend
+	}
+	if sameDataFile(path, rt.DataPath) && backend == rt.Backend {
+		return errors.Errorf("%s is the current data file", path)
+	}
+
+	other, err := openActivityService(ctx, backend, path)
+	if err != nil {
+		return errors.Wrapf(err, "open %s", path)
+	}
+	remote, err := other.List(ctx, models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrapf(err, "read %s", path)
+	}
+	// The projects of the other file go through the catalog like typed ones.
+	// Running activities are skipped by the merge, so they are not checked.
+	for i := range remote {
+		if remote[i].EndTime == nil {
+			continue
+		}
+		if remote[i].Project, err = resolveProject(cmd, remote[i].Project); err != nil {
+			return errors.Wrapf(err, "%s: %s", path, remote[i].Description)
+		}
+	}
+	local, err := rt.ActivityService.List(ctx, models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+
+	plan := merging.NewPlan(local, remote, time.Now())
+	layout := rt.TimeFormatter.GetDisplayFormat()
+	name := filepath.Base(path)
+
+	if opt.DryRun {
+		printMergePlan(cmd, plan, policy, layout)
+		fmt.Fprint(out, text(cmd, "merge.dry_run", name, len(plan.Add), len(plan.Duplicates), len(plan.Conflicts)))
+		printMergeRunning(cmd, len(plan.Running), name)
+		return nil
+	}
+
+	reader := bufio.NewReader(cmd.InOrStdin())
+	decide := func(conflict merging.Conflict) (merging.Policy, error) {
+		if policy != "" {
+			return policy, nil
+		}
+		return promptMergePolicy(cmd, reader, conflict, layout)
+	}
+
+	result, err := merging.Apply(ctx, rt.ActivityService, plan, decide)
+	if err != nil {
+		return errors.Wrapf(err, "merge %s", path)
+	}
+
+	fmt.Fprint(out, text(cmd, "merge.done", name, result.Added, result.Duplicates, result.Enriched))
+	if len(plan.Conflicts) > 0 {
+		fmt.Fprint(out, text(cmd, "merge.conflicts", len(plan.Conflicts), result.KeptLocal, result.Replaced, result.KeptBoth))
+	}
+	printMergeRunning(cmd, result.Running, name)
+	return nil
+}
+
+func printMergePlan(cmd *cobra.Command, plan merging.Plan, policy merging.Policy, layout string) {
+	out := cmd.OutOrStdout()
+	for _, act := range plan.Add {
+		fmt.Fprintln(out, "+ "+formatMergeActivity(act, layout))
+	}
+	for _, dup := range plan.Duplicates {
+		if dup.Changed() {
+			fmt.Fprintln(out, "~ "+formatMergeActivity(dup.Merged, layout))
+		}
+	}
+	for _, conflict := range plan.Conflicts {
+		fmt.Fprintln(out, "! "+formatMergeActivity(conflict.Remote, layout))
+		for _, local := range conflict.Local {
+			fmt.Fprintln(out, "  "+formatMergeActivity(local, layout))
+		}
+		if policy != "" {
+			fmt.Fprint(out, text(cmd, "merge.conflict.policy", policy))
+		}
+	}
+}
+
+func printMergeRunning(cmd *cobra.Command, running int, name string) {
+	if running > 0 {
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, "merge.running", running, name))
+	}
+}
+
+// promptMergePolicy asks how to resolve one conflict.
+func promptMergePolicy(
+	cmd *cobra.Command,
+	reader *bufio.Reader,
+	conflict merging.Conflict,
+	layout string,
+) (merging.Policy, error) {
+	out := cmd.OutOrStdout()
+	fmt.Fprint(out, text(cmd, "merge.conflict.title"))
+	fmt.Fprintln(out, text(cmd, "merge.conflict.remote")+formatMergeActivity(conflict.Remote, layout))
+	for _, local := range conflict.Local {
+		fmt.Fprintln(out, text(cmd, "merge.conflict.local")+formatMergeActivity(local, layout))
+	}
+
+	for {
+		fmt.Fprint(out, text(cmd, "merge.conflict.prompt"))
+		response, err := reader.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || response == "") {
+			if errors.Is(err, io.EOF) {
+				return "", errors.New(defaultText("merge.error.policy_required"))
+			}
+			return "", errors.Wrap(err, "read input")
+		}
+
+		switch strings.ToLower(strings.TrimSpace(response)) {
+		case "l", "local":
+			return merging.PolicyPreferLocal, nil
+		case "r", "remote":
+			return merging.PolicyPreferRemote, nil
+		case "b", "both":
+			return merging.PolicyKeepBoth, nil
+		}
+	}
+}
+
+func formatMergeActivity(act models.Activity, layout string) string {
+	end := "..."
+	if act.EndTime != nil {
+		end = act.EndTime.Format(layout)
+	}
+	line := fmt.Sprintf("%s %s-%s  %s: %s", act.StartTime.Format(time.DateOnly),
+		act.StartTime.Format(layout), end, act.Project, act.Description)
+	if len(act.Tags) > 0 {
+		line += " #" + strings.Join(act.Tags, " #")
+	}
+	return line
+}
+
+func sameDataFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
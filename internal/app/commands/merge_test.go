@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/projects"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+func mergeTestActivity(project, description string, startHour, endHour int) models.Activity {
+	start := time.Date(2026, time.March, 2, startHour, 0, 0, 0, time.Local)
+	end := time.Date(2026, time.March, 2, endHour, 0, 0, 0, time.Local)
+	return models.Activity{Project: project, Description: description, StartTime: start, EndTime: &end}
+}
+
+func stubOpenActivityService(t *testing.T, remote ...models.Activity) {
+	t.Helper()
+	original := openActivityService
+	openActivityService = func(context.Context, string, string) (ports.ActivityResolver, error) {
+		return &stubActivityResolver{
+			listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return remote, nil },
+		}, nil
+	}
+	t.Cleanup(func() { openActivityService = original })
+}
+
+func TestRunMergeCmdAppliesPolicy(t *testing.T) {
+	stubOpenActivityService(t,
+		mergeTestActivity("api", "tests", 9, 10),
+		mergeTestActivity("ops", "deploy", 11, 13),
+		mergeTestActivity("docs", "readme", 14, 15),
+	)
+
+	var added []models.AddActivityRequest
+	var removed []models.Activity
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return []models.Activity{
+				mergeTestActivity("api", "tests", 9, 10),
+				mergeTestActivity("api", "review", 12, 13),
+			}, nil
+		},
+		addFn: func(_ context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+			added = append(added, req)
+			return &models.Activity{}, nil
+		},
+		removeFn: func(_ context.Context, act models.Activity) error {
+			removed = append(removed, act)
+			return nil
+		},
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runMergeCmd(cmd, "/tmp/laptop.txt", &mergeOptions{Policy: "prefer-remote"}))
+
+	require.Len(t, added, 2)
+	assert.Equal(t, "readme", added[0].Description)
+	assert.Equal(t, "deploy", added[1].Description)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "review", removed[0].Description)
+	assert.Contains(t, out.String(), "Merged laptop.txt: 1 added, 1 duplicates (0 with new notes or tags)")
+	assert.Contains(t, out.String(), "1 conflicts: 0 kept local, 1 replaced, 0 kept both")
+}
+
+func TestRunMergeCmdPromptsForConflicts(t *testing.T) {
+	stubOpenActivityService(t, mergeTestActivity("ops", "deploy", 11, 13))
+
+	var added []models.AddActivityRequest
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return []models.Activity{mergeTestActivity("api", "review", 12, 13)}, nil
+		},
+		addFn: func(_ context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+			added = append(added, req)
+			return &models.Activity{}, nil
+		},
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader("maybe\nb\n"))
+
+	require.NoError(t, runMergeCmd(cmd, "/tmp/laptop.txt", &mergeOptions{}))
+
+	require.Len(t, added, 1)
+	assert.Equal(t, "deploy", added[0].Description)
+	assert.Equal(t, 2, strings.Count(out.String(), "Keep [l]ocal, [r]emote or [b]oth?"))
+	assert.Contains(t, out.String(), "other: 2026-03-02 11:00-13:00  ops: deploy")
+	assert.Contains(t, out.String(), "local: 2026-03-02 12:00-13:00  api: review")
+}
+
+func TestRunMergeCmdWithoutAnswerFails(t *testing.T) {
+	stubOpenActivityService(t, mergeTestActivity("ops", "deploy", 11, 13))
+
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return []models.Activity{mergeTestActivity("api", "review", 12, 13)}, nil
+		},
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(""))
+
+	err := runMergeCmd(cmd, "/tmp/laptop.txt", &mergeOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--policy")
+}
+
+func TestRunMergeCmdDryRunSavesNothing(t *testing.T) {
+	stubOpenActivityService(t, mergeTestActivity("docs", "readme", 14, 15), mergeTestActivity("ops", "deploy", 11, 13))
+
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return []models.Activity{mergeTestActivity("api", "review", 12, 13)}, nil
+		},
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runMergeCmd(cmd, "/tmp/laptop.txt", &mergeOptions{Policy: "keep-both", DryRun: true}))
+
+	assert.Contains(t, out.String(), "+ 2026-03-02 14:00-15:00  docs: readme")
+	assert.Contains(t, out.String(), "! 2026-03-02 11:00-13:00  ops: deploy")
+	assert.Contains(t, out.String(), "-> keep-both")
+	assert.Contains(t, out.String(), "Would merge laptop.txt: 1 added, 0 duplicates, 1 conflicts (dry run)")
+}
+
+func TestRunMergeCmdRejectsUnknownPolicy(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+
+	err := runMergeCmd(cmd, "/tmp/laptop.txt", &mergeOptions{Policy: "newest"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown merge policy")
+}
+
+func TestRunMergeCmdChecksRemoteProjectsAgainstCatalog(t *testing.T) {
+	stubOpenActivityService(t,
+		mergeTestActivity("api", "tests", 9, 10),
+		mergeTestActivity("opps", "deploy", 11, 13),
+	)
+
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return nil, nil },
+		applyFn: func(context.Context, models.ChangeSet) error {
+			t.Fatal("nothing may be merged when a project is not in the catalog")
+			return nil
+		},
+	})
+	getRuntime(cmd).Catalog = projects.NewCatalog([]string{"api", "ops"}, nil, true)
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := runMergeCmd(cmd, "/tmp/laptop.txt", &mergeOptions{Policy: "prefer-remote"})
+	require.ErrorContains(t, err, `project "opps" is not in the project catalog: did you mean "ops"?`)
+}
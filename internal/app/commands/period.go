@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+// addPeriodFlags adds --period and --since, which select the same ranges on
+// every command that filters by date.
+func addPeriodFlags(cmd *cobra.Command, period, since *string) {
+	cmd.Flags().StringVar(period, "period", "", defaultText("common.flag.period"))
+	cmd.Flags().StringVar(since, "since", "", defaultText("common.flag.since"))
+	_ = cmd.RegisterFlagCompletionFunc("period", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return timeutil.Periods, cobra.ShellCompDirectiveNoFileComp
+	})
+}
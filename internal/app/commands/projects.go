@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/app/periodlock"
+	"github.com/kriuchkov/tock/internal/app/projects"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func NewProjectsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projects",
+		Short: "List, rename, merge and archive projects",
+		Long:  defaultText("projects.long"),
+	}
+
+	var jsonOutput bool
+	list := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   defaultText("projects.list.short"),
+		Args:    cobra.NoArgs,
+		RunE:    func(cmd *cobra.Command, _ []string) error { return runProjectsListCmd(cmd, jsonOutput) },
+	}
+	list.Flags().BoolVar(&jsonOutput, "json", false, defaultText("projects.flag.json"))
+	cmd.AddCommand(list)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:               "rename OLD NEW",
+		Short:             defaultText("projects.rename.short"),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: projectArgsCompletion(1),
+		RunE:              func(cmd *cobra.Command, args []string) error { return runProjectsRenameCmd(cmd, args[0], args[1]) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:               "merge SOURCE... TARGET",
+		Short:             defaultText("projects.merge.short"),
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: projectArgsCompletion(-1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectsMergeCmd(cmd, args[:len(args)-1], args[len(args)-1])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:               "archive PROJECT...",
+		Short:             defaultText("projects.archive.short"),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: projectArgsCompletion(-1),
+		RunE:              func(cmd *cobra.Command, args []string) error { return runProjectsArchiveCmd(cmd, args) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:               "unarchive PROJECT...",
+		Short:             defaultText("projects.unarchive.short"),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: archivedProjectArgsCompletion,
+		RunE:              func(cmd *cobra.Command, args []string) error { return runProjectsUnarchiveCmd(cmd, args) },
+	})
+	return cmd
+}
+
+func runProjectsListCmd(cmd *cobra.Command, jsonOutput bool) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	archived, err := rt.Archive.Hidden()
+	if err != nil {
+		return err
+	}
+	summaries := projects.Summarize(activities, archived)
+
+	if jsonOutput {
+		return writeJSONTo(out, summaries)
+	}
+	if len(summaries) == 0 {
+		fmt.Fprint(out, text(cmd, "projects.list.empty"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, text(cmd, "projects.list.header"))
+	for _, summary := range summaries {
+		status := ""
+		if summary.Archived {
+			status = text(cmd, "projects.list.archived")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			summary.Name,
+			formatDurationCompact(summary.Duration),
+			summary.Activities,
+			summary.FirstUsed.Format(periodlock.DateLayout),
+			summary.LastUsed.Format(periodlock.DateLayout),
+			status,
+		)
+	}
+	if err = w.Flush(); err != nil {
+		return errors.Wrap(err, "write projects")
+	}
+	return nil
+}
+
+// runProjectsRenameCmd renames a project that the new name is not taken by,
+// so that a rename never mixes two projects; merge does that on purpose.
+func runProjectsRenameCmd(cmd *cobra.Command, from, to string) error {
+	rt := getRuntime(cmd)
+	separator := rt.Config.Projects.Separator
+	to, err := resolveProject(cmd, to)
+	if err != nil {
+		return err
+	}
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	if from == to {
+		return errors.New(text(cmd, "projects.error.same", from))
+	}
+	if !projects.InUse(activities, from, separator) {
+		return errors.New(text(cmd, "projects.error.not_found", from))
+	}
+	if projects.InUse(activities, to, separator) {
+		return errors.New(text(cmd, "projects.error.exists", to))
+	}
+
+	moved, err := projects.Move(cmd.Context(), rt.ActivityService, []string{from}, to, separator)
+	if err != nil {
+		return err
+	}
+	if err = rt.Archive.Move(from, to, false); err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), text(cmd, "projects.rename.done", from, to, moved[0]))
+	return nil
+}
+
+func runProjectsMergeCmd(cmd *cobra.Command, sources []string, target string) error {
+	rt := getRuntime(cmd)
+	separator := rt.Config.Projects.Separator
+	target, err := resolveProject(cmd, target)
+	if err != nil {
+		return err
+	}
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	for _, source := range sources {
+		if source == target {
+			return errors.New(text(cmd, "projects.error.same", source))
+		}
+		if !projects.InUse(activities, source, separator) {
+			return errors.New(text(cmd, "projects.error.not_found", source))
+		}
+	}
+
+	targetInUse := projects.InUse(activities, target, separator)
+	moved, err := projects.Move(cmd.Context(), rt.ActivityService, sources, target, separator)
+	if err != nil {
+		return err
+	}
+	for i, source := range sources {
+		if err = rt.Archive.Move(source, target, targetInUse); err != nil {
+			return err
+		}
+		targetInUse = true
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, "projects.merge.done", source, target, moved[i]))
+	}
+	return nil
+}
+
+func runProjectsArchiveCmd(cmd *cobra.Command, names []string) error {
+	rt := getRuntime(cmd)
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	for _, name := range names {
+		if !projects.InUse(activities, name, rt.Config.Projects.Separator) {
+			return errors.New(text(cmd, "projects.error.not_found", name))
+		}
+	}
+
+	for _, name := range names {
+		added, addErr := rt.Archive.Add(name)
+		if addErr != nil {
+			return addErr
+		}
+		key := "projects.archive.done"
+		if !added {
+			key = "projects.archive.already"
+		}
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, key, name))
+	}
+	return nil
+}
+
+func runProjectsUnarchiveCmd(cmd *cobra.Command, names []string) error {
+	rt := getRuntime(cmd)
+	for _, name := range names {
+		removed, err := rt.Archive.Remove(name)
+		if err != nil {
+			return err
+		}
+		key := "projects.unarchive.done"
+		if !removed {
+			key = "projects.unarchive.not_archived"
+		}
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, key, name))
+	}
+	return nil
+}
+
+// resolveProject expands a project alias and, with a strict catalog,
+// rejects projects outside it with the nearest one as a suggestion.
+func resolveProject(cmd *cobra.Command, project string) (string, error) {
+	return resolveCatalogProject(getRuntime(cmd).Catalog, getLocalizer(cmd), project)
+}
+
+// resolveCatalogProject is resolveProject for code without a command, such
+// as the calendar.
+func resolveCatalogProject(catalog *projects.Catalog, loc *localization.Localizer, project string) (string, error) {
+	project = catalog.Resolve(project)
+	suggestion, ok := catalog.Check(project)
+	if ok {
+		return project, nil
+	}
+	if suggestion != "" {
+		return "", errors.New(formatText(loc, "projects.error.unknown_suggest", project, suggestion))
+	}
+	return "", errors.New(formatText(loc, "projects.error.unknown", project))
+}
+
+// projectArgsCompletion completes every project, archived or not, for the
+// first count arguments, or for all of them when count is negative.
+func projectArgsCompletion(count int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if count >= 0 && len(args) >= count {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		rt, err := getRuntimeForCompletion(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return models.UniqueProjects(activities), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func archivedProjectArgsCompletion(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	rt, err := getRuntimeForCompletion(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	archived, err := rt.Archive.Projects()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return archived, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/projects"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// newProjectsTestService returns a service that keeps activities in memory
+// and applies edits to them.
+func newProjectsTestService(activities []models.Activity) (func() []models.Activity, *stubActivityResolver) {
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return append([]models.Activity(nil), activities...), nil
+		},
+		editFn: func(_ context.Context, act models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+			for i := range activities {
+				if activities[i].StartTime.Equal(act.StartTime) {
+					activities[i].Project = req.Project
+					activities[i].Notes = req.Notes
+					return &activities[i], nil
+				}
+			}
+			return nil, assert.AnError
+		},
+	}
+	return func() []models.Activity { return activities }, service
+}
+
+func projectsTestActivities() []models.Activity {
+	day := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.Local)
+	act := func(project string, offset time.Duration) models.Activity {
+		end := day.Add(offset + time.Hour)
+		return models.Activity{Project: project, Description: "work", StartTime: day.Add(offset), EndTime: &end, Notes: "kept"}
+	}
+	return []models.Activity{
+		act("Backend", 0),
+		act("backend", 24*time.Hour),
+		act("backend/db", 48*time.Hour),
+		act("frontend", 72*time.Hour),
+	}
+}
+
+func projectsOf(activities []models.Activity) []string {
+	names := make([]string, len(activities))
+	for i, act := range activities {
+		names[i] = act.Project
+	}
+	return names
+}
+
+func TestRunProjectsRenameAndMergeCmds(t *testing.T) {
+	current, service := newProjectsTestService(projectsTestActivities())
+	cmd := newTestCLICommand(service)
+	rt := getRuntime(cmd)
+	rt.Config.Projects.Separator = "/"
+	rt.Archive = projects.NewArchive(filepath.Join(t.TempDir(), "archived-projects"), "/")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.ErrorContains(t, runProjectsRenameCmd(cmd, "Backend", "backend"), "use tock projects merge")
+	require.ErrorContains(t, runProjectsRenameCmd(cmd, "missing", "other"), `project "missing" not found`)
+
+	require.NoError(t, runProjectsRenameCmd(cmd, "backend", "api"))
+	assert.Equal(t, "Renamed backend to api in 2 activities\n", out.String())
+	assert.Equal(t, []string{"Backend", "api", "api/db", "frontend"}, projectsOf(current()))
+
+	out.Reset()
+	require.NoError(t, runProjectsMergeCmd(cmd, []string{"Backend"}, "api"))
+	assert.Equal(t, "Merged Backend into api: 1 activities\n", out.String())
+	assert.Equal(t, []string{"api", "api", "api/db", "frontend"}, projectsOf(current()))
+	assert.Equal(t, "kept", current()[0].Notes)
+}
+
+func TestRunProjectsArchiveAndListCmds(t *testing.T) {
+	_, service := newProjectsTestService(projectsTestActivities())
+	cmd := newTestCLICommand(service)
+	rt := getRuntime(cmd)
+	rt.Config.Projects.Separator = "/"
+	rt.Archive = projects.NewArchive(filepath.Join(t.TempDir(), "archived-projects"), "/")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.ErrorContains(t, runProjectsArchiveCmd(cmd, []string{"nope"}), "not found")
+	require.NoError(t, runProjectsArchiveCmd(cmd, []string{"backend"}))
+	assert.Equal(t, "Archived backend\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runProjectsListCmd(cmd, false))
+	assert.Contains(t, out.String(), "Project")
+	assert.Regexp(t, `backend\s+1h\s+1\s+2026-03-03\s+2026-03-03\s+archived`, out.String())
+	assert.Regexp(t, `backend/db\s+1h\s+1\s+2026-03-04\s+2026-03-04\s+archived`, out.String())
+	assert.Regexp(t, `frontend\s+1h\s+1\s+2026-03-05\s+2026-03-05\s*\n`, out.String())
+
+	out.Reset()
+	require.NoError(t, runProjectsListCmd(cmd, true))
+	var summaries []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summaries))
+	require.Len(t, summaries, 4)
+	assert.Equal(t, "Backend", summaries[0]["name"])
+	assert.Equal(t, "01:00:00", summaries[0]["duration"])
+	assert.Equal(t, false, summaries[0]["archived"])
+	assert.Equal(t, true, summaries[1]["archived"])
+
+	out.Reset()
+	require.NoError(t, runProjectsUnarchiveCmd(cmd, []string{"backend", "frontend"}))
+	assert.Equal(t, "Restored backend\nfrontend is not archived\n", out.String())
+}
+
+func TestProjectCatalogResolvesAliasesAndRejectsTypos(t *testing.T) {
+	var started, added []string
+	service := &stubActivityResolver{
+		getRecentFn: func(context.Context, int) ([]models.Activity, error) {
+			return []models.Activity{{Project: "be", Description: "api"}}, nil
+		},
+		startFn: func(_ context.Context, req models.StartActivityRequest) (*models.Activity, error) {
+			started = append(started, req.Project)
+			return &models.Activity{Project: req.Project, Description: req.Description, StartTime: req.StartTime}, nil
+		},
+		addFn: func(_ context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+			added = append(added, req.Project)
+			end := req.EndTime
+			return &models.Activity{Project: req.Project, Description: req.Description, StartTime: req.StartTime, EndTime: &end}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).Catalog = projects.NewCatalog(
+		[]string{"backend", "frontend", "clients/..."},
+		map[string]string{"be": "backend"},
+		true,
+	)
+	cmd.SetOut(&bytes.Buffer{})
+
+	require.NoError(t, runStartCmd(cmd, nil, &startOptions{Project: "BE", Description: "api"}))
+	require.NoError(t, runStartCmd(cmd, nil, &startOptions{Project: "clients/acme", Description: "call"}))
+	require.NoError(t, runContinueCmd(cmd, nil, &continueOptions{}))
+	require.NoError(t, runAdd(cmd, &addOptions{Project: "be", Description: "api", StartStr: "09:00", EndStr: "10:00"}))
+	assert.Equal(t, []string{"backend", "clients/acme", "backend"}, started)
+	assert.Equal(t, []string{"backend"}, added)
+
+	err := runStartCmd(cmd, nil, &startOptions{Project: "backedn", Description: "api"})
+	require.EqualError(t, err, `project "backedn" is not in the project catalog: did you mean "backend"?`)
+	err = runAdd(cmd, &addOptions{Project: "marketing", Description: "api", StartStr: "09:00", EndStr: "10:00"})
+	require.EqualError(t, err, `project "marketing" is not in the project catalog (see projects.allowed)`)
+	assert.Len(t, started, 3)
+	assert.Len(t, added, 1)
+}
+
+func TestRunProjectsRenameAndMergeCheckTargetsAgainstCatalog(t *testing.T) {
+	current, service := newProjectsTestService(projectsTestActivities())
+	cmd := newTestCLICommand(service)
+	rt := getRuntime(cmd)
+	rt.Config.Projects.Separator = "/"
+	rt.Archive = projects.NewArchive(filepath.Join(t.TempDir(), "archived-projects"), "/")
+	rt.Catalog = projects.NewCatalog([]string{"backend", "api"}, map[string]string{"be": "backend"}, true)
+	cmd.SetOut(&bytes.Buffer{})
+
+	require.EqualError(t, runProjectsRenameCmd(cmd, "frontend", "frontnd"),
+		`project "frontnd" is not in the project catalog (see projects.allowed)`)
+	require.EqualError(t, runProjectsMergeCmd(cmd, []string{"Backend"}, "backedn"),
+		`project "backedn" is not in the project catalog: did you mean "backend"?`)
+	assert.Equal(t, []string{"Backend", "backend", "backend/db", "frontend"}, projectsOf(current()))
+
+	require.NoError(t, runProjectsMergeCmd(cmd, []string{"Backend"}, "be"))
+	assert.Equal(t, []string{"backend", "backend", "backend/db", "frontend"}, projectsOf(current()))
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-faster/errors"
 
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/app/insights"
 	ce "github.com/kriuchkov/tock/internal/core/errors"
 	"github.com/kriuchkov/tock/internal/core/models"
 	"github.com/kriuchkov/tock/internal/timeutil"
@@ -22,11 +25,16 @@ type reportOptions struct {
 	Date        string
 	From        string
 	To          string
+	Period      string
+	Since       string
 	Summary     bool
 	Project     string
 	Description string
 	TotalOnly   bool
 	JSONOutput  bool
+	Template    string
+	Dedupe      bool
+	Depth       int
 }
 
 func NewReportCmd() *cobra.Command {
@@ -50,13 +58,23 @@ func NewReportCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opt.Date, "date", "", defaultText("report.flag.date"))
 	cmd.Flags().StringVar(&opt.From, "from", "", defaultText("report.flag.from"))
 	cmd.Flags().StringVar(&opt.To, "to", "", defaultText("report.flag.to"))
+	addPeriodFlags(cmd, &opt.Period, &opt.Since)
 	cmd.Flags().BoolVarP(&opt.Summary, "summary", "s", false, defaultText("report.flag.summary"))
 	cmd.Flags().StringVarP(&opt.Project, "project", "p", "", defaultText("report.flag.project"))
 	cmd.Flags().StringVarP(&opt.Description, "description", "d", "", defaultText("report.flag.description"))
 	cmd.Flags().BoolVar(&opt.TotalOnly, "total-only", false, defaultText("report.flag.total_only"))
 	cmd.Flags().BoolVar(&opt.JSONOutput, "json", false, defaultText("report.flag.json"))
+	cmd.Flags().StringVarP(&opt.Template, "template", "t", "", defaultText("report.flag.template"))
+	cmd.Flags().BoolVar(&opt.Dedupe, "dedupe", false, defaultText("report.flag.dedupe"))
+	cmd.Flags().IntVar(&opt.Depth, "depth", 0, defaultText("report.flag.depth"))
+	cmd.MarkFlagsMutuallyExclusive("template", "json", "total-only")
+	// --json and templates show each activity's own duration, which --dedupe
+	// cannot change, so their totals would not add up.
+	cmd.MarkFlagsMutuallyExclusive("dedupe", "json")
+	cmd.MarkFlagsMutuallyExclusive("dedupe", "template")
 
 	_ = cmd.RegisterFlagCompletionFunc("project", projectRegisterFlagCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("template", templateRegisterFlagCompletion)
 	return cmd
 }
 
@@ -67,14 +85,18 @@ func runReportCmd(cmd *cobra.Command, opt *reportOptions) error {
 	tf := rt.TimeFormatter
 
 	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{
-		Now:         time.Now(),
-		Today:       opt.Today,
-		Yesterday:   opt.Yesterday,
-		Date:        opt.Date,
-		From:        opt.From,
-		To:          opt.To,
-		Project:     opt.Project,
-		Description: opt.Description,
+		Now:              time.Now(),
+		Today:            opt.Today,
+		Yesterday:        opt.Yesterday,
+		Date:             opt.Date,
+		From:             opt.From,
+		To:               opt.To,
+		Project:          opt.Project,
+		ProjectSeparator: rt.Config.Projects.Separator,
+		Description:      opt.Description,
+		Period:           opt.Period,
+		Since:            opt.Since,
+		TimeFormatter:    rt.TimeFormatter,
 	})
 	if err != nil {
 		return err
@@ -84,7 +106,13 @@ func runReportCmd(cmd *cobra.Command, opt *reportOptions) error {
 	if err != nil {
 		return errors.Wrap(err, "generate report")
 	}
+	if opt.Dedupe {
+		report.Deduplicate(time.Now())
+	}
 
+	if opt.Template != "" {
+		return writeReportTemplate(cmd, out, report, filter, opt.Template)
+	}
 	return writeReportOutput(cmd, out, tf, report, opt)
 }
 
@@ -113,8 +141,8 @@ func writeReportOutput(
 	}
 
 	activityIDs := models.ActivitySequenceIDs(report.Activities)
-	for _, projectName := range sortedProjectNames(report.ByProject) {
-		if err := writeProjectSection(cmd, out, tf, report.ByProject[projectName], activityIDs, opt); err != nil {
+	for _, row := range projectTreeRows(report.ByProject, getRuntime(cmd).Config.Projects.Separator, opt.Depth) {
+		if err := writeProjectSection(cmd, out, tf, report.ByProject, row, activityIDs, opt); err != nil {
 			return err
 		}
 	}
@@ -122,6 +150,21 @@ func writeReportOutput(
 	return writeReportTotalLine(cmd, out, report.TotalDuration)
 }
 
+func writeReportTemplate(cmd *cobra.Command, out io.Writer, report *models.Report, filter models.ActivityFilter, name string) error {
+	rt := getRuntime(cmd)
+	data := exportapp.BuildTemplateData(report, filter.FromDate, filter.ToDate, time.Now())
+	output, err := exportapp.RenderReportTemplate(name, data, rt.TimeFormatter,
+		exportapp.WithTagColors(rt.TagColors),
+		exportapp.WithTemplatesDir(rt.Config.Export.TemplatesDir),
+	)
+	if err != nil {
+		return errors.Wrap(err, "render template")
+	}
+
+	_, err = out.Write(output)
+	return err
+}
+
 func writeReportJSON(out io.Writer, activities []models.Activity) error {
 	encoder := json.NewEncoder(out)
 	encoder.SetIndent("", "  ")
@@ -136,43 +179,79 @@ func writeTotalDuration(out io.Writer, duration time.Duration) error {
 	return err
 }
 
-func sortedProjectNames(byProject map[string]models.ProjectReport) []string {
-	projectNames := make([]string, 0, len(byProject))
-	for name := range byProject {
-		projectNames = append(projectNames, name)
+// projectTreeRow is a line of the project tree, in the order it is printed.
+type projectTreeRow struct {
+	node      *insights.ProjectNode
+	depth     int
+	collapsed bool
+}
+
+// projectTreeRows rolls the project durations up the hierarchy spelled by
+// separator, down to maxDepth levels when it is above 0.
+func projectTreeRows(byProject map[string]models.ProjectReport, separator string, maxDepth int) []projectTreeRow {
+	durations := make(map[string]time.Duration, len(byProject))
+	for name, projectReport := range byProject {
+		durations[name] = projectReport.Duration
 	}
-	sort.Strings(projectNames)
 
-	return projectNames
+	var rows []projectTreeRow
+	insights.WalkProjectTree(insights.BuildProjectTree(durations, separator), maxDepth,
+		func(node *insights.ProjectNode, depth int, collapsed bool) {
+			rows = append(rows, projectTreeRow{node: node, depth: depth, collapsed: collapsed})
+		})
+	return rows
+}
+
+// label returns the full name for top-level projects and the last part of
+// the name below them, indented by depth.
+func (r projectTreeRow) label() string {
+	if r.depth == 1 {
+		return r.node.Path
+	}
+	return strings.Repeat("  ", r.depth-1) + r.node.Name
+}
+
+// activities returns the activities tracked on the node, and on the nodes
+// below it when those are not printed.
+func (r projectTreeRow) activities(byProject map[string]models.ProjectReport) []models.Activity {
+	if !r.collapsed {
+		return byProject[r.node.Path].Activities
+	}
+	var activities []models.Activity
+	for _, path := range r.node.Paths() {
+		activities = append(activities, byProject[path].Activities...)
+	}
+	return models.SortActivitiesByStart(activities)
 }
 
 func writeProjectSection(
 	cmd *cobra.Command,
 	out io.Writer,
 	tf *timeutil.Formatter,
-	projectReport models.ProjectReport,
+	byProject map[string]models.ProjectReport,
+	row projectTreeRow,
 	activityIDs map[int64]string,
 	opt *reportOptions,
 ) error {
-	hours := projectReport.Duration.Hours()
-	minutes := int(projectReport.Duration.Minutes()) % 60
-	if _, err := fmt.Fprintf(out, text(cmd, "report.project_line"), projectReport.ProjectName, int(hours), minutes); err != nil {
+	hours := row.node.Duration.Hours()
+	minutes := int(row.node.Duration.Minutes()) % 60
+	if _, err := fmt.Fprintf(out, text(cmd, "report.project_line"), row.label(), int(hours), minutes); err != nil {
 		return errors.Wrap(err, "write project summary")
 	}
 
-	if opt.Project != "" {
-		return writeProjectDescriptionSummary(cmd, out, projectReport)
-	}
-	if opt.Summary {
+	activities := row.activities(byProject)
+	if opt.Summary || len(activities) == 0 {
 		return nil
 	}
-
-	return writeProjectActivities(cmd, out, tf, projectReport.Activities, activityIDs)
+	if opt.Project != "" && !strings.HasSuffix(opt.Project, models.ProjectWildcard) {
+		return writeProjectDescriptionSummary(cmd, out, activities)
+	}
+	return writeProjectActivities(cmd, out, tf, activities, activityIDs)
 }
 
-func writeProjectDescriptionSummary(cmd *cobra.Command, out io.Writer, projectReport models.ProjectReport) error {
+func writeProjectDescriptionSummary(cmd *cobra.Command, out io.Writer, activities []models.Activity) error {
 	descriptions := make(map[string]time.Duration)
-	for _, activity := range projectReport.Activities {
+	for _, activity := range activities {
 		descriptions[activity.Description] += activity.Duration()
 	}
 
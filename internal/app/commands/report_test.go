@@ -3,13 +3,17 @@ package commands
 import (
 	"bytes"
 	"context"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
 	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/services/activity"
 )
 
 func TestRunReportCmdBuildsFilterAndWritesToCommandOutput(t *testing.T) {
@@ -91,3 +95,142 @@ func TestRunReportCmdBuildsInclusiveDateRange(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "8h 0m\n", out.String())
 }
+
+func TestRunReportCmdRendersTemplateWithPeriod(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	end := start.Add(45 * time.Minute)
+	service := &stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{
+				Activities:    []models.Activity{{Project: "tock", Description: "templates", StartTime: start, EndTime: &end}},
+				TotalDuration: 45 * time.Minute,
+			}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runReportCmd(cmd, &reportOptions{From: "2026-03-09", To: "2026-03-15", Template: "weekly-status"}))
+	assert.Contains(t, out.String(), "## Status 2026-03-09 – 2026-03-15")
+	assert.Contains(t, out.String(), "- **tock** — 0h 45m (100%)")
+
+	err := runReportCmd(cmd, &reportOptions{Template: "missing"})
+	require.ErrorContains(t, err, "template not found")
+}
+
+func TestRunReportCmdDedupeCountsOverlapOnce(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	onCallEnd := start.Add(4 * time.Hour)
+	focusEnd := start.Add(2 * time.Hour)
+	onCall := models.Activity{Project: "on-call", StartTime: start, EndTime: &onCallEnd}
+	focus := models.Activity{Project: "api", StartTime: start.Add(time.Hour), EndTime: &focusEnd}
+	service := &stubActivityResolver{
+		getReportFn: func(context.Context, models.ActivityFilter) (*models.Report, error) {
+			return &models.Report{
+				Activities:    []models.Activity{onCall, focus},
+				TotalDuration: 5 * time.Hour,
+				ByProject: map[string]models.ProjectReport{
+					"on-call": {ProjectName: "on-call", Duration: 4 * time.Hour, Activities: []models.Activity{onCall}},
+					"api":     {ProjectName: "api", Duration: time.Hour, Activities: []models.Activity{focus}},
+				},
+			}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runReportCmd(cmd, &reportOptions{Summary: true}))
+	assert.Contains(t, out.String(), "on-call: 4h 0m")
+
+	out.Reset()
+	require.NoError(t, runReportCmd(cmd, &reportOptions{Summary: true, Dedupe: true}))
+	assert.Contains(t, out.String(), "on-call: 3h 0m")
+	assert.Contains(t, out.String(), "api: 1h 0m")
+
+	out.Reset()
+	require.NoError(t, runReportCmd(cmd, &reportOptions{TotalOnly: true, Dedupe: true}))
+	assert.Equal(t, "4h 0m\n", out.String())
+}
+
+func TestReportCmdRejectsDedupeWithPerActivityOutputs(t *testing.T) {
+	for _, flag := range []string{"--json", "--template=timesheet"} {
+		cmd := NewReportCmd()
+		cmd.SetContext(newTestCLICommand(&stubActivityResolver{}).Context())
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--dedupe", flag})
+		require.ErrorContains(t, cmd.Execute(), "dedupe", flag)
+	}
+}
+
+func TestRunReportCmdRollsUpProjectTree(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	act := func(project string, offset, length time.Duration) models.Activity {
+		end := start.Add(offset + length)
+		return models.Activity{Project: project, Description: "work", StartTime: start.Add(offset), EndTime: &end}
+	}
+	api := act("acme/api", 0, time.Hour)
+	web := act("acme/web", time.Hour, 30*time.Minute)
+	home := act("home", 2*time.Hour, 15*time.Minute)
+
+	var gotFilter models.ActivityFilter
+	service := &stubActivityResolver{
+		getReportFn: func(_ context.Context, filter models.ActivityFilter) (*models.Report, error) {
+			gotFilter = filter
+			return &models.Report{
+				Activities:    []models.Activity{api, web, home},
+				TotalDuration: 105 * time.Minute,
+				ByProject: map[string]models.ProjectReport{
+					"acme/api": {ProjectName: "acme/api", Duration: time.Hour, Activities: []models.Activity{api}},
+					"acme/web": {ProjectName: "acme/web", Duration: 30 * time.Minute, Activities: []models.Activity{web}},
+					"home":     {ProjectName: "home", Duration: 15 * time.Minute, Activities: []models.Activity{home}},
+				},
+			}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).Config.Projects.Separator = "/"
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runReportCmd(cmd, &reportOptions{Summary: true}))
+	assert.Contains(t, out.String(), "📁 acme: 1h 30m\n📁   api: 1h 0m\n📁   web: 0h 30m\n📁 home: 0h 15m\n")
+
+	out.Reset()
+	require.NoError(t, runReportCmd(cmd, &reportOptions{Depth: 1, Project: "acme/..."}))
+	assert.Equal(t, new("acme/"), gotFilter.ProjectPrefix)
+	assert.Nil(t, gotFilter.Project)
+	assert.NotContains(t, out.String(), "api:")
+	section := out.String()[strings.Index(out.String(), "📁 acme"):strings.Index(out.String(), "📁 home")]
+	assert.Equal(t, 2, strings.Count(section, "| work"), "the collapsed children list their activities under acme")
+}
+
+func TestRunReportCmdProjectWildcardIncludesTimeOnTheRoot(t *testing.T) {
+	dir := t.TempDir()
+	svc := activity.NewService(file.NewRepository(filepath.Join(dir, ".tock.txt")), nil)
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	for i, project := range []string{"acme", "acme/api", "acme-corp"} {
+		_, err := svc.Add(context.Background(), models.AddActivityRequest{
+			Project:     project,
+			Description: "work",
+			StartTime:   start.Add(time.Duration(i) * time.Hour),
+			EndTime:     start.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	cmd := newTestCLICommand(&stubActivityResolver{getReportFn: svc.GetReport})
+	getRuntime(cmd).Config.Projects.Separator = "/"
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runReportCmd(cmd, &reportOptions{Summary: true, Project: "acme/..."}))
+	assert.Contains(t, out.String(), "📁 acme: 1h 0m\n📁   api: 0h 30m\n")
+	assert.NotContains(t, out.String(), "acme-corp")
+	assert.Contains(t, out.String(), "Total: 1h 0m")
+}
@@ -3,9 +3,13 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/app/projects"
 	appruntime "github.com/kriuchkov/tock/internal/app/runtime"
-	"github.com/kriuchkov/tock/internal/core/ports"
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
 
 	"github.com/spf13/cobra"
 )
@@ -14,16 +18,22 @@ const (
 	defaultRecentActivitiesForCompletion = 1000
 	appName                              = "tock"
 	cmdVersion                           = "version"
+	cmdMergeDriver                       = "merge-driver"
+	cmdUndo                              = "undo"
+	cmdRedo                              = "redo"
+	cmdHistory                           = "history"
+	cmdAudit                             = "audit"
 )
 
 var loadRuntime = appruntime.Load
-var loadCompletionService = appruntime.LoadCompletionService
+var loadCompletionRuntime = appruntime.Load
 
 func NewRootCmd() *cobra.Command {
 	var filePath string
 	var backend string
 	var configPath string
 	var language string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:     appName,
@@ -39,6 +49,7 @@ func NewRootCmd() *cobra.Command {
 				FilePath:   filePath,
 				ConfigPath: configPath,
 				Language:   language,
+				Force:      force,
 			})
 			if err != nil {
 				return fmt.Errorf("load runtime: %w", err)
@@ -64,23 +75,39 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&backend, "backend", "b", "", defaultText("root.flag.backend"))
 	cmd.PersistentFlags().StringVar(&configPath, "config", "", defaultText("root.flag.config"))
 	cmd.PersistentFlags().StringVar(&language, "lang", "", defaultText("root.flag.lang"))
+	cmd.PersistentFlags().BoolVar(&force, "force", false, defaultText("root.flag.force"))
 
 	cmd.AddCommand(NewStartCmd())
 	cmd.AddCommand(NewStopCmd())
 	cmd.AddCommand(NewAddCmd())
 	cmd.AddCommand(NewNoteCmd())
 	cmd.AddCommand(NewTagCmd())
+	cmd.AddCommand(NewTagsCmd())
 	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewReportCmd())
+	cmd.AddCommand(NewTemplateCmd())
 	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewImportCmd())
+	cmd.AddCommand(NewMergeCmd())
 	cmd.AddCommand(NewLastCmd())
 	cmd.AddCommand(NewContinueCmd())
 	cmd.AddCommand(NewCurrentCmd())
 	cmd.AddCommand(NewRemoveCmd())
+	cmd.AddCommand(NewUndoCmd())
+	cmd.AddCommand(NewRedoCmd())
+	cmd.AddCommand(NewHistoryCmd())
+	cmd.AddCommand(NewSearchCmd())
 	cmd.AddCommand(NewWatchCmd())
 	cmd.AddCommand(NewCalendarCmd())
+	cmd.AddCommand(NewWeekCmd())
+	cmd.AddCommand(NewHeatmapCmd())
 	cmd.AddCommand(NewAnalyzeCmd())
 	cmd.AddCommand(NewICalCmd())
+	cmd.AddCommand(NewSyncCmd())
+	cmd.AddCommand(NewAuditCmd())
+	cmd.AddCommand(NewLockCmd())
+	cmd.AddCommand(NewUnlockCmd())
+	cmd.AddCommand(NewProjectsCmd())
 	cmd.AddCommand(NewTrayCmd())
 	cmd.AddCommand(NewVersionCmd())
 	return cmd
@@ -90,6 +117,9 @@ func Execute() {
 	rootCmd := NewRootCmd()
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, coreErrors.ErrPeriodLocked) {
+			fmt.Fprint(os.Stderr, defaultText("lock.hint.force"))
+		}
 		os.Exit(1)
 	}
 }
@@ -102,12 +132,12 @@ func getRuntime(cmd *cobra.Command) *appruntime.Runtime {
 	return rt
 }
 
-func getServiceForCompletion(cmd *cobra.Command) (ports.ActivityResolver, error) {
+func getRuntimeForCompletion(cmd *cobra.Command) (*appruntime.Runtime, error) {
 	configPath, _ := cmd.Root().PersistentFlags().GetString("config")
 	backend, _ := cmd.Root().PersistentFlags().GetString("backend")
 	filePath, _ := cmd.Root().PersistentFlags().GetString("file")
 
-	return loadCompletionService(cmd.Context(), appruntime.Request{
+	return loadCompletionRuntime(cmd.Context(), appruntime.Request{
 		Backend:    backend,
 		FilePath:   filePath,
 		ConfigPath: configPath,
@@ -116,13 +146,23 @@ func getServiceForCompletion(cmd *cobra.Command) (ports.ActivityResolver, error)
 
 func shouldSkipRuntimeContext(cmd *cobra.Command) bool {
 	switch cmd.Name() {
-	case cmdVersion, "completion":
+	case cmdVersion, "completion", cmdMergeDriver:
+		// The merge driver runs inside git operations and must not touch the data file.
 		return true
 	}
 	return false
 }
 
 func shouldSkipWorkingHoursAutoStop(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cmdUndo, cmdRedo, cmdHistory:
+		// An automatic stop would become the change that undo reverts.
+		return true
+	}
+	if cmd.HasParent() && cmd.Parent().Name() == cmdAudit {
+		// Verifying must look at the data as it is.
+		return true
+	}
 	if cmd.Name() != "stop" {
 		return false
 	}
@@ -144,37 +184,56 @@ func printWorkingHoursAutoStopNotice(cmd *cobra.Command) {
 	)
 }
 
+// projectRegisterFlagCompletion completes recent projects that are not
+// archived, followed by the rest of the project catalog.
 func projectRegisterFlagCompletion(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	svc, err := getServiceForCompletion(cmd)
+	rt, err := getRuntimeForCompletion(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	acts, err := rt.ActivityService.GetRecent(cmd.Context(), defaultRecentActivitiesForCompletion)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
-	acts, err := svc.GetRecent(cmd.Context(), defaultRecentActivitiesForCompletion)
+	archived, err := rt.Archive.Hidden()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
 	seen := make(map[string]bool)
-	var projects []string
+	var result []string
+	candidates := make([]string, 0, len(acts))
 	for _, a := range acts {
-		if a.Project != "" && !seen[a.Project] {
-			seen[a.Project] = true
-			projects = append(projects, a.Project)
+		candidates = append(candidates, a.Project)
+	}
+	for _, project := range rt.Catalog.Projects() {
+		if !strings.HasSuffix(project, projects.Wildcard) {
+			candidates = append(candidates, project)
+		}
+	}
+	for _, project := range candidates {
+		if project == "" || seen[project] || archived(project) {
+			continue
+		}
+		if rt.Catalog.Strict() && !rt.Catalog.Allows(project) {
+			continue
 		}
+		seen[project] = true
+		result = append(result, project)
 	}
 
-	return projects, cobra.ShellCompDirectiveNoFileComp
+	return result, cobra.ShellCompDirectiveNoFileComp
 }
 
 func descriptionRegisterFlagCompletion(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	svc, err := getServiceForCompletion(cmd)
+	rt, err := getRuntimeForCompletion(cmd)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
 	projectFilter, _ := cmd.Flags().GetString("project")
 
-	acts, err := svc.GetRecent(cmd.Context(), defaultRecentActivitiesForCompletion)
+	acts, err := rt.ActivityService.GetRecent(cmd.Context(), defaultRecentActivitiesForCompletion)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
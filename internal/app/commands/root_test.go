@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/kriuchkov/tock/internal/app/localization"
 	appruntime "github.com/kriuchkov/tock/internal/app/runtime"
 	"github.com/kriuchkov/tock/internal/config"
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
 	"github.com/kriuchkov/tock/internal/core/models"
 	"github.com/kriuchkov/tock/internal/timeutil"
 )
@@ -136,3 +138,64 @@ func TestRootPersistentPreRunAutoStopsActivityOutsideWorkingHours(t *testing.T)
 	assert.Empty(t, stdout.String())
 	assert.Contains(t, stderr.String(), "Automatically stopped activity: tock | review at 17:30")
 }
+
+func TestRootPersistentPreRunPassesForce(t *testing.T) {
+	loader := loadRuntime
+	t.Cleanup(func() {
+		loadRuntime = loader
+	})
+
+	var gotReq appruntime.Request
+	loadRuntime = func(_ context.Context, req appruntime.Request) (*appruntime.Runtime, error) {
+		gotReq = req
+		return &appruntime.Runtime{ActivityService: &stubActivityResolver{}, Config: &config.Config{}}, nil
+	}
+
+	root := NewRootCmd()
+	root.SetContext(context.Background())
+	require.NoError(t, root.ParseFlags([]string{"--force"}))
+	require.NoError(t, root.PersistentPreRunE(root, nil))
+	assert.True(t, gotReq.Force)
+}
+
+func TestRootPersistentPreRunLeavesActivityRunningWhenStopTimeIsLocked(t *testing.T) {
+	loader := loadRuntime
+	clock := currentWorkingHoursTime
+	t.Cleanup(func() {
+		loadRuntime = loader
+		currentWorkingHoursTime = clock
+	})
+
+	running := models.Activity{
+		Project:     "tock",
+		Description: "review",
+		StartTime:   time.Date(2026, time.September, 30, 16, 0, 0, 0, time.Local),
+	}
+	svc := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return []models.Activity{running}, nil
+		},
+		stopFn: func(context.Context, models.StopActivityRequest) (*models.Activity, error) {
+			return nil, fmt.Errorf("2026-09-30 17:30 is before 2026-10-01 00:00: %w", coreErrors.ErrPeriodLocked)
+		},
+	}
+	loadRuntime = func(context.Context, appruntime.Request) (*appruntime.Runtime, error) {
+		return &appruntime.Runtime{
+			ActivityService: svc,
+			Config: &config.Config{
+				WorkingHours: config.WorkingHoursConfig{Enabled: true, StopAt: "17:30"},
+			},
+			TimeFormatter: timeutil.NewFormatter("24"),
+			Localizer:     localization.MustNew(localization.LanguageEnglish),
+		}, nil
+	}
+	currentWorkingHoursTime = func() time.Time {
+		return time.Date(2026, time.October, 1, 9, 0, 0, 0, time.Local)
+	}
+
+	root := NewRootCmd()
+	root.SetContext(context.Background())
+	require.NoError(t, root.PersistentPreRunE(root, nil))
+	_, ok := autoStoppedActivityFromContext(root.Context())
+	assert.False(t, ok)
+}
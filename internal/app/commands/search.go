@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-faster/errors"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+const (
+	searchDefaultWidth  = 100
+	searchDefaultHeight = 24
+	searchChromeHeight  = 8
+)
+
+var runSearchProgram = func(model searchModel) (searchModel, error) {
+	program := tea.NewProgram(&model, tea.WithAltScreen())
+	finalModel, err := program.Run()
+	if err != nil {
+		return searchModel{}, errors.Wrap(err, "run program")
+	}
+	return *finalModel.(*searchModel), nil //nolint:errcheck // safe cast
+}
+
+var copyToClipboard = termenv.Copy
+
+type searchAction int
+
+const (
+	searchActionNone searchAction = iota
+	searchActionContinue
+	searchActionEdit
+	searchActionRemove
+	searchActionCopy
+)
+
+func NewSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "search [QUERY]",
+		Aliases: []string{"find"},
+		Short:   "Fuzzy-find activities in your history",
+		Long:    defaultText("search.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearchCmd(cmd, args)
+		},
+	}
+	return cmd
+}
+
+func runSearchCmd(cmd *cobra.Command, args []string) error {
+	rt := getRuntime(cmd)
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	if len(activities) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), text(cmd, "search.empty"))
+		return nil
+	}
+
+	theme := GetTheme(rt.Config.Theme)
+	model, err := runSearchProgram(newSearchModel(activities, strings.Join(args, " "), rt.TimeFormatter, theme))
+	if err != nil {
+		return err
+	}
+
+	entry, ok := model.selectedEntry()
+	if model.list.canceled || model.action == searchActionNone || !ok {
+		return nil
+	}
+	return applySearchAction(cmd, model.action, entry, theme)
+}
+
+func applySearchAction(cmd *cobra.Command, action searchAction, entry searchEntry, theme Theme) error {
+	rt := getRuntime(cmd)
+	ctx := cmd.Context()
+	svc := rt.ActivityService
+	tf := rt.TimeFormatter
+	out := cmd.OutOrStdout()
+
+	switch action {
+	case searchActionContinue:
+		started, err := svc.Start(ctx, models.StartActivityRequest{
+			Description: entry.activity.Description,
+			Project:     entry.activity.Project,
+			StartTime:   time.Now(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "start activity")
+		}
+		ensureTrayRunning(cmd)
+		_, err = fmt.Fprintf(out, text(cmd, "message.activity_started"),
+			started.Project, started.Description, started.StartTime.Format(tf.GetDisplayFormat()))
+		return err
+	case searchActionEdit:
+		form, err := runActivityFormProgram(newActivityFormModel(text(cmd, "search.edit_title", entry.key), entry.activity, tf, theme))
+		if err != nil {
+			return err
+		}
+		if !form.submitted {
+			return nil
+		}
+		req, err := form.request()
+		if err != nil {
+			return err
+		}
+		// A kept project is not checked again, as in the calendar.
+		if req.Project != entry.activity.Project {
+			if req.Project, err = resolveProject(cmd, req.Project); err != nil {
+				return err
+			}
+		}
+		updated, err := svc.Edit(ctx, entry.activity, req)
+		if err != nil {
+			return errors.Wrap(err, "edit activity")
+		}
+		_, err = fmt.Fprintf(out, text(cmd, "search.edited"), updated.Project, updated.Description)
+		return err
+	case searchActionRemove:
+		confirmed, err := confirmRemoval(out, cmd.InOrStdin(), entry.activity)
+		if err != nil || !confirmed {
+			return err
+		}
+		if err = svc.Remove(ctx, entry.activity); err != nil {
+			return errors.Wrap(err, "remove activity")
+		}
+		fmt.Fprintln(out, text(cmd, "remove.done"))
+		return nil
+	case searchActionCopy:
+		copyToClipboard(entry.key)
+		_, err := fmt.Fprintf(out, text(cmd, "search.copied"), entry.key)
+		return err
+	case searchActionNone:
+	}
+	return nil
+}
+
+type searchEntry struct {
+	activity models.Activity
+	key      string
+}
+
+// searchModel wraps simpleListModel, which owns the query, fuzzy filtering and
+// cursor, and adds the action keys and a preview of the highlighted activity.
+type searchModel struct {
+	list    simpleListModel
+	entries []searchEntry
+	action  searchAction
+	tf      *timeutil.Formatter
+	theme   Theme
+	width   int
+	height  int
+}
+
+func newSearchModel(activities []models.Activity, query string, tf *timeutil.Formatter, theme Theme) searchModel {
+	ids := models.ActivitySequenceIDs(activities)
+	sorted := models.SortActivitiesByStart(activities)
+	slices.Reverse(sorted)
+
+	entries := make([]searchEntry, 0, len(sorted))
+	haystacks := make([]string, 0, len(sorted))
+	for _, activity := range sorted {
+		entry := searchEntry{activity: activity, key: ids[activity.StartTime.UnixNano()]}
+		entries = append(entries, entry)
+		haystacks = append(haystacks, searchHaystack(entry))
+	}
+
+	list := simpleListModel{
+		title:  defaultText("search.title"),
+		all:    haystacks,
+		filter: query,
+		theme:  theme,
+	}
+	list.applyFilter()
+
+	return searchModel{
+		list:    list,
+		entries: entries,
+		tf:      tf,
+		theme:   theme,
+		width:   searchDefaultWidth,
+		height:  searchDefaultHeight,
+	}
+}
+
+// searchHaystack flattens every searchable field of an entry into one line.
+func searchHaystack(entry searchEntry) string {
+	parts := []string{entry.key, entry.activity.Project, entry.activity.Description}
+	for _, tag := range entry.activity.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if notes := strings.Join(strings.Fields(entry.activity.Notes), " "); notes != "" {
+		parts = append(parts, notes)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m *searchModel) selectedEntry() (searchEntry, bool) {
+	if len(m.list.matches) == 0 || m.list.cursor >= len(m.list.matches) {
+		return searchEntry{}, false
+	}
+	return m.entries[m.list.matches[m.list.cursor]], true
+}
+
+func (m *searchModel) Init() tea.Cmd { return nil }
+
+func (m *searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		//nolint:exhaustive // bubbletea keyMsg.Type is not exhaustive
+		switch msg.Type {
+		case tea.KeyEnter:
+			return m, m.choose(searchActionContinue)
+		case tea.KeyCtrlE:
+			return m, m.choose(searchActionEdit)
+		case tea.KeyCtrlD:
+			return m, m.choose(searchActionRemove)
+		case tea.KeyCtrlY:
+			return m, m.choose(searchActionCopy)
+		case tea.KeyTab:
+			return m, nil
+		}
+		_, cmd := m.list.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *searchModel) choose(action searchAction) tea.Cmd {
+	if _, ok := m.selectedEntry(); !ok {
+		return nil
+	}
+	m.action = action
+	return tea.Quit
+}
+
+func (m *searchModel) View() string {
+	t := m.theme
+	listWidth := max(m.width*55/100, 30)
+	previewWidth := max(m.width-listWidth-8, 20)
+	rows := max(m.height-searchChromeHeight, 3)
+
+	header := lipgloss.NewStyle().Bold(true).Foreground(t.Highlight).Render(m.list.title) + "  " +
+		lipgloss.NewStyle().Foreground(t.Faint).Render(defaultText("search.count", len(m.list.matches), len(m.entries)))
+
+	input := defaultText("interactive.filter_label") + m.list.filter + lipgloss.NewStyle().Blink(true).Render("█")
+
+	list := strings.Builder{}
+	start, end := 0, len(m.list.matches)
+	if end > rows {
+		start, end = m.list.calculateWindow(rows, end)
+	}
+	rowStyle := lipgloss.NewStyle().MaxWidth(listWidth - 2)
+	for i := start; i < end; i++ {
+		entry := m.entries[m.list.matches[i]]
+		label := fmt.Sprintf("%s  %s  %s", entry.key, entry.activity.Project, entry.activity.Description)
+		if i == m.list.cursor {
+			list.WriteString("> " + rowStyle.Foreground(t.Text).Background(t.Primary).Bold(true).Render(label) + "\n")
+			continue
+		}
+		list.WriteString("  " + rowStyle.Render(label) + "\n")
+	}
+	if len(m.list.matches) == 0 {
+		list.WriteString(defaultText("search.no_matches") + "\n")
+	}
+
+	preview := lipgloss.NewStyle().
+		Width(previewWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Faint).
+		Padding(0, 1).
+		Render(m.preview(previewWidth - 2))
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(listWidth).Render(list.String()),
+		preview,
+	)
+
+	help := lipgloss.NewStyle().Foreground(t.Faint).Render(defaultText("search.help"))
+	return lipgloss.NewStyle().Margin(1, 2).Render(header + "\n" + input + "\n\n" + body + "\n\n" + help)
+}
+
+func (m *searchModel) preview(width int) string {
+	entry, ok := m.selectedEntry()
+	if !ok {
+		return ""
+	}
+
+	t := m.theme
+	activity := entry.activity
+	label := lipgloss.NewStyle().Foreground(t.SubText).Width(13)
+	layout := "2006-01-02 " + m.tf.GetDisplayFormat()
+
+	end := defaultText("search.preview.running")
+	if activity.EndTime != nil {
+		end = activity.EndTime.Format(layout)
+	}
+
+	lines := []string{
+		label.Render(defaultText("search.preview.key")) + entry.key,
+		label.Render(defaultText("form.project")) + lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(activity.Project),
+		label.Render(defaultText("form.description")) + activity.Description,
+		label.Render(defaultText("form.start")) + activity.StartTime.Format(layout),
+		label.Render(defaultText("form.end")) + end,
+		label.Render(defaultText("list.table.duration")) + activity.DurationString(),
+	}
+	if len(activity.Tags) > 0 {
+		lines = append(lines, label.Render(defaultText("form.tags"))+
+			lipgloss.NewStyle().Foreground(t.Tag).Render(strings.Join(activity.Tags, ", ")))
+	}
+	if activity.Notes != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Width(width).Render(activity.Notes))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func searchFixture() []models.Activity {
+	end1 := time.Date(2026, time.March, 14, 10, 0, 0, 0, time.Local)
+	end2 := time.Date(2026, time.March, 15, 12, 0, 0, 0, time.Local)
+	return []models.Activity{
+		{
+			Project:     "tock",
+			Description: "fuzzy search",
+			StartTime:   time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local),
+			EndTime:     &end1,
+			Tags:        []string{"cli"},
+		},
+		{
+			Project:     "ops",
+			Description: "deploy",
+			StartTime:   time.Date(2026, time.March, 15, 11, 0, 0, 0, time.Local),
+			EndTime:     &end2,
+			Notes:       "rolled back the canary",
+		},
+	}
+}
+
+func TestNewSearchModelListsNewestFirstAndMatchesNotes(t *testing.T) {
+	model := newSearchModel(searchFixture(), "", timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+
+	entry, ok := model.selectedEntry()
+	require.True(t, ok)
+	assert.Equal(t, "2026-03-15-01", entry.key)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#cli")})
+	entry, ok = model.selectedEntry()
+	require.True(t, ok)
+	assert.Equal(t, "fuzzy search", entry.activity.Description)
+
+	model = newSearchModel(searchFixture(), "canary", timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+	entry, ok = model.selectedEntry()
+	require.True(t, ok)
+	assert.Equal(t, "deploy", entry.activity.Description)
+	assert.Contains(t, model.View(), "rolled back the canary")
+}
+
+func TestSearchModelActionKeysQuitWithSelection(t *testing.T) {
+	model := newSearchModel(searchFixture(), "", timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+	require.NotNil(t, cmd)
+	assert.Equal(t, searchActionCopy, model.action)
+
+	empty := newSearchModel(searchFixture(), "zzzz", timeutil.NewFormatter("24"), GetTheme(config.ThemeConfig{}))
+	_, cmd = empty.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, searchActionNone, empty.action)
+}
+
+func TestRunSearchCmdContinuesSelectedActivity(t *testing.T) {
+	runner := runSearchProgram
+	t.Cleanup(func() { runSearchProgram = runner })
+
+	runSearchProgram = func(model searchModel) (searchModel, error) {
+		assert.Equal(t, "deploy", model.list.filter)
+		model.action = searchActionContinue
+		return model, nil
+	}
+
+	var started models.StartActivityRequest
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return searchFixture(), nil
+		},
+		startFn: func(_ context.Context, req models.StartActivityRequest) (*models.Activity, error) {
+			started = req
+			return &models.Activity{Project: req.Project, Description: req.Description, StartTime: req.StartTime}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runSearchCmd(cmd, []string{"deploy"}))
+	assert.Equal(t, "ops", started.Project)
+	assert.Equal(t, "deploy", started.Description)
+	assert.Contains(t, out.String(), "ops")
+}
+
+func TestRunSearchCmdEditsSelectedActivity(t *testing.T) {
+	searchRunner, formRunner := runSearchProgram, runActivityFormProgram
+	t.Cleanup(func() {
+		runSearchProgram = searchRunner
+		runActivityFormProgram = formRunner
+	})
+
+	runSearchProgram = func(model searchModel) (searchModel, error) {
+		model.action = searchActionEdit
+		return model, nil
+	}
+	runActivityFormProgram = func(model activityFormModel) (activityFormModel, error) {
+		model.values[formFieldDescription] = "deploy v2"
+		model.values[formFieldTags] = "release, ops"
+		model.submit()
+		return model, nil
+	}
+
+	var got models.EditActivityRequest
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return searchFixture(), nil
+		},
+		editFn: func(_ context.Context, _ models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+			got = req
+			return &models.Activity{Project: req.Project, Description: req.Description}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runSearchCmd(cmd, nil))
+	assert.Equal(t, "deploy v2", got.Description)
+	assert.Equal(t, []string{"release", "ops"}, got.Tags)
+	require.NotNil(t, got.EndTime)
+	assert.Equal(t, "Activity updated: ops | deploy v2\n", out.String())
+}
+
+func TestRunSearchCmdCopiesKey(t *testing.T) {
+	searchRunner, copier := runSearchProgram, copyToClipboard
+	t.Cleanup(func() {
+		runSearchProgram = searchRunner
+		copyToClipboard = copier
+	})
+
+	runSearchProgram = func(model searchModel) (searchModel, error) {
+		model.action = searchActionCopy
+		return model, nil
+	}
+	var copied string
+	copyToClipboard = func(value string) { copied = value }
+
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return searchFixture(), nil
+		},
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runSearchCmd(cmd, []string{"fuzzy"}))
+	assert.Equal(t, "2026-03-14-01", copied)
+	assert.Equal(t, "Copied 2026-03-14-01 to clipboard\n", out.String())
+}
+
+func TestRunSearchCmdRemovesAfterConfirmation(t *testing.T) {
+	runner := runSearchProgram
+	t.Cleanup(func() { runSearchProgram = runner })
+
+	runSearchProgram = func(model searchModel) (searchModel, error) {
+		model.action = searchActionRemove
+		return model, nil
+	}
+
+	var removed models.Activity
+	cmd := newTestCLICommand(&stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return searchFixture(), nil
+		},
+		removeFn: func(_ context.Context, activity models.Activity) error {
+			removed = activity
+			return nil
+		},
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(bytes.NewBufferString("y\n"))
+
+	require.NoError(t, runSearchCmd(cmd, []string{"fuzzy"}))
+	assert.Equal(t, "fuzzy search", removed.Description)
+	assert.Contains(t, out.String(), "Activity removed.")
+}
@@ -19,6 +19,7 @@ type startOptions struct {
 	At          string
 	Notes       string
 	Tags        []string
+	Parallel    bool
 	JSONOutput  bool
 }
 
@@ -48,6 +49,7 @@ func NewStartCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.At, "time", "t", "", defaultText("start.flag.time"))
 	cmd.Flags().StringVar(&opts.Notes, "note", "", defaultText("start.flag.note"))
 	cmd.Flags().StringSliceVar(&opts.Tags, "tag", nil, defaultText("start.flag.tag"))
+	cmd.Flags().BoolVar(&opts.Parallel, "parallel", false, defaultText("start.flag.parallel"))
 	cmd.Flags().BoolVar(&opts.JSONOutput, "json", false, defaultText("start.flag.json"))
 
 	_ = cmd.RegisterFlagCompletionFunc("description", descriptionRegisterFlagCompletion)
@@ -71,7 +73,7 @@ func runStartCmd(cmd *cobra.Command, args []string, opts *startOptions) error {
 	startTime := time.Now()
 	if opts.At != "" {
 		var err error
-		startTime, err = tf.ParseTime(opts.At)
+		startTime, err = tf.ParseTimeWithDate(opts.At)
 		if err != nil {
 			return errors.Wrap(err, "parse time")
 		}
@@ -97,9 +99,12 @@ func runStartCmd(cmd *cobra.Command, args []string, opts *startOptions) error {
 	if project == "" || description == "" {
 		activities, _ := service.List(cmd.Context(), models.ActivityFilter{})
 		theme := GetTheme(rt.Config.Theme)
+		archived, err := rt.Archive.Hidden()
+		if err != nil {
+			return err
+		}
 
-		var err error
-		project, description, err = SelectActivityMetadata(activities, project, description, theme)
+		project, description, err = SelectActivityMetadata(activities, archived, project, description, theme)
 		if err != nil {
 			return errors.Wrap(err, "select activity metadata")
 		}
@@ -111,12 +116,18 @@ func runStartCmd(cmd *cobra.Command, args []string, opts *startOptions) error {
 		}
 	}
 
+	project, err := resolveProject(cmd, project)
+	if err != nil {
+		return err
+	}
+
 	activity, err := service.Start(cmd.Context(), models.StartActivityRequest{
 		Description: description,
 		Project:     project,
 		StartTime:   startTime,
 		Notes:       notes,
 		Tags:        tags,
+		Parallel:    opts.Parallel,
 	})
 	if err != nil {
 		return errors.Wrap(err, "start activity")
@@ -50,3 +50,39 @@ func TestRunStartCmdJSONUsesCommandWriter(t *testing.T) {
 	assert.Contains(t, out.String(), "\"project\": \"tock\"")
 	assert.Contains(t, out.String(), "\"description\": \"json\"")
 }
+
+func TestRunStartCmdParallel(t *testing.T) {
+	service := &stubActivityResolver{
+		startFn: func(_ context.Context, req models.StartActivityRequest) (*models.Activity, error) {
+			assert.True(t, req.Parallel)
+			return &models.Activity{Project: req.Project, Description: req.Description, StartTime: req.StartTime}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := runStartCmd(cmd, []string{"on-call", "pager"}, &startOptions{Parallel: true})
+	require.NoError(t, err)
+}
+
+func TestRunStartCmdAcceptsRelativeTime(t *testing.T) {
+	var started time.Time
+	service := &stubActivityResolver{
+		startFn: func(_ context.Context, req models.StartActivityRequest) (*models.Activity, error) {
+			started = req.StartTime
+			return &models.Activity{Project: req.Project, Description: req.Description, StartTime: req.StartTime}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	cmd.SetOut(&bytes.Buffer{})
+
+	before := time.Now().Truncate(time.Minute)
+	require.NoError(t, runStartCmd(cmd, nil, &startOptions{Project: "tock", Description: "late", At: "15 min ago"}))
+	assert.WithinDuration(t, before.Add(-15*time.Minute), started, time.Minute)
+
+	require.NoError(t, runStartCmd(cmd, nil, &startOptions{Project: "tock", Description: "late", At: "yesterday 18:00"}))
+	yesterday := time.Now().AddDate(0, 0, -1)
+	assert.Equal(t, time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 18, 0, 0, 0, time.Local), started)
+}
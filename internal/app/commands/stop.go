@@ -15,6 +15,7 @@ import (
 
 type stopOptions struct {
 	At         string
+	Project    string
 	Notes      string
 	Tags       []string
 	JSONOutput bool
@@ -24,19 +25,24 @@ func NewStopCmd() *cobra.Command {
 	var opts stopOptions
 
 	cmd := &cobra.Command{
-		Use:     "stop",
+		Use:     "stop [KEY]",
 		Aliases: []string{"s"},
 		Short:   defaultText("stop.short"),
-		RunE:    func(cmd *cobra.Command, _ []string) error { return runStopCmd(cmd, &opts) },
+		Long:    defaultText("stop.long"),
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    func(cmd *cobra.Command, args []string) error { return runStopCmd(cmd, args, &opts) },
 	}
 	cmd.Flags().StringVarP(&opts.At, "time", "t", "", defaultText("stop.flag.time"))
+	cmd.Flags().StringVarP(&opts.Project, "project", "p", "", defaultText("stop.flag.project"))
 	cmd.Flags().StringVar(&opts.Notes, "note", "", defaultText("stop.flag.note"))
 	cmd.Flags().StringSliceVar(&opts.Tags, "tag", nil, defaultText("stop.flag.tag"))
 	cmd.Flags().BoolVar(&opts.JSONOutput, "json", false, defaultText("stop.flag.json"))
+
+	_ = cmd.RegisterFlagCompletionFunc("project", projectRegisterFlagCompletion)
 	return cmd
 }
 
-func runStopCmd(cmd *cobra.Command, opts *stopOptions) error {
+func runStopCmd(cmd *cobra.Command, args []string, opts *stopOptions) error {
 	defer runUpdateCheck(cmd)
 
 	rt := getRuntime(cmd)
@@ -47,21 +53,27 @@ func runStopCmd(cmd *cobra.Command, opts *stopOptions) error {
 	endTime := time.Now()
 	if opts.At != "" {
 		var err error
-		endTime, err = tf.ParseTime(opts.At)
+		endTime, err = tf.ParseTimeWithDate(opts.At)
 		if err != nil {
 			return errors.Wrap(err, "parse time")
 		}
 	}
 
+	target, err := resolveStopTarget(cmd, args, opts.Project)
+	if err != nil {
+		return err
+	}
+
 	req := models.StopActivityRequest{
 		EndTime: endTime,
 		Notes:   opts.Notes,
 		Tags:    opts.Tags,
+		Target:  target,
 	}
 
 	activity, err := service.Stop(cmd.Context(), req)
 	if err != nil {
-		if errors.Is(err, coreErrors.ErrNoActiveActivity) {
+		if target == nil && errors.Is(err, coreErrors.ErrNoActiveActivity) {
 			if autoStopped, ok := autoStoppedActivityFromContext(cmd.Context()); ok {
 				return writeStoppedActivity(cmd, out, tf, autoStopped, opts.JSONOutput)
 			}
@@ -72,6 +84,42 @@ func runStopCmd(cmd *cobra.Command, opts *stopOptions) error {
 	return writeStoppedActivity(cmd, out, tf, activity, opts.JSONOutput)
 }
 
+// resolveStopTarget returns the running activity picked by its key or by its
+// project, the latest one of the project if several run. Without either it
+// returns nil, and the latest running activity is stopped.
+func resolveStopTarget(cmd *cobra.Command, args []string, project string) (*models.Activity, error) {
+	if len(args) == 0 && project == "" {
+		return nil, nil
+	}
+	if len(args) > 0 && project != "" {
+		return nil, errors.New(text(cmd, "stop.error.key_and_project"))
+	}
+
+	ctx := cmd.Context()
+	service := getRuntime(cmd).ActivityService
+	if len(args) > 0 {
+		activity, err := findActivityByIndex(ctx, service, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if activity.EndTime != nil {
+			return nil, errors.New(text(cmd, "stop.error.not_running", args[0], activity.Project, activity.Description))
+		}
+		return &activity, nil
+	}
+
+	isRunning := true
+	running, err := service.List(ctx, models.ActivityFilter{IsRunning: &isRunning, Project: &project})
+	if err != nil {
+		return nil, errors.Wrap(err, "list running activities")
+	}
+	if len(running) == 0 {
+		return nil, errors.New(text(cmd, "stop.error.project_not_running", project))
+	}
+	latest := latestRunningActivity(running)
+	return &latest, nil
+}
+
 func writeStoppedActivity(
 	cmd *cobra.Command,
 	out io.Writer,
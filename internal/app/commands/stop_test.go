@@ -30,7 +30,7 @@ func TestRunStopCmdUsesOptionsAndWriter(t *testing.T) {
 	var out bytes.Buffer
 	cmd.SetOut(&out)
 
-	err := runStopCmd(cmd, &stopOptions{At: "18:45", Notes: "closing", Tags: []string{"done"}})
+	err := runStopCmd(cmd, nil, &stopOptions{At: "18:45", Notes: "closing", Tags: []string{"done"}})
 	require.NoError(t, err)
 	assert.Contains(t, out.String(), "Stopped activity: tock | cleanup at 18:45")
 }
@@ -47,7 +47,7 @@ func TestRunStopCmdJSONUsesCommandWriter(t *testing.T) {
 	var out bytes.Buffer
 	cmd.SetOut(&out)
 
-	err := runStopCmd(cmd, &stopOptions{JSONOutput: true})
+	err := runStopCmd(cmd, nil, &stopOptions{JSONOutput: true})
 	require.NoError(t, err)
 	assert.Contains(t, out.String(), "\"project\": \"tock\"")
 	assert.Contains(t, out.String(), "\"description\": \"cleanup\"")
@@ -72,7 +72,63 @@ func TestRunStopCmdUsesAutoStoppedActivityFromContext(t *testing.T) {
 	var out bytes.Buffer
 	cmd.SetOut(&out)
 
-	err := runStopCmd(cmd, &stopOptions{})
+	err := runStopCmd(cmd, nil, &stopOptions{})
 	require.NoError(t, err)
 	assert.Contains(t, out.String(), "Stopped activity: tock | cleanup at 17:30")
 }
+
+func TestRunStopCmdTargetsRunningActivity(t *testing.T) {
+	day := time.Date(2026, time.April, 21, 0, 0, 0, 0, time.Local)
+	onCall := models.Activity{Project: "on-call", Description: "pager", StartTime: day.Add(8 * time.Hour)}
+	focus := models.Activity{Project: "api", Description: "tests", StartTime: day.Add(9 * time.Hour)}
+	later := models.Activity{Project: "api", Description: "review", StartTime: day.Add(10 * time.Hour)}
+	var target *models.Activity
+	service := &stubActivityResolver{
+		listFn: func(_ context.Context, filter models.ActivityFilter) ([]models.Activity, error) {
+			if filter.Project != nil {
+				assert.True(t, *filter.IsRunning)
+				assert.Equal(t, "api", *filter.Project)
+				return []models.Activity{focus, later}, nil
+			}
+			return []models.Activity{onCall, focus}, nil
+		},
+		stopFn: func(_ context.Context, req models.StopActivityRequest) (*models.Activity, error) {
+			target = req.Target
+			stopped := *req.Target
+			stopped.EndTime = &req.EndTime
+			return &stopped, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runStopCmd(cmd, []string{"2026-04-21-01"}, &stopOptions{}))
+	assert.Equal(t, &onCall, target)
+	assert.Contains(t, out.String(), "Stopped activity: on-call | pager")
+
+	require.NoError(t, runStopCmd(cmd, nil, &stopOptions{Project: "api"}))
+	assert.Equal(t, &later, target, "the latest running activity of the project")
+
+	err := runStopCmd(cmd, []string{"2026-04-21-01"}, &stopOptions{Project: "api"})
+	require.ErrorContains(t, err, "either a key or --project")
+}
+
+func TestRunStopCmdRejectsStoppedActivity(t *testing.T) {
+	start := time.Date(2026, time.April, 21, 8, 0, 0, 0, time.Local)
+	end := start.Add(time.Hour)
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return []models.Activity{{Project: "api", Description: "tests", StartTime: start, EndTime: &end}}, nil
+		},
+	}
+
+	cmd := newTestCLICommand(service)
+	err := runStopCmd(cmd, []string{"2026-04-21-01"}, &stopOptions{})
+	require.ErrorContains(t, err, "activity 2026-04-21-01 (api | tests) is not running")
+
+	service.listFn = func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return nil, nil }
+	err = runStopCmd(cmd, nil, &stopOptions{Project: "web"})
+	require.ErrorContains(t, err, "no running activity in project web")
+}
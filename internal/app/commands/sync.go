@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/caldav"
+	"github.com/kriuchkov/tock/internal/app/gitsync"
+	appruntime "github.com/kriuchkov/tock/internal/app/runtime"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+type syncCalDAVOptions struct {
+	Today     bool
+	Yesterday bool
+	Date      string
+	From      string
+	To        string
+	Period    string
+	Since     string
+	URL       string
+	DryRun    bool
+}
+
+// NewSyncCmd pulls and pushes the git repository of the data file; its
+// subcommands sync with other systems.
+func NewSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync activities with other systems",
+		Long:  defaultText("sync.long"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runSyncGitCmd(cmd) },
+	}
+
+	cmd.AddCommand(newSyncInitCmd())
+	cmd.AddCommand(newSyncMergeDriverCmd())
+	cmd.AddCommand(newSyncCalDAVCmd())
+	return cmd
+}
+
+func newSyncInitCmd() *cobra.Command {
+	var remoteURL string
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: defaultText("sync.init.short"),
+		Long:  defaultText("sync.init.long"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runSyncInitCmd(cmd, remoteURL) },
+	}
+
+	cmd.Flags().StringVar(&remoteURL, "remote", "", defaultText("sync.init.flag.remote"))
+	return cmd
+}
+
+func newSyncMergeDriverCmd() *cobra.Command {
+	var backend string
+
+	cmd := &cobra.Command{
+		Use:    cmdMergeDriver + " BASE OURS THEIRS",
+		Short:  "Merge two versions of the data file (used by git)",
+		Args:   cobra.ExactArgs(3),
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parse, ok := appruntime.LineParser(backend)
+			if !ok {
+				return errors.Errorf("no merge driver for the %s backend", backend)
+			}
+			return gitsync.MergeFiles(args[0], args[1], args[2], parse, cmd.ErrOrStderr())
+		},
+	}
+
+	cmd.Flags().StringVar(&backend, "format", "file", "Data file format: file or todotxt")
+	return cmd
+}
+
+func runSyncGitCmd(cmd *cobra.Command) error {
+	rt := getRuntime(cmd)
+	if !rt.Config.Git.Enabled || rt.Git == nil {
+		return errors.New(text(cmd, "sync.git.error.disabled"))
+	}
+
+	// A pull changes the data without going through the activity service,
+	// so the audit log records the synced state. It only does so when the
+	// data matched the log before, so changes made by hand stay reported.
+	auditSync := auditIntact(cmd)
+	conflicts, err := rt.Git.Sync(cmd.Context(), rt.Config.Git.Remote, rt.Config.Git.Branch)
+	if err != nil {
+		return errors.Wrap(err, "sync")
+	}
+	if auditSync {
+		if _, err = rt.Audit.Synced(cmd.Context()); err != nil {
+			return errors.Wrap(err, "record sync in audit log")
+		}
+	}
+	for _, conflict := range conflicts {
+		fmt.Fprint(cmd.ErrOrStderr(), text(cmd, "sync.git.conflict", conflict))
+	}
+	fmt.Fprint(cmd.OutOrStdout(), text(cmd, "sync.git.done", rt.Config.Git.Remote))
+	return nil
+}
+
+// auditIntact reports whether audit.enabled is on and the audit log matches
+// the data.
+func auditIntact(cmd *cobra.Command) bool {
+	rt := getRuntime(cmd)
+	if !rt.Config.Audit.Enabled || rt.Audit == nil {
+		return false
+	}
+	result, err := rt.Audit.Verify(cmd.Context())
+	return err == nil && result.OK()
+}
+
+func runSyncInitCmd(cmd *cobra.Command, remoteURL string) error {
+	rt := getRuntime(cmd)
+	if rt.Git == nil {
+		return errors.New(text(cmd, "sync.init.error.backend", rt.Backend))
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "locate tock executable")
+	}
+	driver := fmt.Sprintf("%s sync merge-driver --format %s %%O %%A %%B", shellQuote(exe), rt.Backend)
+	if err = rt.Git.Init(cmd.Context(), driver, remoteURL); err != nil {
+		return errors.Wrap(err, "init git repository")
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), text(cmd, "sync.init.done", rt.Git.Dir()))
+	if !rt.Config.Git.Enabled {
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, "sync.init.enable_hint"))
+	}
+	return nil
+}
+
+// shellQuote quotes a path for the shell git runs merge drivers with.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func newSyncCalDAVCmd() *cobra.Command {
+	var opt syncCalDAVOptions
+
+	cmd := &cobra.Command{
+		Use:   "caldav",
+		Short: defaultText("sync.caldav.short"),
+		Long:  defaultText("sync.caldav.long"),
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runSyncCalDAVCmd(cmd, &opt) },
+	}
+
+	cmd.Flags().BoolVar(&opt.Today, "today", false, defaultText("sync.caldav.flag.today"))
+	cmd.Flags().BoolVar(&opt.Yesterday, "yesterday", false, defaultText("sync.caldav.flag.yesterday"))
+	cmd.Flags().StringVar(&opt.Date, "date", "", defaultText("sync.caldav.flag.date"))
+	cmd.Flags().StringVar(&opt.From, "from", "", defaultText("sync.caldav.flag.from"))
+	cmd.Flags().StringVar(&opt.To, "to", "", defaultText("sync.caldav.flag.to"))
+	addPeriodFlags(cmd, &opt.Period, &opt.Since)
+	cmd.Flags().StringVar(&opt.URL, "url", "", defaultText("sync.caldav.flag.url"))
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, defaultText("sync.caldav.flag.dry_run"))
+	return cmd
+}
+
+func runSyncCalDAVCmd(cmd *cobra.Command, opt *syncCalDAVOptions) error {
+	rt := getRuntime(cmd)
+	cfg := rt.Config.Sync.CalDAV
+	if opt.URL != "" {
+		cfg.URL = opt.URL
+	}
+	if cfg.URL == "" {
+		return errors.New(text(cmd, "sync.caldav.error.url_required"))
+	}
+	if cfg.StateFile == "" {
+		return errors.New(text(cmd, "sync.caldav.error.state_file_required"))
+	}
+
+	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{
+		Today:         opt.Today,
+		Yesterday:     opt.Yesterday,
+		Date:          opt.Date,
+		From:          opt.From,
+		To:            opt.To,
+		Period:        opt.Period,
+		Since:         opt.Since,
+		TimeFormatter: rt.TimeFormatter,
+	})
+	if err != nil {
+		return errors.Wrap(err, "build date range")
+	}
+	syncOpts := caldav.Options{Now: time.Now(), CalendarName: rt.Config.Export.ICal.CalendarName, DryRun: opt.DryRun}
+	if filter.FromDate != nil {
+		syncOpts.From = *filter.FromDate
+	}
+	if filter.ToDate != nil {
+		syncOpts.To = *filter.ToDate
+	}
+
+	client, err := caldav.NewClient(cfg.URL, cfg.Username, cfg.Password, &http.Client{Timeout: 30 * time.Second})
+	if err != nil {
+		return err
+	}
+	state, err := caldav.LoadState(cfg.StateFile, client.Collection())
+	if err != nil {
+		return err
+	}
+
+	activities, err := rt.ActivityService.List(cmd.Context(), filter)
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+
+	result, syncErr := caldav.Sync(cmd.Context(), client, state, activities, syncOpts)
+	if !opt.DryRun {
+		// Keep what was pushed before a failure, so the next sync resumes from there.
+		if err = state.Save(cfg.StateFile); err != nil && syncErr == nil {
+			return err
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+
+	key := "sync.caldav.done"
+	if opt.DryRun {
+		key = "sync.caldav.dry_run"
+	}
+	fmt.Fprint(cmd.OutOrStdout(), text(cmd, key, client.Collection(), result.Created, result.Updated, result.Deleted, result.Unchanged))
+	if result.Running > 0 {
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, "sync.caldav.running", result.Running))
+	}
+	return nil
+}
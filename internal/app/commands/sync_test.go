@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
+	"github.com/kriuchkov/tock/internal/app/audit"
+	"github.com/kriuchkov/tock/internal/app/gitsync"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/services/activity"
+)
+
+func TestRunSyncCalDAVCmdPushesActivitiesAndSavesState(t *testing.T) {
+	var puts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts = append(puts, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	service := &stubActivityResolver{
+		listFn: func(_ context.Context, filter models.ActivityFilter) ([]models.Activity, error) {
+			require.NotNil(t, filter.FromDate)
+			return []models.Activity{
+				{Project: "core", Description: "review", StartTime: start, EndTime: &end},
+				{Project: "core", Description: "coding", StartTime: end},
+			}, nil
+		},
+	}
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cfg := getRuntime(cmd).Config
+	cfg.Sync.CalDAV.URL = server.URL + "/cal"
+	cfg.Sync.CalDAV.StateFile = filepath.Join(t.TempDir(), "caldav_state.json")
+
+	err := runSyncCalDAVCmd(cmd, &syncCalDAVOptions{From: "2026-03-01"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/cal/20260314T090000Z-tock.ics"}, puts)
+	assert.Contains(t, out.String(), "Synced with "+server.URL+"/cal/: 1 created, 0 updated, 0 deleted, 0 unchanged")
+	assert.Contains(t, out.String(), "Skipped 1 running activity(ies)")
+	assert.FileExists(t, cfg.Sync.CalDAV.StateFile)
+
+	out.Reset()
+	err = runSyncCalDAVCmd(cmd, &syncCalDAVOptions{From: "2026-03-01"})
+	require.NoError(t, err)
+	assert.Len(t, puts, 1, "unchanged activities are not uploaded again")
+	assert.Contains(t, out.String(), "0 created, 0 updated, 0 deleted, 1 unchanged")
+}
+
+func TestRunSyncCalDAVCmdRequiresURL(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	err := runSyncCalDAVCmd(cmd, &syncCalDAVOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CalDAV collection URL is not set")
+}
+
+func TestRunSyncGitCmdRequiresGitIntegration(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	err := runSyncGitCmd(cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git integration is disabled")
+}
+
+func TestRunSyncInitCmdRequiresPlaintextBackend(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	getRuntime(cmd).Backend = "sqlite"
+	err := runSyncInitCmd(cmd, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs the file or todotxt backend, not sqlite")
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'/opt/my tools/tock'`, shellQuote("/opt/my tools/tock"))
+	assert.Equal(t, `'/it'\''s/tock'`, shellQuote("/it's/tock"))
+}
+
+func TestRunSyncGitCmdRecordsPulledChangesInAuditLog(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+	git := func(dir string, args ...string) {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	appendLine := func(path, line string) {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.WriteString(line + "\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	git(t.TempDir(), "init", "-q", "--bare", remote)
+	laptopDir := t.TempDir()
+	laptopFile := filepath.Join(laptopDir, ".tock.txt")
+	laptop := gitsync.NewRepo(laptopFile, filepath.Join(laptopDir, ".tock", "notes"))
+	appendLine(laptopFile, "2026-03-14 09:00 - 2026-03-14 10:00 | core | review")
+	require.NoError(t, laptop.Init(ctx, "false", remote))
+	_, err := laptop.Sync(ctx, "origin", "main")
+	require.NoError(t, err)
+
+	desktopDir := filepath.Join(t.TempDir(), "desktop")
+	git(t.TempDir(), "clone", "-q", "--branch", "main", remote, desktopDir)
+	desktopFile := filepath.Join(desktopDir, ".tock.txt")
+	desktop := gitsync.NewRepo(desktopFile, filepath.Join(desktopDir, ".tock", "notes"))
+	appendLine(desktopFile, "2026-03-14 10:30 - 2026-03-14 11:00 | ops | desktop")
+	_, err = desktop.Sync(ctx, "origin", "main")
+	require.NoError(t, err)
+
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	cmd.SetOut(&bytes.Buffer{})
+	rt := getRuntime(cmd)
+	rt.Config.Git = config.GitConfig{Enabled: true, Remote: "origin", Branch: "main"}
+	rt.Config.Audit.Enabled = true
+	rt.Git = laptop
+	rt.Audit = audit.New(filepath.Join(laptopDir, ".tock", "audit.jsonl"),
+		activity.NewService(file.NewRepository(laptopFile), nil))
+	_, err = rt.Audit.Init(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, runSyncGitCmd(cmd))
+	result, err := rt.Audit.Verify(ctx)
+	require.NoError(t, err)
+	assert.True(t, result.OK(), "the pulled activity is vouched for")
+	assert.Equal(t, audit.OpSync, result.Head.Op)
+
+	// A change made by hand is not vouched for by a later sync.
+	appendLine(laptopFile, "2026-03-14 12:00 - 2026-03-14 13:00 | core | by hand")
+	require.NoError(t, runSyncGitCmd(cmd))
+	result, err = rt.Audit.Verify(ctx)
+	require.NoError(t, err)
+	assert.True(t, result.DataChanged)
+}
@@ -18,12 +18,11 @@ func NewTagCmd() *cobra.Command {
 	var opts tagOptions
 
 	cmd := &cobra.Command{
-		Use:     "tag [DATE-INDEX] TAG [TAG...]",
-		Aliases: []string{"tags"},
-		Short:   defaultText("tag.short"),
-		Long:    defaultText("tag.long"),
-		Args:    cobra.MinimumNArgs(1),
-		RunE:    func(cmd *cobra.Command, args []string) error { return runTagCmd(cmd, args, &opts) },
+		Use:   "tag [DATE-INDEX] TAG [TAG...]",
+		Short: defaultText("tag.short"),
+		Long:  defaultText("tag.long"),
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  func(cmd *cobra.Command, args []string) error { return runTagCmd(cmd, args, &opts) },
 	}
 
 	cmd.Flags().BoolVar(&opts.JSONOutput, "json", false, defaultText("tag.flag.json"))
@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/tags"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+type tagsOptions struct {
+	JSONOutput bool
+	DryRun     bool
+}
+
+// tagChange is a planned or applied change in JSON output.
+type tagChange struct {
+	Key         string   `json:"key"`
+	Project     string   `json:"project"`
+	Description string   `json:"description"`
+	Before      []string `json:"before"`
+	After       []string `json:"after"`
+}
+
+// NewTagsCmd manages tags across all activities. It used to be an alias of
+// tag, so arguments that are not a subcommand still tag an activity.
+func NewTagsCmd() *cobra.Command {
+	var tagOpts tagOptions
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "List, rename, merge and delete tags",
+		Long:  defaultText("tags.long"),
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return runTagCmd(cmd, args, &tagOpts)
+		},
+	}
+	cmd.Flags().BoolVar(&tagOpts.JSONOutput, "json", false, defaultText("tag.flag.json"))
+
+	var listJSON bool
+	list := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   defaultText("tags.list.short"),
+		Args:    cobra.NoArgs,
+		RunE:    func(cmd *cobra.Command, _ []string) error { return runTagsListCmd(cmd, listJSON) },
+	}
+	list.Flags().BoolVar(&listJSON, "json", false, defaultText("tags.flag.json"))
+	cmd.AddCommand(list)
+
+	var renameOpts, mergeOpts, deleteOpts tagsOptions
+	cmd.AddCommand(withTagsRewriteFlags(&cobra.Command{
+		Use:               "rename OLD NEW",
+		Short:             defaultText("tags.rename.short"),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: tagArgsCompletion(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTagsRewriteCmd(cmd, args[:1], tags.Rename(args[:1], args[1]), &renameOpts,
+				text(cmd, "tags.rename.done", args[0], args[1]))
+		},
+	}, &renameOpts))
+	cmd.AddCommand(withTagsRewriteFlags(&cobra.Command{
+		Use:               "merge SOURCE... TARGET",
+		Short:             defaultText("tags.merge.short"),
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: tagArgsCompletion(-1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, target := args[:len(args)-1], args[len(args)-1]
+			return runTagsRewriteCmd(cmd, sources, tags.Rename(sources, target), &mergeOpts,
+				text(cmd, "tags.merge.done", strings.Join(sources, ", "), target))
+		},
+	}, &mergeOpts))
+	cmd.AddCommand(withTagsRewriteFlags(&cobra.Command{
+		Use:               "delete TAG...",
+		Aliases:           []string{"rm"},
+		Short:             defaultText("tags.delete.short"),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: tagArgsCompletion(-1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTagsRewriteCmd(cmd, args, tags.Delete(args), &deleteOpts,
+				text(cmd, "tags.delete.done", strings.Join(args, ", ")))
+		},
+	}, &deleteOpts))
+	return cmd
+}
+
+func withTagsRewriteFlags(cmd *cobra.Command, opts *tagsOptions) *cobra.Command {
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, defaultText("tags.flag.dry_run"))
+	cmd.Flags().BoolVar(&opts.JSONOutput, "json", false, defaultText("tags.flag.json_changes"))
+	return cmd
+}
+
+func runTagsListCmd(cmd *cobra.Command, jsonOutput bool) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	summaries := tags.Summarize(activities, rt.TagColors)
+
+	if jsonOutput {
+		return writeJSONTo(out, summaries)
+	}
+	if len(summaries) == 0 {
+		fmt.Fprint(out, text(cmd, "tags.list.empty"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, text(cmd, "tags.list.header"))
+	for _, summary := range summaries {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", summary.Name, summary.Activities, renderTagColor(summary))
+	}
+	if err = w.Flush(); err != nil {
+		return errors.Wrap(err, "write tags")
+	}
+	return nil
+}
+
+// renderTagColor shows the color of a tag as its spec, drawn in that color.
+// The color is last in the row, so its escape codes cannot break alignment.
+func renderTagColor(summary tags.Summary) string {
+	if summary.Color == "" && summary.Background == "" {
+		return ""
+	}
+	spec := summary.Color
+	style := lipgloss.NewStyle()
+	if summary.Color != "" {
+		style = style.Foreground(lipgloss.Color(summary.Color))
+	}
+	if summary.Background != "" {
+		spec = strings.TrimSpace(spec + " on " + summary.Background)
+		style = style.Background(lipgloss.Color(summary.Background))
+	}
+	return style.Render(spec)
+}
+
+// runTagsRewriteCmd applies rewrite to the tags of every activity, or only
+// shows what would change with --dry-run. Every tag in required must be in
+// use, so a typo does not silently change nothing.
+func runTagsRewriteCmd(cmd *cobra.Command, required []string, rewrite tags.Rewrite, opts *tagsOptions, done string) error {
+	rt := getRuntime(cmd)
+	out := cmd.OutOrStdout()
+
+	activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+	if err != nil {
+		return errors.Wrap(err, "list activities")
+	}
+	for _, tag := range required {
+		if !slices.ContainsFunc(activities, func(act models.Activity) bool { return slices.Contains(act.Tags, tag) }) {
+			return errors.New(text(cmd, "tags.error.not_found", tag))
+		}
+	}
+
+	changes := tags.Plan(activities, rewrite)
+	keys := models.ActivitySequenceIDs(activities)
+	if !opts.DryRun {
+		if _, err = tags.Apply(cmd.Context(), rt.ActivityService, changes); err != nil {
+			return err
+		}
+	}
+
+	if opts.JSONOutput {
+		output := make([]tagChange, len(changes))
+		for i, change := range changes {
+			output[i] = tagChange{
+				Key:         keys[change.Activity.StartTime.UnixNano()],
+				Project:     change.Activity.Project,
+				Description: change.Activity.Description,
+				Before:      change.Activity.Tags,
+				After:       change.Tags,
+			}
+		}
+		return writeJSONTo(out, output)
+	}
+
+	if opts.DryRun {
+		fmt.Fprint(out, text(cmd, "tags.dry_run.header", len(changes)))
+		for _, change := range changes {
+			act := change.Activity
+			fmt.Fprint(out, text(cmd, "tags.dry_run.line", keys[act.StartTime.UnixNano()], act.Project, act.Description,
+				joinTags(act.Tags), joinTags(change.Tags)))
+		}
+		return nil
+	}
+	fmt.Fprint(out, text(cmd, "tags.done", done, len(changes)))
+	return nil
+}
+
+func joinTags(list []string) string {
+	if len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ", ")
+}
+
+// tagArgsCompletion completes the tags in use for the first count
+// arguments, or for all of them when count is negative.
+func tagArgsCompletion(count int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if count >= 0 && len(args) >= count {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		rt, err := getRuntimeForCompletion(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		activities, err := rt.ActivityService.List(cmd.Context(), models.ActivityFilter{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		summaries := tags.Summarize(activities, nil)
+		names := make([]string, len(summaries))
+		for i, summary := range summaries {
+			names[i] = summary.Name
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func newTagsTestService() (*[]models.Activity, *stubActivityResolver) {
+	day := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.Local)
+	activities := []models.Activity{
+		{Project: "api", Description: "tests", StartTime: day, Tags: []string{"CI", "urgent"}},
+		{Project: "api", Description: "deploy", StartTime: day.Add(time.Hour), Tags: []string{"ci"}},
+		{Project: "web", Description: "css", StartTime: day.Add(2 * time.Hour)},
+	}
+	service := &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+			return append([]models.Activity(nil), activities...), nil
+		},
+		editFn: func(_ context.Context, act models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+			for i := range activities {
+				if activities[i].StartTime.Equal(act.StartTime) {
+					activities[i].Tags = req.Tags
+					return &activities[i], nil
+				}
+			}
+			return nil, assert.AnError
+		},
+	}
+	return &activities, service
+}
+
+// executeTagsCmd runs tock tags with args on a new command, as flags keep
+// their values between executions.
+func executeTagsCmd(service *stubActivityResolver, out *bytes.Buffer, args ...string) error {
+	cmd := NewTagsCmd()
+	cmd.SetContext(newTestCLICommand(service).Context())
+	cmd.SetOut(out)
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestRunTagsListCmd(t *testing.T) {
+	_, service := newTagsTestService()
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).TagColors = map[string]models.TagColor{"ci": {FG: "2"}}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runTagsListCmd(cmd, false))
+	assert.Regexp(t, `Tag\s+Activities\s+Color\n`, out.String())
+	assert.Regexp(t, `CI\s+1\s*\n`, out.String())
+	assert.Regexp(t, `ci\s+1\s+2\n`, out.String())
+
+	out.Reset()
+	require.NoError(t, runTagsListCmd(cmd, true))
+	var summaries []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summaries))
+	require.Len(t, summaries, 3)
+	assert.Equal(t, map[string]any{"name": "ci", "activities": float64(1), "color": "2"}, summaries[1])
+}
+
+func TestRunTagsRenameDryRunAndApply(t *testing.T) {
+	activities, service := newTagsTestService()
+	var out bytes.Buffer
+
+	require.NoError(t, executeTagsCmd(service, &out, "rename", "CI", "ci", "--dry-run"))
+	assert.Equal(t, "Would change 1 activities:\n  [2026-03-02-01] api: tests (CI, urgent -> ci, urgent)\n", out.String())
+	assert.Equal(t, []string{"CI", "urgent"}, (*activities)[0].Tags)
+
+	out.Reset()
+	require.NoError(t, executeTagsCmd(service, &out, "rename", "CI", "ci"))
+	assert.Equal(t, "Renamed CI to ci in 1 activities\n", out.String())
+	assert.Equal(t, []string{"ci", "urgent"}, (*activities)[0].Tags)
+
+	require.ErrorContains(t, executeTagsCmd(service, &out, "rename", "CI", "ci"), `tag "CI" not found`)
+}
+
+func TestRunTagsMergeAndDeleteJSON(t *testing.T) {
+	activities, service := newTagsTestService()
+	cmd := newTestCLICommand(service)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runTagsRewriteCmd(cmd, []string{"CI", "urgent"},
+		func(string) (string, bool) { return "ci", true }, &tagsOptions{}, "Merged"))
+	assert.Equal(t, "Merged in 1 activities\n", out.String())
+	assert.Equal(t, []string{"ci"}, (*activities)[0].Tags)
+
+	out.Reset()
+	require.NoError(t, executeTagsCmd(service, &out, "delete", "ci", "--json"))
+	var changes []tagChange
+	require.NoError(t, json.Unmarshal(out.Bytes(), &changes))
+	require.Len(t, changes, 2)
+	assert.Equal(t, tagChange{Key: "2026-03-02-02", Project: "api", Description: "deploy", Before: []string{"ci"}, After: []string{}}, changes[1])
+	assert.Empty(t, (*activities)[1].Tags)
+}
+
+func TestTagsCmdStillTagsAnActivity(t *testing.T) {
+	var gotTags []string
+	service := &stubActivityResolver{
+		getLastFn: func(context.Context) (*models.Activity, error) {
+			return &models.Activity{Project: "tock", StartTime: time.Date(2026, time.March, 14, 10, 0, 0, 0, time.Local)}, nil
+		},
+		addTagsFn: func(_ context.Context, a models.Activity, tags []string) (*models.Activity, error) {
+			gotTags = tags
+			return &a, nil
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, executeTagsCmd(service, &out, "review"))
+	assert.Equal(t, []string{"review"}, gotTags)
+	assert.Equal(t, "Tags added.\n", out.String())
+
+	// Every form of the former alias still works, flags included.
+	service.listFn = func(context.Context, models.ActivityFilter) ([]models.Activity, error) {
+		return []models.Activity{{Project: "tock", StartTime: time.Date(2026, time.March, 14, 10, 0, 0, 0, time.Local)}}, nil
+	}
+	service.getReportFn = func(_ context.Context, filter models.ActivityFilter) (*models.Report, error) {
+		acts, _ := service.listFn(context.Background(), filter)
+		return &models.Report{Activities: acts}, nil
+	}
+	out.Reset()
+	require.NoError(t, executeTagsCmd(service, &out, "2026-03-14-01", "review", "urgent", "--json"))
+	assert.Equal(t, []string{"review", "urgent"}, gotTags)
+	assert.Contains(t, out.String(), `"project": "tock"`)
+}
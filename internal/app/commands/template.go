@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/config"
+)
+
+// NewTemplateCmd returns the command for discovering report templates used by
+// `tock report --template`.
+func NewTemplateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates"},
+		Short:   "List and show report templates",
+		Long:    defaultText("template.long"),
+		Args:    cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newTemplateListCmd())
+	cmd.AddCommand(newTemplateShowCmd())
+	return cmd
+}
+
+func newTemplateListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   defaultText("template.list.short"),
+		Args:    cobra.NoArgs,
+		RunE:    func(cmd *cobra.Command, _ []string) error { return runTemplateListCmd(cmd) },
+	}
+}
+
+func newTemplateShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show NAME",
+		Short: defaultText("template.show.short"),
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return templateRegisterFlagCompletion(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error { return runTemplateShowCmd(cmd, args[0]) },
+	}
+}
+
+func runTemplateListCmd(cmd *cobra.Command) error {
+	dir := getRuntime(cmd).Config.Export.TemplatesDir
+	templates, err := exportapp.ListReportTemplates(dir)
+	if err != nil {
+		return errors.Wrap(err, "list templates")
+	}
+
+	out := cmd.OutOrStdout()
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, text(cmd, "template.table.header"))
+	for _, tmpl := range templates {
+		source := text(cmd, "template.source.builtin")
+		if tmpl.Source == exportapp.TemplateSourceUser {
+			source = tmpl.Path
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", tmpl.Name, tmpl.Description, source)
+	}
+	if err = w.Flush(); err != nil {
+		return errors.Wrap(err, "flush template table")
+	}
+
+	if dir != "" {
+		fmt.Fprintf(out, "\n"+text(cmd, "template.dir_hint")+"\n", dir)
+	}
+	return nil
+}
+
+func runTemplateShowCmd(cmd *cobra.Command, name string) error {
+	source, err := exportapp.ReportTemplateSource(getRuntime(cmd).Config.Export.TemplatesDir, name)
+	if err != nil {
+		return errors.Wrap(err, "show template")
+	}
+
+	out := cmd.OutOrStdout()
+	if _, err = fmt.Fprint(out, source); err != nil {
+		return errors.Wrap(err, "write template")
+	}
+	if !strings.HasSuffix(source, "\n") {
+		fmt.Fprintln(out)
+	}
+	return nil
+}
+
+func templateRegisterFlagCompletion(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	var opts []config.Option
+	if configPath, _ := cmd.Root().PersistentFlags().GetString("config"); configPath != "" {
+		opts = append(opts, config.WithConfigFile(configPath))
+	}
+	cfg, _, err := config.Load(opts...)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	templates, err := exportapp.ListReportTemplates(cfg.Export.TemplatesDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name+"\t"+tmpl.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTemplateListShowsBuiltinAndUserTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.tmpl"), []byte("{{/* Invoice summary. */}}{{ .Report.TotalDuration }}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.md.tmpl"), []byte("export template"), 0600))
+
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	getRuntime(cmd).Config.Export.TemplatesDir = dir
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runTemplateListCmd(cmd))
+	output := out.String()
+	assert.Contains(t, output, "NAME")
+	assert.Regexp(t, `weekly-status\s+Weekly status update.*built-in`, output)
+	assert.Regexp(t, `client\s+Invoice summary\.\s+`+regexp.QuoteMeta(filepath.Join(dir, "client.tmpl")), output)
+	assert.NotContains(t, output, "report.md")
+	assert.Contains(t, output, "User templates: "+dir+"/NAME.tmpl")
+}
+
+func TestRunTemplateShowPrefersUserTemplate(t *testing.T) {
+	dir := t.TempDir()
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	getRuntime(cmd).Config.Export.TemplatesDir = dir
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runTemplateShowCmd(cmd, "standup"))
+	assert.Contains(t, out.String(), "{{ range .Days -}}")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "standup.tmpl"), []byte("custom"), 0600))
+	out.Reset()
+	require.NoError(t, runTemplateShowCmd(cmd, "standup"))
+	assert.Equal(t, "custom\n", out.String())
+
+	require.ErrorContains(t, runTemplateShowCmd(cmd, "../standup"), "template not found")
+}
@@ -27,6 +27,9 @@ type stubActivityResolver struct {
 	getLastFn   func(context.Context) (*models.Activity, error)
 	addNoteFn   func(context.Context, models.Activity, string) (*models.Activity, error)
 	addTagsFn   func(context.Context, models.Activity, []string) (*models.Activity, error)
+	editFn      func(context.Context, models.Activity, models.EditActivityRequest) (*models.Activity, error)
+	editAllFn   func(context.Context, []models.ActivityEdit) ([]models.Activity, error)
+	applyFn     func(context.Context, models.ChangeSet) error
 	removeFn    func(context.Context, models.Activity) error
 }
 
@@ -101,6 +104,54 @@ func (s stubActivityResolver) AddTags(
 	return s.addTagsFn(ctx, activity, tags)
 }
 
+func (s stubActivityResolver) Edit(
+	ctx context.Context,
+	activity models.Activity,
+	req models.EditActivityRequest,
+) (*models.Activity, error) {
+	if s.editFn == nil {
+		return nil, stubMethodNotConfigured()
+	}
+	return s.editFn(ctx, activity, req)
+}
+
+// EditAll falls back to editFn for every edit when editAllFn is not set.
+func (s stubActivityResolver) EditAll(ctx context.Context, edits []models.ActivityEdit) ([]models.Activity, error) {
+	if s.editAllFn != nil {
+		return s.editAllFn(ctx, edits)
+	}
+	updated := make([]models.Activity, 0, len(edits))
+	for _, e := range edits {
+		act, err := s.Edit(ctx, e.Activity, e.Request)
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, *act)
+	}
+	return updated, nil
+}
+
+// ApplyChanges falls back to removeFn, editFn and addFn when applyFn is not set.
+func (s stubActivityResolver) ApplyChanges(ctx context.Context, set models.ChangeSet) error {
+	if s.applyFn != nil {
+		return s.applyFn(ctx, set)
+	}
+	for _, act := range set.Remove {
+		if err := s.Remove(ctx, act); err != nil {
+			return err
+		}
+	}
+	if _, err := s.EditAll(ctx, set.Edit); err != nil {
+		return err
+	}
+	for _, req := range set.Add {
+		if _, err := s.Add(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s stubActivityResolver) Remove(ctx context.Context, activity models.Activity) error {
 	if s.removeFn == nil {
 		return nil
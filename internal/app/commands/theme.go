@@ -5,6 +5,7 @@ import (
 	"github.com/muesli/termenv"
 
 	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
 )
 
 // TagColorStyle holds the lipgloss foreground and optional background colors for a tag.
@@ -22,6 +23,7 @@ type Theme struct {
 	Faint     lipgloss.Color
 	Highlight lipgloss.Color
 	Tag       lipgloss.Color
+	Heat      [4]lipgloss.Color // heatmap intensity levels, lowest first
 	TagColors map[string]TagColorStyle
 }
 
@@ -53,6 +55,7 @@ func DarkTheme() Theme {
 		Faint:     lipgloss.Color("240"), // Dark Grey
 		Highlight: lipgloss.Color("214"), // Orange/Gold
 		Tag:       lipgloss.Color("120"), // Light Green
+		Heat:      [4]lipgloss.Color{"22", "28", "34", "46"},
 	}
 }
 
@@ -66,6 +69,7 @@ func LightTheme() Theme {
 		Faint:     lipgloss.Color("250"), // Light Grey
 		Highlight: lipgloss.Color("166"), // Orange (Darker than 214)
 		Tag:       lipgloss.Color("28"),  // Dark Green
+		Heat:      [4]lipgloss.Color{"151", "114", "71", "28"},
 	}
 }
 
@@ -79,6 +83,7 @@ func ANSIDarkTheme() Theme {
 		Faint:     lipgloss.Color("8"),  // Dark Grey
 		Highlight: lipgloss.Color("3"),  // Yellow
 		Tag:       lipgloss.Color("2"),  // Green
+		Heat:      [4]lipgloss.Color{"2", "2", "10", "10"},
 	}
 }
 
@@ -92,6 +97,7 @@ func ANSILightTheme() Theme {
 		Faint:     lipgloss.Color("7"), // Light Grey
 		Highlight: lipgloss.Color("5"), // Magenta (Yellow is often invisible on white)
 		Tag:       lipgloss.Color("2"), // Green
+		Heat:      [4]lipgloss.Color{"2", "2", "2", "2"},
 	}
 }
 
@@ -175,6 +181,25 @@ func GetTheme(cfg config.ThemeConfig) Theme {
 	return LightTheme()
 }
 
+// withTagColors returns theme with the resolved per-tag colors applied on top of
+// any colors the theme already defines.
+func withTagColors(theme Theme, tagColors map[string]models.TagColor) Theme {
+	for tag, tc := range tagColors {
+		if theme.TagColors == nil {
+			theme.TagColors = make(map[string]TagColorStyle, len(tagColors))
+		}
+		ts := TagColorStyle{}
+		if tc.FG != "" {
+			ts.FG = lipgloss.Color(tc.FG)
+		}
+		if tc.BG != "" {
+			ts.BG = lipgloss.Color(tc.BG)
+		}
+		theme.TagColors[tag] = ts
+	}
+	return theme
+}
+
 // InitStyles creates the styles based on the provided theme.
 func InitStyles(t Theme) Styles {
 	s := Styles{}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/journal"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+func NewUndoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo [N]",
+		Short: "Undo the last change",
+		Long:  defaultText("undo.long"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJournalCmd(cmd, args, (*journal.Journal).Undo, "undo.done")
+		},
+	}
+}
+
+func NewRedoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo [N]",
+		Short: "Redo the last undone change",
+		Long:  defaultText("redo.long"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJournalCmd(cmd, args, (*journal.Journal).Redo, "redo.done")
+		},
+	}
+}
+
+type journalStep func(*journal.Journal, context.Context, ports.ActivityResolver) (journal.Entry, error)
+
+// runJournalCmd undoes or redoes N entries, one at a time, and stops at the
+// first that cannot be reverted.
+func runJournalCmd(cmd *cobra.Command, args []string, step journalStep, doneKey string) error {
+	rt := getRuntime(cmd)
+	if rt.Journal == nil {
+		return errors.New(defaultText("journal.error.disabled"))
+	}
+
+	count := 1
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return errors.Errorf("invalid count %q: expected a positive number", args[0])
+		}
+		count = n
+	}
+
+	for range count {
+		entry, err := step(rt.Journal, cmd.Context(), rt.ActivityService)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), text(cmd, doneKey, entry.Seq, journalEntrySummary(entry)))
+	}
+	return nil
+}
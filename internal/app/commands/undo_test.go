@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/journal"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const undoTestJournal = `{"seq":1,"time":"2026-03-02T09:00:00Z","op":"add","changes":[{"op":"add","after":{"description":"tests","project":"api","start_time":"2026-03-02T09:00:00Z","end_time":"2026-03-02T10:00:00Z"}}]}
+{"seq":2,"time":"2026-03-02T10:00:00Z","op":"remove","changes":[{"op":"remove","before":{"description":"review","project":"api","start_time":"2026-03-02T10:00:00Z","end_time":"2026-03-02T11:00:00Z","notes":"LGTM"}}]}
+`
+
+func newUndoTestCommand(t *testing.T, service *stubActivityResolver) (*bytes.Buffer, func(args ...string) error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(undoTestJournal), 0600))
+
+	cmd := newTestCLICommand(service)
+	getRuntime(cmd).Journal = journal.New(path)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return &out, func(args ...string) error {
+		return runJournalCmd(cmd, args, (*journal.Journal).Undo, "undo.done")
+	}
+}
+
+func TestRunUndoCmdRestoresRemovedActivity(t *testing.T) {
+	var restored []models.EditActivityRequest
+	out, undo := newUndoTestCommand(t, &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return nil, nil },
+		editFn: func(_ context.Context, _ models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+			restored = append(restored, req)
+			return &models.Activity{}, nil
+		},
+	})
+
+	require.NoError(t, undo())
+
+	require.Len(t, restored, 1)
+	assert.Equal(t, "review", restored[0].Description)
+	assert.Equal(t, "LGTM", restored[0].Notes)
+	assert.True(t, restored[0].EndTime.Equal(time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Undid #2 remove api: review\n", out.String())
+}
+
+func TestRunUndoCmdUndoesSeveralChanges(t *testing.T) {
+	var removed []models.Activity
+	current := []models.Activity{{
+		Project:     "api",
+		Description: "tests",
+		StartTime:   time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:     new(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)),
+	}}
+	out, undo := newUndoTestCommand(t, &stubActivityResolver{
+		listFn: func(context.Context, models.ActivityFilter) ([]models.Activity, error) { return current, nil },
+		editFn: func(context.Context, models.Activity, models.EditActivityRequest) (*models.Activity, error) {
+			return &models.Activity{}, nil
+		},
+		removeFn: func(_ context.Context, act models.Activity) error {
+			removed = append(removed, act)
+			return nil
+		},
+	})
+
+	require.NoError(t, undo("2"))
+
+	require.Len(t, removed, 1)
+	assert.Equal(t, "tests", removed[0].Description)
+	assert.Equal(t, "Undid #2 remove api: review\nUndid #1 add api: tests\n", out.String())
+
+	err := undo()
+	require.ErrorIs(t, err, journal.ErrNothingToUndo)
+}
+
+func TestRunUndoCmdRejectsInvalidCount(t *testing.T) {
+	_, undo := newUndoTestCommand(t, &stubActivityResolver{})
+
+	err := undo("zero")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid count")
+}
+
+func TestRunUndoCmdRequiresJournal(t *testing.T) {
+	cmd := newTestCLICommand(&stubActivityResolver{})
+
+	err := runJournalCmd(cmd, nil, (*journal.Journal).Undo, "undo.done")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "journal is disabled")
+}
@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-faster/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+const (
+	weekSlotsPerHour  = 2
+	weekLabelWidth    = 6
+	weekChromeHeight  = 9
+	weekMinColumn     = 5
+	weekDefaultWidth  = 100
+	weekDefaultHeight = 30
+)
+
+// weekFallbackPalette colors projects that have no configured tag color.
+var weekFallbackPalette = []lipgloss.Color{"63", "168", "35", "214", "81", "141", "203", "108", "179", "75"}
+
+var runWeekProgram = func(model weekModel) error {
+	program := tea.NewProgram(&model, tea.WithAltScreen())
+	_, err := program.Run()
+	if err != nil {
+		return errors.Wrap(err, "run program")
+	}
+	return nil
+}
+
+type weekOptions struct {
+	Date string
+}
+
+func NewWeekCmd() *cobra.Command {
+	var opts weekOptions
+
+	cmd := &cobra.Command{
+		Use:   "week",
+		Short: "Show a weekly timeline with an hour grid",
+		Long:  defaultText("week.long"),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runWeekCmd(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Date, "date", "", defaultText("week.flag.date"))
+	return cmd
+}
+
+func runWeekCmd(cmd *cobra.Command, opts *weekOptions) error {
+	rt := getRuntime(cmd)
+
+	date := time.Now()
+	if opts.Date != "" {
+		var err error
+		date, err = rt.TimeFormatter.ParseDate(opts.Date)
+		if err != nil {
+			return errors.Wrap(err, "parse date")
+		}
+	}
+
+	return runWeekProgram(initialWeekModel(
+		rt.ActivityService, rt.Config, getLocalizer(cmd), rt.TagColors, date, rt.TimeFormatter.Calendar().WeekStart,
+	))
+}
+
+type weekDataMsg struct {
+	grid insights.WeekGrid
+}
+
+type weekModel struct {
+	service     ports.ActivityResolver
+	loc         *localization.Localizer
+	theme       Theme
+	weekStart   time.Weekday
+	startOfWeek time.Time
+	grid        insights.WeekGrid
+	loaded      bool
+	offset      int // first visible slot of the hour range
+	width       int
+	height      int
+	err         error
+}
+
+func initialWeekModel(
+	service ports.ActivityResolver,
+	cfg *config.Config,
+	loc *localization.Localizer,
+	tagColors map[string]models.TagColor,
+	date time.Time,
+	weekStart time.Weekday,
+) weekModel {
+	return weekModel{
+		service:     service,
+		loc:         loc,
+		theme:       withTagColors(GetTheme(cfg.Theme), tagColors),
+		weekStart:   weekStart,
+		startOfWeek: insights.StartOfWeek(date, weekStart),
+		width:       weekDefaultWidth,
+		height:      weekDefaultHeight,
+	}
+}
+
+func (m *weekModel) Init() tea.Cmd {
+	return m.fetchWeekData
+}
+
+func (m *weekModel) fetchWeekData() tea.Msg {
+	from := m.startOfWeek
+	to := from.AddDate(0, 0, 7)
+
+	report, err := m.service.GetReport(context.Background(), models.ActivityFilter{FromDate: &from, ToDate: &to})
+	if err != nil {
+		return errMsg{errors.Wrap(err, "get report")}
+	}
+	return weekDataMsg{grid: insights.BuildWeekGrid(report.Activities, from, time.Now())}
+}
+
+func (m *weekModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case weekDataMsg:
+		m.grid = msg.grid
+		m.loaded = true
+		m.offset = 0
+	case errMsg:
+		m.err = msg.err
+	case tea.KeyMsg:
+		return m, m.handleKeyMsg(msg)
+	}
+	return m, nil
+}
+
+func (m *weekModel) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return tea.Quit
+	case "left", "h":
+		m.startOfWeek = m.startOfWeek.AddDate(0, 0, -7)
+		return m.fetchWeekData
+	case "right", "l":
+		m.startOfWeek = m.startOfWeek.AddDate(0, 0, 7)
+		return m.fetchWeekData
+	case "t":
+		m.startOfWeek = insights.StartOfWeek(time.Now(), m.weekStart)
+		return m.fetchWeekData
+	case "down", "j":
+		m.offset++
+	case "up", "k":
+		m.offset = max(m.offset-1, 0)
+	}
+	return nil
+}
+
+func (m *weekModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v", m.err)
+	}
+	if !m.loaded {
+		return m.loc.Format("calendar.initializing")
+	}
+
+	colWidth := max((m.width-weekLabelWidth-4)/7-1, weekMinColumn)
+	firstHour, lastHour := m.grid.HourRange()
+	slots := (lastHour - firstHour) * weekSlotsPerHour
+	visible := max(m.height-weekChromeHeight, 4)
+	m.offset = min(m.offset, max(slots-visible, 0))
+
+	var b strings.Builder
+	b.WriteString(m.renderHeader() + "\n\n")
+	b.WriteString(m.renderDayHeaders(colWidth) + "\n")
+
+	faint := lipgloss.NewStyle().Foreground(m.theme.Faint)
+	for slot := m.offset; slot < min(m.offset+visible, slots); slot++ {
+		minutes := firstHour*60 + slot*60/weekSlotsPerHour
+		label := ""
+		if minutes%60 == 0 {
+			label = fmt.Sprintf("%02d:00", minutes/60)
+		}
+		b.WriteString(faint.Width(weekLabelWidth).Render(label))
+		for day := range 7 {
+			slotStart := m.startOfWeek.AddDate(0, 0, day).Add(time.Duration(minutes) * time.Minute)
+			b.WriteString(" " + m.renderCell(day, slotStart, colWidth, minutes%60 == 0))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderTotals(colWidth) + "\n\n")
+	b.WriteString(m.renderLegend() + "\n")
+	b.WriteString(faint.Render(m.loc.Text("week.help")))
+	return lipgloss.NewStyle().Margin(0, 1).Render(b.String())
+}
+
+func (m *weekModel) renderHeader() string {
+	end := m.startOfWeek.AddDate(0, 0, 6)
+	title := m.loc.Format("week.title",
+		fmt.Sprintf("%02d %s", m.startOfWeek.Day(), localizedMonthShortName(m.loc, m.startOfWeek.Month())),
+		fmt.Sprintf("%02d %s %d", end.Day(), localizedMonthShortName(m.loc, end.Month()), end.Year()),
+	)
+	total := m.loc.Format("week.total", formatDurationCompact(m.grid.Total))
+	return lipgloss.NewStyle().Bold(true).Foreground(m.theme.Text).Render(title) + "  " +
+		lipgloss.NewStyle().Foreground(m.theme.SubText).Render(total)
+}
+
+func (m *weekModel) renderDayHeaders(colWidth int) string {
+	names := localizedWeekdayShortNames(m.loc, m.weekStart)
+	today := time.Now()
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", weekLabelWidth))
+	for day := range 7 {
+		date := m.startOfWeek.AddDate(0, 0, day)
+		style := lipgloss.NewStyle().Width(colWidth).Align(lipgloss.Center).Foreground(m.theme.SubText)
+		if insights.DateKey(date) == insights.DateKey(today) {
+			style = style.Foreground(m.theme.Highlight).Bold(true)
+		}
+		b.WriteString(" " + style.Render(fmt.Sprintf("%s %02d", names[day], date.Day())))
+	}
+	return b.String()
+}
+
+// renderCell draws one slot of a day. The segment covering most of the slot
+// fills it; its first slot carries the project name and the second the
+// description. Empty slots stay blank so gaps between activities show.
+func (m *weekModel) renderCell(day int, slotStart time.Time, width int, onHour bool) string {
+	slotEnd := slotStart.Add(time.Hour / weekSlotsPerHour)
+	segment, ok := dominantSegment(m.grid.Days[day], slotStart, slotEnd)
+	if !ok {
+		if onHour {
+			return lipgloss.NewStyle().Foreground(m.theme.Faint).Width(width).Render("·")
+		}
+		return strings.Repeat(" ", width)
+	}
+
+	label := ""
+	switch m.blockRow(day, slotStart, segment) {
+	case 0:
+		label = segment.Project
+	case 1:
+		label = segment.Description
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxWidth(width).
+		Background(m.activityColor(segment)).
+		Foreground(m.theme.Text).
+		Render(truncateRunes(label, width))
+}
+
+// blockRow returns how many slots above slotStart the same segment already
+// fills, counting only visible slots and stopping at two.
+func (m *weekModel) blockRow(day int, slotStart time.Time, segment models.Activity) int {
+	firstHour, _ := m.grid.HourRange()
+	slot := time.Hour / weekSlotsPerHour
+	top := m.startOfWeek.AddDate(0, 0, day).Add(time.Duration(firstHour)*time.Hour + time.Duration(m.offset)*slot)
+
+	row := 0
+	for prev := slotStart.Add(-slot); row < 2 && !prev.Before(top); prev = prev.Add(-slot) {
+		above, ok := dominantSegment(m.grid.Days[day], prev, prev.Add(slot))
+		if !ok || !above.StartTime.Equal(segment.StartTime) {
+			break
+		}
+		row++
+	}
+	return row
+}
+
+func (m *weekModel) renderTotals(colWidth int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Width(weekLabelWidth).Foreground(m.theme.SubText).Render(m.loc.Text("week.totals")))
+	for day := range 7 {
+		total := ""
+		if m.grid.Totals[day] > 0 {
+			total = formatDurationCompact(m.grid.Totals[day])
+		}
+		b.WriteString(" " + lipgloss.NewStyle().Width(colWidth).Align(lipgloss.Center).Foreground(m.theme.Primary).Render(total))
+	}
+	return b.String()
+}
+
+func (m *weekModel) renderLegend() string {
+	seen := make(map[string]bool)
+	var parts []string
+	for _, day := range m.grid.Days {
+		for _, segment := range day {
+			key := m.colorKey(segment)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			swatch := lipgloss.NewStyle().Foreground(m.activityColor(segment)).Render("■")
+			parts = append(parts, swatch+" "+key)
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// activityColor prefers the first tag with a configured color, then a color
+// configured for the project name, then a stable color from the fallback palette.
+func (m *weekModel) activityColor(act models.Activity) lipgloss.Color {
+	for _, name := range append(append([]string(nil), act.Tags...), act.Project) {
+		if ts, ok := m.theme.TagColors[name]; ok {
+			if ts.BG != "" {
+				return ts.BG
+			}
+			if ts.FG != "" {
+				return ts.FG
+			}
+		}
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(act.Project))
+	return weekFallbackPalette[h.Sum32()%uint32(len(weekFallbackPalette))]
+}
+
+// colorKey names the legend entry an activity's color comes from.
+func (m *weekModel) colorKey(act models.Activity) string {
+	for _, tag := range act.Tags {
+		if _, ok := m.theme.TagColors[tag]; ok {
+			return "#" + tag
+		}
+	}
+	return act.Project
+}
+
+// dominantSegment returns the segment with the largest overlap with [start, end).
+func dominantSegment(segments []models.Activity, start, end time.Time) (models.Activity, bool) {
+	var (
+		best    models.Activity
+		overlap time.Duration
+	)
+	for _, segment := range segments {
+		segEnd := segment.StartTime.Add(segment.Duration())
+		from := later(segment.StartTime, start)
+		to := earlier(segEnd, end)
+		if d := to.Sub(from); d > overlap {
+			best, overlap = segment, d
+		}
+	}
+	return best, overlap > 0
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func truncateRunes(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
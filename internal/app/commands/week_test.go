@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/config"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func TestRunWeekCmdUsesDateFlag(t *testing.T) {
+	runner := runWeekProgram
+	t.Cleanup(func() { runWeekProgram = runner })
+
+	called := false
+	runWeekProgram = func(model weekModel) error {
+		called = true
+		assert.NotNil(t, model.service)
+		assert.Equal(t, time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local), model.startOfWeek)
+		return nil
+	}
+
+	cmd := newTestCLICommand(&stubActivityResolver{})
+	require.NoError(t, runWeekCmd(cmd, &weekOptions{Date: "2026-04-09"}))
+	assert.True(t, called)
+
+	require.Error(t, runWeekCmd(cmd, &weekOptions{Date: "09.04.2026"}))
+}
+
+func newTestWeekModel(t *testing.T, activities []models.Activity, tagColors map[string]models.TagColor) weekModel {
+	t.Helper()
+	var gotFilter models.ActivityFilter
+	service := &stubActivityResolver{
+		getReportFn: func(_ context.Context, filter models.ActivityFilter) (*models.Report, error) {
+			gotFilter = filter
+			return &models.Report{Activities: activities}, nil
+		},
+	}
+
+	model := initialWeekModel(service, &config.Config{}, localization.MustNew(localization.LanguageEnglish),
+		tagColors, time.Date(2026, time.April, 8, 0, 0, 0, 0, time.Local), time.Monday)
+	model.Update(model.fetchWeekData())
+
+	require.NotNil(t, gotFilter.FromDate)
+	require.NotNil(t, gotFilter.ToDate)
+	assert.Equal(t, model.startOfWeek, *gotFilter.FromDate)
+	assert.Equal(t, model.startOfWeek.AddDate(0, 0, 7), *gotFilter.ToDate)
+	return model
+}
+
+func TestWeekModelViewDrawsBlocksAndGaps(t *testing.T) {
+	monday := time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local)
+	end := monday.Add(11 * time.Hour)
+	model := newTestWeekModel(t, []models.Activity{{
+		Project:     "tock",
+		Description: "week view",
+		StartTime:   monday.Add(9 * time.Hour),
+		EndTime:     &end,
+	}}, nil)
+	model.width, model.height = 120, 40
+
+	view := model.View()
+	assert.Contains(t, view, "Week 06 Apr – 12 Apr 2026")
+	assert.Contains(t, view, "Total: 2h")
+	assert.Contains(t, view, "09:00")
+	assert.Contains(t, view, "tock")
+	assert.Contains(t, view, "week view")
+	assert.Contains(t, view, "·", "empty hours are marked")
+}
+
+func TestWeekModelActivityColorPrefersTagColors(t *testing.T) {
+	model := newTestWeekModel(t, nil, map[string]models.TagColor{
+		"deep":  {FG: "15", BG: "22"},
+		"infra": {FG: "33"},
+	})
+
+	assert.Equal(t, lipgloss.Color("22"), model.activityColor(models.Activity{Project: "x", Tags: []string{"deep"}}))
+	assert.Equal(t, lipgloss.Color("33"), model.activityColor(models.Activity{Project: "infra"}))
+	assert.Equal(t, "#deep", model.colorKey(models.Activity{Project: "x", Tags: []string{"deep"}}))
+
+	fallback := model.activityColor(models.Activity{Project: "tock"})
+	assert.Contains(t, weekFallbackPalette, fallback)
+	assert.Equal(t, fallback, model.activityColor(models.Activity{Project: "tock"}), "fallback is stable")
+}
+
+func TestWeekModelNavigatesWeeks(t *testing.T) {
+	model := newTestWeekModel(t, nil, nil)
+	start := model.startOfWeek
+
+	cmd := model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, start.AddDate(0, 0, 7), model.startOfWeek)
+
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyLeft})
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, start.AddDate(0, 0, -7), model.startOfWeek)
+
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Equal(t, time.Monday, model.startOfWeek.Weekday())
+	assert.True(t, !time.Now().Before(model.startOfWeek) && time.Now().Before(model.startOfWeek.AddDate(0, 0, 7)))
+
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	assert.Equal(t, 0, model.offset)
+	model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	assert.Equal(t, 1, model.offset)
+}
+
+func TestDominantSegmentPicksLargestOverlap(t *testing.T) {
+	base := time.Date(2026, time.April, 6, 9, 0, 0, 0, time.Local)
+	end1 := base.Add(10 * time.Minute)
+	end2 := base.Add(time.Hour)
+	segments := []models.Activity{
+		{Project: "a", StartTime: base, EndTime: &end1},
+		{Project: "b", StartTime: end1, EndTime: &end2},
+	}
+
+	got, ok := dominantSegment(segments, base, base.Add(30*time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, "b", got.Project)
+
+	_, ok = dominantSegment(segments, base.Add(2*time.Hour), base.Add(150*time.Minute))
+	assert.False(t, ok)
+}
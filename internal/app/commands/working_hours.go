@@ -61,7 +61,9 @@ func reconcileWorkingHours(ctx context.Context, now time.Time) (context.Context,
 
 	stopped, err := rt.ActivityService.Stop(ctx, models.StopActivityRequest{EndTime: stopTime})
 	if err != nil {
-		if errors.Is(err, coreErrors.ErrNoActiveActivity) {
+		// A stop time in a locked period is left for the user to resolve
+		// with an explicit stop, rather than failing every command.
+		if errors.Is(err, coreErrors.ErrNoActiveActivity) || errors.Is(err, coreErrors.ErrPeriodLocked) {
 			return ctx, nil
 		}
 		return ctx, errors.Wrap(err, "auto-stop activity")
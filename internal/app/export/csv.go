@@ -0,0 +1,195 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// CSV column names accepted by CSVOptions.Columns. start_time, end_time and
+// duration_minutes are the original columns and remain the default.
+const (
+	CSVColumnID              = "id"
+	CSVColumnDate            = "date"
+	CSVColumnWeekday         = "weekday"
+	CSVColumnProject         = "project"
+	CSVColumnDescription     = "description"
+	CSVColumnStart           = "start"
+	CSVColumnEnd             = "end"
+	CSVColumnStartTime       = "start_time"
+	CSVColumnEndTime         = "end_time"
+	CSVColumnDurationMinutes = "duration_minutes"
+	CSVColumnDurationHours   = "duration_hours"
+	CSVColumnTags            = "tags"
+	CSVColumnNotes           = "notes"
+)
+
+// CSVColumns lists every supported column.
+var CSVColumns = []string{
+	CSVColumnID, CSVColumnDate, CSVColumnWeekday, CSVColumnProject, CSVColumnDescription,
+	CSVColumnStart, CSVColumnEnd, CSVColumnStartTime, CSVColumnEndTime,
+	CSVColumnDurationMinutes, CSVColumnDurationHours, CSVColumnTags, CSVColumnNotes,
+}
+
+// DefaultCSVColumns is the column set used when none is configured.
+var DefaultCSVColumns = []string{
+	CSVColumnProject, CSVColumnDescription, CSVColumnStartTime, CSVColumnEndTime, CSVColumnDurationMinutes,
+}
+
+// CSVOptions controls the csv format. The zero value writes the default
+// columns, comma separated, with a header row.
+type CSVOptions struct {
+	Columns   []string
+	Delimiter rune           // defaults to ','
+	Decimal   rune           // decimal separator for durations, defaults to '.'
+	Location  *time.Location // nil keeps the offset each timestamp was stored with
+	NoHeader  bool
+}
+
+// WithCSVOptions configures the csv format.
+func WithCSVOptions(csvOptions CSVOptions) Option {
+	return func(o *renderOptions) { o.csv = csvOptions }
+}
+
+// ParseCSVColumns splits a comma-separated column list and rejects unknown names.
+func ParseCSVColumns(value string) ([]string, error) {
+	var columns []string
+	for field := range strings.SplitSeq(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(CSVColumns, name) {
+			return nil, errors.Errorf("unknown csv column %q (use %s)", name, strings.Join(CSVColumns, ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("no csv columns given")
+	}
+	return columns, nil
+}
+
+// ParseCSVDelimiter accepts a single character, or "tab" and `\t` for a tab.
+func ParseCSVDelimiter(value string) (rune, error) {
+	switch value {
+	case "tab", `\t`, "\t":
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, errors.Errorf("invalid csv delimiter %q", value)
+	}
+	return r, nil
+}
+
+// ParseCSVDecimal accepts "." or "," as the decimal separator.
+func ParseCSVDecimal(value string) (rune, error) {
+	switch value {
+	case ".", "":
+		return '.', nil
+	case ",":
+		return ',', nil
+	default:
+		return 0, errors.Errorf("invalid decimal separator %q (use . or ,)", value)
+	}
+}
+
+// RenderCSVReport writes one row per activity, sorted by start time.
+func RenderCSVReport(activities []models.Activity, opts ...Option) ([]byte, error) {
+	o := newRenderOptions(opts).csv
+	columns := o.Columns
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+
+	sortedActivities := models.SortActivitiesByStart(activities)
+	ids := models.ActivitySequenceIDs(sortedActivities)
+
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if o.Delimiter != 0 {
+		w.Comma = o.Delimiter
+	}
+
+	if !o.NoHeader {
+		if err := w.Write(columns); err != nil {
+			return nil, errors.Wrap(err, "write csv header")
+		}
+	}
+
+	for _, act := range sortedActivities {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = csvValue(column, act, ids, o)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, errors.Wrap(err, "write csv row")
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.Wrap(err, "flush csv")
+	}
+
+	return b.Bytes(), nil
+}
+
+func csvValue(column string, act models.Activity, ids map[int64]string, o CSVOptions) string {
+	start := csvTime(act.StartTime, o.Location)
+	switch column {
+	case CSVColumnID:
+		// IDs ignore Location so they match the IDs other commands accept.
+		return ids[act.StartTime.UnixNano()]
+	case CSVColumnDate:
+		return start.Format(time.DateOnly)
+	case CSVColumnWeekday:
+		return start.Weekday().String()
+	case CSVColumnProject:
+		return act.Project
+	case CSVColumnDescription:
+		return act.Description
+	case CSVColumnStart, CSVColumnStartTime:
+		return start.Format(time.RFC3339)
+	case CSVColumnEnd, CSVColumnEndTime:
+		if act.EndTime == nil {
+			return ""
+		}
+		return csvTime(*act.EndTime, o.Location).Format(time.RFC3339)
+	case CSVColumnDurationMinutes:
+		return csvDecimal(math.Floor(act.Duration().Minutes()*100)/100, o.Decimal)
+	case CSVColumnDurationHours:
+		return csvDecimal(act.Duration().Round(time.Minute).Hours(), o.Decimal)
+	case CSVColumnTags:
+		return strings.Join(act.Tags, " ")
+	case CSVColumnNotes:
+		return act.Notes
+	default:
+		return ""
+	}
+}
+
+func csvTime(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+func csvDecimal(value float64, separator rune) string {
+	formatted := fmt.Sprintf("%.2f", value)
+	if separator == ',' {
+		return strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
@@ -0,0 +1,73 @@
+package export_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func csvActivities() []models.Activity {
+	start := time.Date(2026, time.March, 13, 23, 30, 0, 0, time.UTC)
+	end := start.Add(45 * time.Minute)
+	return []models.Activity{
+		{Project: "tock", Description: "csv, columns", StartTime: start, EndTime: &end, Tags: []string{"dev", "export"}, Notes: "line one\nline two"},
+	}
+}
+
+func TestRenderCSVReportDefaultColumns(t *testing.T) {
+	content, err := exportapp.RenderCSVReport(csvActivities())
+	require.NoError(t, err)
+	assert.Equal(t,
+		"project,description,start_time,end_time,duration_minutes\n"+
+			"tock,\"csv, columns\",2026-03-13T23:30:00Z,2026-03-14T00:15:00Z,45.00\n",
+		string(content))
+}
+
+func TestRenderCSVReportOptions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	content, err := exportapp.RenderCSVReport(csvActivities(), exportapp.WithCSVOptions(exportapp.CSVOptions{
+		Columns:   []string{"id", "date", "weekday", "start", "end", "duration_hours", "tags", "notes"},
+		Delimiter: '\t',
+		Decimal:   ',',
+		Location:  berlin,
+		NoHeader:  true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"2026-03-13-01\t2026-03-14\tSaturday\t2026-03-14T00:30:00+01:00\t2026-03-14T01:15:00+01:00\t0,75\tdev export\t\"line one\nline two\"\n",
+		string(content))
+}
+
+func TestParseCSVSettings(t *testing.T) {
+	columns, err := exportapp.ParseCSVColumns(" Project, duration_hours ,,tags")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"project", "duration_hours", "tags"}, columns)
+
+	_, err = exportapp.ParseCSVColumns("project,hours")
+	require.ErrorContains(t, err, `unknown csv column "hours"`)
+	_, err = exportapp.ParseCSVColumns(" , ")
+	require.Error(t, err)
+
+	for value, want := range map[string]rune{";": ';', "tab": '\t', `\t`: '\t', "|": '|'} {
+		got, parseErr := exportapp.ParseCSVDelimiter(value)
+		require.NoError(t, parseErr, value)
+		assert.Equal(t, want, got, value)
+	}
+	for _, value := range []string{"", ";;", `"`, "\n"} {
+		_, err = exportapp.ParseCSVDelimiter(value)
+		require.Error(t, err, value)
+	}
+
+	decimal, err := exportapp.ParseCSVDecimal(",")
+	require.NoError(t, err)
+	assert.Equal(t, ',', decimal)
+	_, err = exportapp.ParseCSVDecimal("'")
+	require.Error(t, err)
+}
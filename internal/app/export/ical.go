@@ -1,23 +1,48 @@
 package export
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/kriuchkov/tock/internal/core/models"
 )
 
-func CombinedCalendar(activities []models.Activity) string {
-	sorted := models.SortActivitiesByStart(activities)
-	ids := models.ActivitySequenceIDs(sorted)
+// DefaultCalendarName is the X-WR-CALNAME of exported calendars.
+const DefaultCalendarName = "Tock"
+
+// maxLineOctets is the RFC 5545 limit for a content line, excluding CRLF.
+const maxLineOctets = 75
+
+const icalTimeLayout = "20060102T150405Z"
+
+const uidDomain = "@tock"
 
+// WithCalendarName sets the X-WR-CALNAME that calendar clients show for an
+// imported or subscribed calendar.
+func WithCalendarName(name string) Option {
+	return func(o *renderOptions) { o.calendarName = name }
+}
+
+// CombinedCalendar returns one calendar with every activity, sorted by start.
+func CombinedCalendar(activities []models.Activity, opts ...Option) string {
+	o := newRenderOptions(opts)
+
+	sorted := models.SortActivitiesByStart(activities)
+	uids := ActivityUIDs(sorted)
 	var sb strings.Builder
-	for _, activity := range sorted {
-		sb.WriteString(GenerateEvent(activity, ids[activity.StartTime.UnixNano()]))
+	for i, activity := range sorted {
+		uid := uids[i]
+		sb.WriteString(generateEvent(activity, uid, o.now, o.revisions.Revise(uid, activity, o.now)))
 	}
 
-	return WrapCalendar(sb.String())
+	return WrapCalendar(sb.String(), o.calendarName)
 }
 
 func ResolveExportFileName(configured string) string {
@@ -31,67 +56,244 @@ func ResolveExportFileName(configured string) string {
 	return fileName
 }
 
-func Generate(act models.Activity, uidKey string) string {
-	event := GenerateEvent(act, uidKey)
-	return WrapCalendar(event)
+// WithUID sets the UID of the activity exported by Generate, for an activity
+// that shares its start with others; see ActivityUIDs.
+func WithUID(uid string) Option {
+	return func(o *renderOptions) { o.uid = uid }
 }
 
-func WrapCalendar(eventsBody string) string {
+// Generate returns a calendar with a single activity.
+func Generate(act models.Activity, opts ...Option) string {
+	o := newRenderOptions(opts)
+	uid := o.uid
+	if uid == "" {
+		uid = ActivityUID(act)
+	}
+	return WrapCalendar(generateEvent(act, uid, o.now, o.revisions.Revise(uid, act, o.now)), o.calendarName)
+}
+
+// WrapCalendar wraps components produced by GenerateEvent in a VCALENDAR.
+func WrapCalendar(components, name string) string {
+	if name == "" {
+		name = DefaultCalendarName
+	}
+
 	var sb strings.Builder
-	sb.WriteString("BEGIN:VCALENDAR\n")
-	sb.WriteString("VERSION:2.0\n")
-	sb.WriteString("PRODID:-//Tock//NONSGML v1.0//EN\n")
-	sb.WriteString(eventsBody)
-	sb.WriteString("END:VCALENDAR")
+	writeLine(&sb, "BEGIN:VCALENDAR")
+	writeLine(&sb, "VERSION:2.0")
+	writeLine(&sb, "PRODID:-//Tock//NONSGML v1.0//EN")
+	writeLine(&sb, "CALSCALE:GREGORIAN")
+	writeLine(&sb, "METHOD:PUBLISH")
+	writeLine(&sb, "X-WR-CALNAME:"+escapeProperty(name))
+	sb.WriteString(components)
+	writeLine(&sb, "END:VCALENDAR")
 	return sb.String()
 }
 
-func GenerateEvent(act models.Activity, uidKey string) string {
-	now := time.Now().UTC().Format("20060102T150405Z")
-	start := act.StartTime.UTC().Format("20060102T150405Z")
+// ActivityUID identifies an activity across exports. It only depends on the
+// start time, so it survives reordering and edits of everything else. Use
+// ActivityUIDs when activities may share a start.
+func ActivityUID(act models.Activity) string {
+	start := act.StartTime.UTC()
+	uid := start.Format(icalTimeLayout)
+	if nanos := start.Nanosecond(); nanos != 0 {
+		uid += "-" + strconv.Itoa(nanos)
+	}
+	return uid + uidDomain
+}
 
-	var end string
+// ActivityUIDs returns the UID of each activity, in the given order. Parallel
+// timers and merged additions can share a start time and with it the
+// ActivityUID; each of those gets a suffix from its project and description
+// instead, and a counter when these are equal too.
+func ActivityUIDs(activities []models.Activity) []string {
+	byStart := make(map[string][]int)
+	for i, act := range activities {
+		uid := ActivityUID(act)
+		byStart[uid] = append(byStart[uid], i)
+	}
+
+	uids := make([]string, len(activities))
+	for uid, indexes := range byStart {
+		if len(indexes) == 1 {
+			uids[indexes[0]] = uid
+			continue
+		}
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return ActivityFingerprint(activities[indexes[a]]) < ActivityFingerprint(activities[indexes[b]])
+		})
+		base := strings.TrimSuffix(uid, uidDomain)
+		counts := make(map[string]int)
+		for _, i := range indexes {
+			suffix := uidSuffix(activities[i])
+			counts[suffix]++
+			if counts[suffix] > 1 {
+				suffix += "-" + strconv.Itoa(counts[suffix])
+			}
+			uids[i] = base + "-" + suffix + uidDomain
+		}
+	}
+	return uids
+}
+
+func uidSuffix(act models.Activity) string {
+	sum := sha256.Sum256([]byte(act.Project + "\x00" + act.Description))
+	return hex.EncodeToString(sum[:4])
+}
+
+// ActivityFingerprint changes whenever anything exported for the activity
+// changes. Unlike the generated calendar, it does not depend on the export
+// time, so it tells whether a copy elsewhere is out of date.
+func ActivityFingerprint(act models.Activity) string {
+	h := sha256.New()
+	end := int64(0)
 	if act.EndTime != nil {
-		end = act.EndTime.UTC().Format("20060102T150405Z")
-	} else {
-		end = time.Now().UTC().Format("20060102T150405Z")
+		end = act.EndTime.UnixNano()
 	}
+	fmt.Fprintf(h, "%d\x00%d\x00%q\x00%q\x00%q\x00%q", act.StartTime.UnixNano(), end, act.Project, act.Description, act.Notes, act.Tags)
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
 
-	summary := fmt.Sprintf("%s: %s", act.Project, act.Description)
-	uid := fmt.Sprintf("%s@tock", uidKey)
+// Revision is the SEQUENCE and LAST-MODIFIED of an exported activity. They
+// only change when its fingerprint does.
+type Revision struct {
+	Fingerprint string
+	Sequence    int
+	Modified    time.Time
+}
+
+// Revisions remembers the revision of every exported activity by UID, so a
+// change of an activity bumps its SEQUENCE and a re-export does not.
+type Revisions struct {
+	mu      sync.Mutex
+	entries map[string]Revision
+}
+
+// NewRevisions returns an empty Revisions.
+func NewRevisions() *Revisions {
+	return &Revisions{entries: make(map[string]Revision)}
+}
+
+// WithRevisions takes SEQUENCE and LAST-MODIFIED from revisions and records
+// the exported activities in it. Without it, every activity is exported as
+// its first revision.
+func WithRevisions(revisions *Revisions) Option {
+	return func(o *renderOptions) { o.revisions = revisions }
+}
+
+// Restore records a revision exported earlier, e.g. one kept in a sync state.
+func (r *Revisions) Restore(uid string, rev Revision) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[uid] = rev
+}
+
+// Revise returns the revision of act. It is the recorded one while the
+// fingerprint is unchanged; otherwise SEQUENCE goes up by one and
+// LAST-MODIFIED becomes now. A nil Revisions always returns the first
+// revision.
+func (r *Revisions) Revise(uid string, act models.Activity, now time.Time) Revision {
+	first := firstRevision(act)
+	if r == nil {
+		return first
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rev, known := r.entries[uid]
+	switch {
+	case !known:
+		rev = first
+	case rev.Fingerprint != first.Fingerprint:
+		rev = Revision{Fingerprint: first.Fingerprint, Sequence: rev.Sequence + 1, Modified: now.UTC().Truncate(time.Second)}
+	}
+	r.entries[uid] = rev
+	return rev
+}
+
+// firstRevision has SEQUENCE 0. Activities carry no modification time, so
+// LAST-MODIFIED is when the activity was last written in the normal course:
+// its end, or its start while it is running.
+func firstRevision(act models.Activity) Revision {
+	modified := act.StartTime
+	if act.EndTime != nil {
+		modified = *act.EndTime
+	}
+	return Revision{Fingerprint: ActivityFingerprint(act), Modified: modified.UTC().Truncate(time.Second)}
+}
+
+// GenerateEvent returns a VEVENT for a finished activity and a VTODO in
+// process for a running one, which has no end to report yet. The event is
+// the activity's first revision; use CombinedCalendar or Generate with
+// WithRevisions to track later ones.
+func GenerateEvent(act models.Activity, now time.Time) string {
+	return generateEvent(act, ActivityUID(act), now, firstRevision(act))
+}
+
+func generateEvent(act models.Activity, uid string, now time.Time, rev Revision) string {
+	now = now.UTC().Truncate(time.Second)
+	component := "VEVENT"
+	if act.EndTime == nil {
+		component = "VTODO"
+	}
 
 	var sb strings.Builder
-	sb.WriteString("BEGIN:VEVENT\n")
-	fmt.Fprintf(&sb, "UID:%s\n", uid)
-	fmt.Fprintf(&sb, "DTSTAMP:%s\n", now)
-	fmt.Fprintf(&sb, "DTSTART:%s\n", start)
-	fmt.Fprintf(&sb, "DTEND:%s\n", end)
-	fmt.Fprintf(&sb, "SUMMARY:%s\n", escapeProperty(summary))
+	writeLine(&sb, "BEGIN:"+component)
+	writeLine(&sb, "UID:"+uid)
+	writeLine(&sb, "DTSTAMP:"+now.Format(icalTimeLayout))
+	writeLine(&sb, "LAST-MODIFIED:"+rev.Modified.Format(icalTimeLayout))
+	writeLine(&sb, fmt.Sprintf("SEQUENCE:%d", rev.Sequence))
+	writeLine(&sb, "DTSTART:"+act.StartTime.UTC().Format(icalTimeLayout))
+	if act.EndTime != nil {
+		writeLine(&sb, "DTEND:"+act.EndTime.UTC().Format(icalTimeLayout))
+	} else {
+		writeLine(&sb, "STATUS:IN-PROCESS")
+	}
+	writeLine(&sb, "SUMMARY:"+escapeProperty(fmt.Sprintf("%s: %s", act.Project, act.Description)))
 
 	description := act.Description
 	if act.Notes != "" {
 		description += "\n\n" + act.Notes
 	}
-
-	fmt.Fprintf(&sb, "DESCRIPTION:%s\n", escapeProperty(description))
+	writeLine(&sb, "DESCRIPTION:"+escapeProperty(description))
 
 	if len(act.Tags) > 0 {
 		escapedTags := make([]string, len(act.Tags))
 		for i, tag := range act.Tags {
 			escapedTags[i] = escapeProperty(tag)
 		}
-
-		fmt.Fprintf(&sb, "CATEGORIES:%s\n", strings.Join(escapedTags, ","))
+		writeLine(&sb, "CATEGORIES:"+strings.Join(escapedTags, ","))
 	}
 
-	sb.WriteString("END:VEVENT\n")
+	writeLine(&sb, "X-TOCK-PROJECT:"+escapeProperty(act.Project))
+	writeLine(&sb, "END:"+component)
 	return sb.String()
 }
 
+// writeLine writes a content line terminated by CRLF, folded so that no line
+// exceeds 75 octets. Continuation lines start with a space, which counts
+// towards the limit, and multi-byte characters are never split.
+func writeLine(sb *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+}
+
 func escapeProperty(s string) string {
 	s = strings.ReplaceAll(s, "\\", "\\\\")
 	s = strings.ReplaceAll(s, ";", "\\;")
 	s = strings.ReplaceAll(s, ",", "\\,")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
 	s = strings.ReplaceAll(s, "\n", "\\n")
 	return s
 }
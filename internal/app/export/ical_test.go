@@ -1,8 +1,10 @@
 package export_test
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 
@@ -10,21 +12,134 @@ import (
 	"github.com/kriuchkov/tock/internal/core/models"
 )
 
-func TestCombinedCalendarUsesSequenceIDs(t *testing.T) {
-	start1 := time.Date(2026, time.March, 14, 11, 0, 0, 0, time.Local)
+func TestCombinedCalendarUsesStableUIDs(t *testing.T) {
+	start1 := time.Date(2026, time.March, 14, 11, 0, 0, 0, time.UTC)
 	end1 := start1.Add(time.Hour)
-	start2 := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.Local)
+	start2 := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
 	end2 := start2.Add(30 * time.Minute)
+	late := models.Activity{Project: "late", Description: "b", StartTime: start1, EndTime: &end1}
+	early := models.Activity{Project: "early", Description: "a", StartTime: start2, EndTime: &end2}
 
-	content := exportapp.CombinedCalendar([]models.Activity{
-		{Project: "late", Description: "b", StartTime: start1, EndTime: &end1},
-		{Project: "early", Description: "a", StartTime: start2, EndTime: &end2},
-	})
+	content := exportapp.CombinedCalendar([]models.Activity{late, early})
+	assert.Contains(t, content, "UID:20260314T110000Z@tock\r\n")
+	assert.Contains(t, content, "UID:20260314T090000Z@tock\r\n")
+	assert.Less(t, strings.Index(content, "SUMMARY:early: a"), strings.Index(content, "SUMMARY:late: b"))
+	assert.True(t, strings.HasPrefix(content, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(content, "END:VCALENDAR\r\n"))
+	assert.Contains(t, content, "X-WR-CALNAME:Tock\r\n")
 
-	assert.Contains(t, content, "UID:2026-03-14-01@tock")
-	assert.Contains(t, content, "UID:2026-03-14-02@tock")
-	assert.Contains(t, content, "BEGIN:VCALENDAR")
-	assert.Contains(t, content, "END:VCALENDAR")
+	// Edits other than the start time keep the UID.
+	late.Description = "renamed"
+	assert.Contains(t, exportapp.Generate(late), "UID:20260314T110000Z@tock\r\n")
+	assert.Equal(t, "20260314T090000Z@tock", exportapp.ActivityUID(early))
+}
+
+func TestActivityUIDsDisambiguateSharedStarts(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	alone := models.Activity{Project: "ops", Description: "deploy", StartTime: start.Add(2 * time.Hour), EndTime: &end}
+	api := models.Activity{Project: "api", Description: "review", StartTime: start, EndTime: &end}
+	web := models.Activity{Project: "web", Description: "review", StartTime: start}
+	twin := api
+	twin.Notes = "second timer"
+
+	uids := exportapp.ActivityUIDs([]models.Activity{api, alone, web})
+	assert.Equal(t, "20260314T110000Z@tock", uids[1], "an activity with its own start keeps its UID")
+	assert.NotEqual(t, uids[0], uids[2])
+	assert.True(t, strings.HasPrefix(uids[0], "20260314T090000Z-"))
+	assert.True(t, strings.HasSuffix(uids[0], "@tock"))
+	assert.Equal(t, uids, exportapp.ActivityUIDs([]models.Activity{api, alone, web}), "UIDs are stable")
+	assert.Equal(t, uids[0], exportapp.ActivityUIDs([]models.Activity{web, api})[1], "UIDs do not depend on the order")
+
+	uids = exportapp.ActivityUIDs([]models.Activity{api, twin})
+	assert.NotEqual(t, uids[0], uids[1], "equal project and description get a counter")
+
+	content := exportapp.CombinedCalendar([]models.Activity{api, web})
+	assert.Equal(t, 2, strings.Count(content, "UID:20260314T090000Z-"))
+}
+
+func TestGenerateEventUsesCRLFAndFoldsLongLines(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	act := models.Activity{
+		Project:     "docs",
+		Description: strings.Repeat("Überarbeitung, ", 10),
+		StartTime:   start,
+		EndTime:     &end,
+	}
+
+	content := exportapp.GenerateEvent(act, start.Add(2*time.Hour))
+	assert.NotContains(t, strings.ReplaceAll(content, "\r\n", ""), "\n")
+
+	lines := strings.Split(strings.TrimSuffix(content, "\r\n"), "\r\n")
+	folded := 0
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.True(t, utf8.ValidString(line), line)
+		if strings.HasPrefix(line, " ") {
+			folded++
+		}
+	}
+	assert.Positive(t, folded)
+
+	unfolded := strings.ReplaceAll(content, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:docs: "+strings.Repeat("Überarbeitung\\, ", 10)+"\r\n")
+	assert.Contains(t, unfolded, "DTSTAMP:20260314T110000Z\r\nLAST-MODIFIED:20260314T100000Z\r\nSEQUENCE:0\r\n")
+}
+
+func TestGenerateEventKeepsSequenceAcrossExports(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	act := models.Activity{Project: "p", Description: "d", StartTime: start, EndTime: &end}
+
+	first := exportapp.GenerateEvent(act, end)
+	later := exportapp.GenerateEvent(act, end.Add(24*time.Hour))
+	assert.Contains(t, later, "SEQUENCE:0\r\n")
+	assert.Contains(t, later, "LAST-MODIFIED:20260314T100000Z\r\n")
+	assert.Equal(t, strings.Replace(first, "DTSTAMP:20260314T100000Z", "DTSTAMP:20260315T100000Z", 1), later,
+		"only DTSTAMP changes while the activity does not")
+}
+
+func TestCombinedCalendarRevisionsBumpSequenceOnlyOnChange(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	act := models.Activity{Project: "p", Description: "d", StartTime: start, EndTime: &end}
+	revisions := exportapp.NewRevisions()
+	export := func(act models.Activity, now time.Time) string {
+		return exportapp.CombinedCalendar([]models.Activity{act}, exportapp.WithRevisions(revisions), exportapp.WithNow(now))
+	}
+
+	content := export(act, end.Add(time.Hour))
+	assert.Contains(t, content, "SEQUENCE:0\r\n")
+	assert.Contains(t, content, "LAST-MODIFIED:20260314T100000Z\r\n")
+	content = export(act, end.Add(2*time.Hour))
+	assert.Contains(t, content, "SEQUENCE:0\r\n")
+	assert.Contains(t, content, "LAST-MODIFIED:20260314T100000Z\r\n")
+
+	act.Description = "edited"
+	content = export(act, end.Add(3*time.Hour))
+	assert.Contains(t, content, "SEQUENCE:1\r\n")
+	assert.Contains(t, content, "LAST-MODIFIED:20260314T130000Z\r\n")
+	content = export(act, end.Add(4*time.Hour))
+	assert.Contains(t, content, "SEQUENCE:1\r\n")
+	assert.Contains(t, content, "LAST-MODIFIED:20260314T130000Z\r\n")
+}
+
+func TestGenerateEventExportsRunningActivityAsTodo(t *testing.T) {
+	start := time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+	act := models.Activity{Project: "p", Description: "d", StartTime: start}
+
+	content := exportapp.GenerateEvent(act, start.Add(time.Hour))
+	assert.True(t, strings.HasPrefix(content, "BEGIN:VTODO\r\n"))
+	assert.True(t, strings.HasSuffix(content, "END:VTODO\r\n"))
+	assert.Contains(t, content, "STATUS:IN-PROCESS\r\n")
+	assert.Contains(t, content, "DTSTART:20260314T090000Z\r\n")
+	assert.NotContains(t, content, "DTEND")
+}
+
+func TestWithCalendarName(t *testing.T) {
+	content := exportapp.CombinedCalendar(nil, exportapp.WithCalendarName("Work; client A"))
+	assert.Contains(t, content, "X-WR-CALNAME:Work\\; client A\r\n")
 }
 
 func TestResolveExportFileName(t *testing.T) {
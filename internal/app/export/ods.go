@@ -0,0 +1,147 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+// RenderODS writes the report as an OpenDocument spreadsheet with the same
+// sheets as RenderXLSX. Dates are date cells and durations are time cells, so
+// LibreOffice can sum them.
+func RenderODS(report *models.Report, opts ...Option) ([]byte, error) {
+	o := newRenderOptions(opts)
+	sheets := buildSpreadsheet(report, o.now)
+
+	return writeZip([]zipEntry{
+		// The mimetype must be the first entry and stored uncompressed.
+		{name: "mimetype", content: odsMimeType, store: true},
+		{name: "META-INF/manifest.xml", content: odsManifest},
+		{name: "content.xml", content: odsContent(sheets)},
+	}, o.now)
+}
+
+const odsManifest = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+	`<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+	`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>` +
+	`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+	`</manifest:manifest>`
+
+const odsStyles = `<office:automatic-styles>` +
+	`<number:date-style style:name="NDateTime"><number:year number:style="long"/><number:text>-</number:text>` +
+	`<number:month number:style="long"/><number:text>-</number:text><number:day number:style="long"/><number:text> </number:text>` +
+	`<number:hours number:style="long"/><number:text>:</number:text><number:minutes number:style="long"/></number:date-style>` +
+	`<number:date-style style:name="NDate"><number:year number:style="long"/><number:text>-</number:text>` +
+	`<number:month number:style="long"/><number:text>-</number:text><number:day number:style="long"/></number:date-style>` +
+	`<number:time-style style:name="NDuration" number:truncate-on-overflow="false"><number:hours/><number:text>:</number:text>` +
+	`<number:minutes number:style="long"/></number:time-style>` +
+	`<number:number-style style:name="NHours"><number:number number:decimal-places="2" number:min-integer-digits="1"/></number:number-style>` +
+	`<style:style style:name="cBold" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>` +
+	`<style:style style:name="cDateTime" style:family="table-cell" style:data-style-name="NDateTime"/>` +
+	`<style:style style:name="cDate" style:family="table-cell" style:data-style-name="NDate"/>` +
+	`<style:style style:name="cDuration" style:family="table-cell" style:data-style-name="NDuration"/>` +
+	`<style:style style:name="cBoldDuration" style:family="table-cell" style:data-style-name="NDuration">` +
+	`<style:text-properties fo:font-weight="bold"/></style:style>` +
+	`<style:style style:name="cHours" style:family="table-cell" style:data-style-name="NHours"/>` +
+	`<style:style style:name="cBoldHours" style:family="table-cell" style:data-style-name="NHours">` +
+	`<style:text-properties fo:font-weight="bold"/></style:style>`
+
+func odsContent(sheets []sheet) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+		`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+		`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+		`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+		`xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" ` +
+		`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" office:version="1.2">`)
+
+	// Column styles are shared by width.
+	b.WriteString(odsStyles)
+	columnStyles := make(map[float64]string)
+	for _, s := range sheets {
+		for _, w := range s.widths {
+			if _, ok := columnStyles[w]; ok {
+				continue
+			}
+			name := "co" + strconv.Itoa(len(columnStyles)+1)
+			columnStyles[w] = name
+			// A character is roughly 0.2 cm wide in the default font.
+			fmt.Fprintf(&b, `<style:style style:name="%s" style:family="table-column">`+
+				`<style:table-column-properties style:column-width="%.2fcm"/></style:style>`, name, w*0.2)
+		}
+	}
+	b.WriteString(`</office:automatic-styles><office:body><office:spreadsheet>`)
+
+	for _, s := range sheets {
+		fmt.Fprintf(&b, `<table:table table:name="%s">`, xmlEscape(s.name))
+		for _, w := range s.widths {
+			fmt.Fprintf(&b, `<table:table-column table:style-name="%s"/>`, columnStyles[w])
+		}
+		for r, row := range s.rows {
+			if r == 0 {
+				b.WriteString(`<table:table-header-rows>`)
+			}
+			b.WriteString(`<table:table-row>`)
+			for _, cell := range row {
+				writeODSCell(&b, cell)
+			}
+			b.WriteString(`</table:table-row>`)
+			if r == 0 {
+				b.WriteString(`</table:table-header-rows>`)
+			}
+		}
+		b.WriteString(`</table:table>`)
+	}
+	b.WriteString(`</office:spreadsheet></office:body></office:document-content>`)
+	return b.String()
+}
+
+func writeODSCell(b *strings.Builder, cell sheetCell) {
+	switch cell.kind {
+	case cellEmpty:
+		b.WriteString(`<table:table-cell/>`)
+	case cellString:
+		style := ""
+		if cell.bold {
+			style = ` table:style-name="cBold"`
+		}
+		fmt.Fprintf(b, `<table:table-cell office:value-type="string"%s><text:p>%s</text:p></table:table-cell>`, style, xmlEscape(cell.text))
+	case cellNumber:
+		value := strconv.FormatFloat(cell.number, 'f', -1, 64)
+		fmt.Fprintf(b, `<table:table-cell table:style-name="%s" office:value-type="float" office:value="%s"><text:p>%s</text:p></table:table-cell>`,
+			pickODSStyle(cell.bold, "cHours", "cBoldHours"), value, value)
+	case cellDateTime:
+		fmt.Fprintf(b, `<table:table-cell table:style-name="cDateTime" office:value-type="date" office:date-value="%s">`+
+			`<text:p>%s</text:p></table:table-cell>`, cell.time.Format("2006-01-02T15:04:05"), cell.time.Format("2006-01-02 15:04"))
+	case cellDate:
+		fmt.Fprintf(b, `<table:table-cell table:style-name="cDate" office:value-type="date" office:date-value="%s">`+
+			`<text:p>%s</text:p></table:table-cell>`, cell.time.Format(time.DateOnly), cell.time.Format(time.DateOnly))
+	case cellDuration:
+		d := cell.duration.Round(time.Second)
+		fmt.Fprintf(b, `<table:table-cell table:style-name="%s" office:value-type="time" office:time-value="%s">`+
+			`<text:p>%d:%02d</text:p></table:table-cell>`,
+			pickODSStyle(cell.bold, "cDuration", "cBoldDuration"), odsDuration(d), int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+func pickODSStyle(bold bool, regular, emphasized string) string {
+	if bold {
+		return emphasized
+	}
+	return regular
+}
+
+// odsDuration formats d as an ISO 8601 duration such as PT26H05M00S.
+func odsDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	return fmt.Sprintf("%sPT%02dH%02dM%02dS", sign, int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
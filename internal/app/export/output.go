@@ -1,11 +1,8 @@
 package export
 
 import (
-	"bytes"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"math"
 	"sort"
 	"strings"
 	"time"
@@ -16,16 +13,64 @@ import (
 	"github.com/kriuchkov/tock/internal/timeutil"
 )
 
-func RenderOutput(format string, report *models.Report, tf *timeutil.Formatter) ([]byte, error) {
+// Option customizes RenderOutput. Formats ignore options they do not use.
+type Option func(*renderOptions)
+
+type renderOptions struct {
+	tagColors    map[string]models.TagColor
+	templatesDir string
+	now          time.Time
+	csv          CSVOptions
+	calendarName string
+	revisions    *Revisions
+	uid          string
+}
+
+// WithTagColors colors timeline bars by tag or project, like the TUI views.
+func WithTagColors(colors map[string]models.TagColor) Option {
+	return func(o *renderOptions) { o.tagColors = colors }
+}
+
+// WithTemplatesDir makes md and html reports use report.md.tmpl and
+// report.html.tmpl from dir when they exist.
+func WithTemplatesDir(dir string) Option {
+	return func(o *renderOptions) { o.templatesDir = dir }
+}
+
+// WithNow sets the time used as the end of running activities and as the
+// timestamp of iCal exports.
+func WithNow(now time.Time) Option {
+	return func(o *renderOptions) { o.now = now }
+}
+
+func newRenderOptions(opts []Option) renderOptions {
+	o := renderOptions{now: time.Now()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func RenderOutput(format string, report *models.Report, tf *timeutil.Formatter, opts ...Option) ([]byte, error) {
 	switch format {
 	case "txt":
 		return []byte(RenderTextReport(report, tf)), nil
 	case "csv":
-		return RenderCSVReport(report.Activities)
+		return RenderCSVReport(report.Activities, opts...)
 	case "json":
 		return RenderJSONReport(report.Activities)
+	case "svg":
+		return RenderTimelineSVG(report, tf, opts...), nil
+	case "md":
+		return RenderMarkdownReport(report, tf, opts...)
+	case "html":
+		return RenderHTMLReport(report, tf, opts...)
+	case "xlsx":
+		return RenderXLSX(report, opts...)
+	case "ods":
+		return RenderODS(report, opts...)
 	default:
-		return nil, fmt.Errorf("unsupported format: %s (use txt, csv, or json)", format)
+		return nil, fmt.Errorf("unsupported format: %s (use txt, csv, json, md, html, svg, xlsx, or ods)", format)
 	}
 }
 
@@ -89,47 +134,6 @@ func RenderTextReport(report *models.Report, tf *timeutil.Formatter) string {
 	return b.String()
 }
 
-func RenderCSVReport(activities []models.Activity) ([]byte, error) {
-	sortedActivities := make([]models.Activity, len(activities))
-	copy(sortedActivities, activities)
-	sort.Slice(sortedActivities, func(i, j int) bool {
-		return sortedActivities[i].StartTime.Before(sortedActivities[j].StartTime)
-	})
-
-	var b bytes.Buffer
-	w := csv.NewWriter(&b)
-
-	if err := w.Write([]string{"project", "description", "start_time", "end_time", "duration_minutes"}); err != nil {
-		return nil, errors.Wrap(err, "write csv header")
-	}
-
-	for _, act := range sortedActivities {
-		endTime := ""
-		if act.EndTime != nil {
-			endTime = act.EndTime.Format(time.RFC3339)
-		}
-
-		durationMinutes := math.Floor((act.Duration().Seconds()/60)*100) / 100
-		record := []string{
-			act.Project,
-			act.Description,
-			act.StartTime.Format(time.RFC3339),
-			endTime,
-			fmt.Sprintf("%.2f", durationMinutes),
-		}
-		if err := w.Write(record); err != nil {
-			return nil, errors.Wrap(err, "write csv row")
-		}
-	}
-
-	w.Flush()
-	if err := w.Error(); err != nil {
-		return nil, errors.Wrap(err, "flush csv")
-	}
-
-	return b.Bytes(), nil
-}
-
 func RenderJSONReport(activities []models.Activity) ([]byte, error) {
 	payload, err := json.MarshalIndent(activities, "", "  ")
 	if err != nil {
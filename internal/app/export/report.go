@@ -0,0 +1,196 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+const (
+	MarkdownTemplateName = "report.md.tmpl"
+	HTMLTemplateName     = "report.html.tmpl"
+)
+
+//go:embed templates/*.tmpl templates/reports/*.tmpl
+var builtinTemplates embed.FS
+
+// ReportData is what report templates are executed with. Durations are
+// preformatted as "Xh Ym"; the raw values are kept for custom templates.
+type ReportData struct {
+	Title         string
+	From          string
+	To            string
+	GeneratedAt   string
+	Projects      []ProjectSummary
+	Activities    []ActivityRow
+	Total         string
+	TotalDuration time.Duration
+	Timeline      htmltemplate.HTML // SVG timeline, only set for html
+}
+
+type ProjectSummary struct {
+	Name       string
+	Total      string
+	Duration   time.Duration
+	Activities int
+}
+
+type ActivityRow struct {
+	ID          string
+	Project     string
+	Description string
+	Date        string
+	Start       string
+	End         string
+	Duration    string
+	Running     bool
+	Tags        []string
+	Notes       string
+}
+
+// BuildReportData flattens a report into template data. Activities are sorted
+// by start time and keep the YYYY-MM-DD-NN IDs used by other commands.
+func BuildReportData(report *models.Report, tf *timeutil.Formatter, now time.Time) ReportData {
+	sorted := models.SortActivitiesByStart(report.Activities)
+	ids := models.ActivitySequenceIDs(sorted)
+	layout := tf.GetDisplayFormat()
+
+	data := ReportData{Title: "Time Tracking Report", GeneratedAt: now.Format(time.DateOnly + " " + layout)}
+	byProject := make(map[string]*ProjectSummary)
+	for _, act := range sorted {
+		end := now
+		row := ActivityRow{
+			ID:          ids[act.StartTime.UnixNano()],
+			Project:     act.Project,
+			Description: act.Description,
+			Date:        act.StartTime.Format(time.DateOnly),
+			Start:       act.StartTime.Format(layout),
+			End:         "--:--",
+			Running:     act.EndTime == nil,
+			Tags:        act.Tags,
+			Notes:       act.Notes,
+		}
+		if act.EndTime != nil {
+			end = *act.EndTime
+			row.End = end.Format(layout)
+		}
+		duration := end.Sub(act.StartTime)
+		row.Duration = formatTimelineDuration(duration)
+		data.Activities = append(data.Activities, row)
+		data.TotalDuration += duration
+
+		summary, ok := byProject[act.Project]
+		if !ok {
+			summary = &ProjectSummary{Name: act.Project}
+			byProject[act.Project] = summary
+		}
+		summary.Duration += duration
+		summary.Activities++
+	}
+
+	for _, summary := range byProject {
+		summary.Total = formatTimelineDuration(summary.Duration)
+		data.Projects = append(data.Projects, *summary)
+	}
+	sort.Slice(data.Projects, func(i, j int) bool { return data.Projects[i].Name < data.Projects[j].Name })
+
+	data.Total = formatTimelineDuration(data.TotalDuration)
+	if len(data.Activities) > 0 {
+		data.From = data.Activities[0].Date
+		data.To = data.Activities[len(data.Activities)-1].Date
+	}
+	return data
+}
+
+// RenderMarkdownReport renders the report with report.md.tmpl, read from the
+// templates directory when present and from the built-in copy otherwise.
+func RenderMarkdownReport(report *models.Report, tf *timeutil.Formatter, opts ...Option) ([]byte, error) {
+	o := newRenderOptions(opts)
+	source, err := loadTemplate(o.templatesDir, MarkdownTemplateName)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := texttemplate.New(MarkdownTemplateName).Funcs(texttemplate.FuncMap{
+		"join": strings.Join,
+		"md":   escapeMarkdownCell,
+	}).Parse(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse markdown template")
+	}
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, BuildReportData(report, tf, o.now)); err != nil {
+		return nil, errors.Wrap(err, "execute markdown template")
+	}
+	return b.Bytes(), nil
+}
+
+// RenderHTMLReport renders the report with report.html.tmpl. The page is
+// self-contained and embeds the project timeline as inline SVG.
+func RenderHTMLReport(report *models.Report, tf *timeutil.Formatter, opts ...Option) ([]byte, error) {
+	o := newRenderOptions(opts)
+	source, err := loadTemplate(o.templatesDir, HTMLTemplateName)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := htmltemplate.New(HTMLTemplateName).Funcs(htmltemplate.FuncMap{
+		"join": strings.Join,
+	}).Parse(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse html template")
+	}
+
+	data := BuildReportData(report, tf, o.now)
+	var svg strings.Builder
+	newTimeline(report.Activities, tf, o).writeSVG(&svg)
+	data.Timeline = htmltemplate.HTML(svg.String()) //nolint:gosec // generated by writeSVG, which escapes all text
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, data); err != nil {
+		return nil, errors.Wrap(err, "execute html template")
+	}
+	return b.Bytes(), nil
+}
+
+// BuiltinTemplate returns the embedded default for a template name.
+func BuiltinTemplate(name string) (string, error) {
+	content, err := builtinTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		return "", errors.Wrapf(err, "read built-in template %s", name)
+	}
+	return string(content), nil
+}
+
+// loadTemplate prefers a user template in dir over the built-in one.
+func loadTemplate(dir, name string) (string, error) {
+	if dir != "" {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return string(content), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", errors.Wrapf(err, "read template %s", name)
+		}
+	}
+	return BuiltinTemplate(name)
+}
+
+// escapeMarkdownCell keeps a value on one table row.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "<br>")
+}
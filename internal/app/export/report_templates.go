@@ -0,0 +1,306 @@
+package export
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+const (
+	reportTemplateExt = ".tmpl"
+	builtinReportsDir = "templates/reports"
+
+	TemplateSourceBuiltin = "built-in"
+	TemplateSourceUser    = "user"
+)
+
+// ErrTemplateNotFound is returned when no user or built-in template has the name.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// TemplateData is what `tock report --template` templates are executed with.
+// Groups are sorted by duration, longest first; Days are sorted by date.
+type TemplateData struct {
+	Report     *models.Report
+	Activities []models.Activity // sorted by start time
+	Period     Period
+	Projects   []Group
+	Tags       []Group
+	Days       []Group
+	Stats      insights.Stats
+	Generated  time.Time
+}
+
+// Period holds the report bounds. To is exclusive; use Until for the last
+// included day. Without a date filter the bounds of the activities are used.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Until returns the last day covered by the period.
+func (p Period) Until() time.Time {
+	if p.To.IsZero() {
+		return p.To
+	}
+	return p.To.Add(-time.Nanosecond)
+}
+
+// Group aggregates activities by project, tag or day.
+type Group struct {
+	Name       string
+	Duration   time.Duration
+	Activities []models.Activity
+}
+
+// TemplateInfo describes a report template found by ListReportTemplates.
+type TemplateInfo struct {
+	Name        string
+	Source      string // TemplateSourceBuiltin or TemplateSourceUser
+	Path        string // empty for built-in templates
+	Description string
+}
+
+// BuildTemplateData groups a report for report templates. from and to are the
+// filter bounds and may be nil.
+func BuildTemplateData(report *models.Report, from, to *time.Time, now time.Time) TemplateData {
+	sorted := models.SortActivitiesByStart(report.Activities)
+	data := TemplateData{
+		Report:     report,
+		Activities: sorted,
+		Stats:      insights.AnalyzeActivities(sorted),
+		Generated:  now,
+	}
+
+	projects := make(map[string]*Group)
+	tags := make(map[string]*Group)
+	days := make(map[string]*Group)
+	for _, act := range sorted {
+		addToGroup(projects, act.Project, act)
+		for _, tag := range act.Tags {
+			addToGroup(tags, tag, act)
+		}
+		for _, segment := range insights.SplitActivityByDay(act, now) {
+			addToGroup(days, insights.DateKey(segment.StartTime), segment)
+		}
+	}
+	data.Projects = sortedGroups(projects, byDuration)
+	data.Tags = sortedGroups(tags, byDuration)
+	data.Days = sortedGroups(days, func(a, b Group) bool { return a.Name < b.Name })
+
+	if from != nil {
+		data.Period.From = *from
+	}
+	if to != nil {
+		data.Period.To = *to
+	}
+	if len(sorted) > 0 && data.Period.From.IsZero() {
+		first := sorted[0].StartTime
+		data.Period.From = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if len(data.Days) > 0 && data.Period.To.IsZero() {
+		last, _ := time.ParseInLocation(time.DateOnly, data.Days[len(data.Days)-1].Name, time.Local)
+		data.Period.To = last.AddDate(0, 0, 1)
+	}
+	return data
+}
+
+func addToGroup(groups map[string]*Group, name string, act models.Activity) {
+	group, ok := groups[name]
+	if !ok {
+		group = &Group{Name: name}
+		groups[name] = group
+	}
+	group.Duration += act.Duration()
+	group.Activities = append(group.Activities, act)
+}
+
+func byDuration(a, b Group) bool {
+	if a.Duration != b.Duration {
+		return a.Duration > b.Duration
+	}
+	return a.Name < b.Name
+}
+
+func sortedGroups(groups map[string]*Group, less func(a, b Group) bool) []Group {
+	result := make([]Group, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool { return less(result[i], result[j]) })
+	return result
+}
+
+// RenderReportTemplate executes the named report template, preferring a user
+// template in dir over a built-in one with the same name.
+func RenderReportTemplate(name string, data TemplateData, tf *timeutil.Formatter, opts ...Option) ([]byte, error) {
+	o := newRenderOptions(opts)
+	source, err := ReportTemplateSource(o.templatesDir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := texttemplate.New(name).Funcs(templateFuncs(data, tf, o)).Parse(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse template %s", name)
+	}
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, data); err != nil {
+		return nil, errors.Wrapf(err, "execute template %s", name)
+	}
+	return b.Bytes(), nil
+}
+
+// templateFuncs are the helpers available to report templates.
+func templateFuncs(data TemplateData, tf *timeutil.Formatter, o renderOptions) texttemplate.FuncMap {
+	ids := models.ActivitySequenceIDs(data.Activities)
+	return texttemplate.FuncMap{
+		// duration formats as "1h 30m", or with a timeutil layout such as "15:04" or "decimal".
+		"duration": func(d time.Duration, layout ...string) string {
+			if len(layout) > 0 {
+				return timeutil.FormatDuration(d, layout[0])
+			}
+			return formatTimelineDuration(d)
+		},
+		// round rounds a duration to the nearest step, e.g. round .Duration "15m".
+		"round": func(d time.Duration, step string) (time.Duration, error) {
+			parsed, err := time.ParseDuration(step)
+			if err != nil {
+				return 0, errors.Wrap(err, "parse rounding step")
+			}
+			return d.Round(parsed), nil
+		},
+		"hours": func(d time.Duration) float64 { return d.Hours() },
+		"percent": func(part, total time.Duration) float64 {
+			if total <= 0 {
+				return 0
+			}
+			return float64(part) / float64(total) * 100
+		},
+		"date": func(t any) string { return formatTemplateTime(t, time.DateOnly) },
+		"time": func(t any) string { return formatTemplateTime(t, tf.GetDisplayFormat()) },
+		"csv":  csvField,
+		"id":   func(act models.Activity) string { return ids[act.StartTime.UnixNano()] },
+		// tagColor returns the configured color of a tag or project as a hex value, or "".
+		"tagColor": func(name string) string {
+			if c, ok := o.tagColors[name]; ok {
+				if hex, valid := tagColorHex(c); valid {
+					return hex
+				}
+			}
+			return ""
+		},
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
+
+// formatTemplateTime accepts time.Time and *time.Time, so templates can pass
+// an activity's EndTime directly. A nil pointer formats as "".
+func formatTemplateTime(value any, layout string) string {
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t != nil {
+			return t.Format(layout)
+		}
+	}
+	return ""
+}
+
+// csvField quotes a value for a CSV cell when it needs quoting.
+func csvField(value string) string {
+	if !strings.ContainsAny(value, ",\"\r\n") {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+// ReportTemplateSource returns the source of a user or built-in template.
+func ReportTemplateSource(dir, name string) (string, error) {
+	if strings.ContainsAny(name, `/\`) || name == "" {
+		return "", errors.Wrapf(ErrTemplateNotFound, "invalid template name %q", name)
+	}
+	if dir != "" {
+		content, err := os.ReadFile(filepath.Join(dir, name+reportTemplateExt))
+		if err == nil {
+			return string(content), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", errors.Wrapf(err, "read template %s", name)
+		}
+	}
+
+	content, err := builtinTemplates.ReadFile(builtinReportsDir + "/" + name + reportTemplateExt)
+	if err != nil {
+		return "", errors.Wrapf(ErrTemplateNotFound, "%s", name)
+	}
+	return string(content), nil
+}
+
+// ListReportTemplates returns built-in and user templates sorted by name. A
+// user template shadows a built-in one with the same name. The md and html
+// export templates in dir are not report templates and are skipped.
+func ListReportTemplates(dir string) ([]TemplateInfo, error) {
+	found := make(map[string]TemplateInfo)
+
+	builtin, err := fs.ReadDir(builtinTemplates, builtinReportsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "read built-in templates")
+	}
+	for _, entry := range builtin {
+		name := strings.TrimSuffix(entry.Name(), reportTemplateExt)
+		content, _ := builtinTemplates.ReadFile(builtinReportsDir + "/" + entry.Name())
+		found[name] = TemplateInfo{Name: name, Source: TemplateSourceBuiltin, Description: templateDescription(string(content))}
+	}
+
+	if dir != "" {
+		entries, readErr := os.ReadDir(dir)
+		if readErr != nil && !errors.Is(readErr, os.ErrNotExist) {
+			return nil, errors.Wrap(readErr, "read templates directory")
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), reportTemplateExt) ||
+				entry.Name() == MarkdownTemplateName || entry.Name() == HTMLTemplateName {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			content, _ := os.ReadFile(path)
+			name := strings.TrimSuffix(entry.Name(), reportTemplateExt)
+			found[name] = TemplateInfo{Name: name, Source: TemplateSourceUser, Path: path, Description: templateDescription(string(content))}
+		}
+	}
+
+	result := make([]TemplateInfo, 0, len(found))
+	for _, info := range found {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// templateDescription returns the text of a leading {{/* ... */}} comment.
+func templateDescription(source string) string {
+	source = strings.TrimSpace(source)
+	for _, prefix := range []string{"{{- /*", "{{/*"} {
+		if rest, ok := strings.CutPrefix(source, prefix); ok {
+			if end := strings.Index(rest, "*/"); end >= 0 {
+				return strings.Join(strings.Fields(rest[:end]), " ")
+			}
+		}
+	}
+	return ""
+}
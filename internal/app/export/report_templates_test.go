@@ -0,0 +1,126 @@
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func templateReport() *models.Report {
+	day1 := time.Date(2026, time.March, 9, 9, 0, 0, 0, time.Local)
+	day2 := time.Date(2026, time.March, 10, 23, 0, 0, 0, time.Local)
+	end1 := day1.Add(2 * time.Hour)
+	end2 := day1.Add(3 * time.Hour)
+	end3 := day2.Add(2 * time.Hour)
+	return &models.Report{
+		TotalDuration: 5 * time.Hour,
+		Activities: []models.Activity{
+			{Project: "ops", Description: "on-call, night", StartTime: day2, EndTime: &end3, Tags: []string{"infra"}},
+			{Project: "tock", Description: "templates", StartTime: day1, EndTime: &end1, Tags: []string{"dev", "infra"}},
+			{Project: "tock", Description: "review", StartTime: end1, EndTime: &end2},
+		},
+	}
+}
+
+func TestBuildTemplateDataGroupsReport(t *testing.T) {
+	now := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.Local)
+	data := exportapp.BuildTemplateData(templateReport(), nil, nil, now)
+
+	require.Len(t, data.Activities, 3)
+	assert.Equal(t, "templates", data.Activities[0].Description)
+
+	require.Len(t, data.Projects, 2)
+	assert.Equal(t, "tock", data.Projects[0].Name)
+	assert.Equal(t, 3*time.Hour, data.Projects[0].Duration)
+
+	require.Len(t, data.Tags, 2)
+	assert.Equal(t, "infra", data.Tags[0].Name)
+	assert.Equal(t, 4*time.Hour, data.Tags[0].Duration)
+
+	require.Len(t, data.Days, 3, "the night shift is split at midnight")
+	assert.Equal(t, []string{"2026-03-09", "2026-03-10", "2026-03-11"},
+		[]string{data.Days[0].Name, data.Days[1].Name, data.Days[2].Name})
+	assert.Equal(t, time.Hour, data.Days[2].Duration)
+
+	assert.Equal(t, time.Date(2026, time.March, 9, 0, 0, 0, 0, time.Local), data.Period.From)
+	assert.Equal(t, time.Date(2026, time.March, 12, 0, 0, 0, 0, time.Local), data.Period.To)
+	assert.Equal(t, 11, data.Period.Until().Day())
+	assert.Equal(t, 5*time.Hour, data.Stats.TotalDuration)
+}
+
+func TestRenderReportTemplateHelpers(t *testing.T) {
+	dir := t.TempDir()
+	source := `{{ range .Activities }}{{ id . }} {{ csv .Description }} {{ time .StartTime }}-{{ time .EndTime }} ` +
+		`{{ duration .Duration }} {{ duration (round .Duration "1h") "decimal:1" }} {{ tagColor .Project }}|{{ end }}` +
+		`{{ printf "%.0f" (percent (index .Projects 0).Duration .Report.TotalDuration) }}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "custom.tmpl"), []byte(source), 0600))
+
+	now := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.Local)
+	content, err := exportapp.RenderReportTemplate("custom", exportapp.BuildTemplateData(templateReport(), nil, nil, now),
+		timeutil.NewFormatter("24"),
+		exportapp.WithTemplatesDir(dir),
+		exportapp.WithTagColors(map[string]models.TagColor{"ops": {FG: "196"}}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"2026-03-09-01 templates 09:00-11:00 2h 0m 2.0 |"+
+			"2026-03-09-02 review 11:00-12:00 1h 0m 1.0 |"+
+			`2026-03-10-01 "on-call, night" 23:00-01:00 2h 0m 2.0 #ff0000|60`,
+		string(content))
+}
+
+func TestRenderBuiltinReportTemplates(t *testing.T) {
+	now := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.Local)
+	data := exportapp.BuildTemplateData(templateReport(), nil, nil, now)
+	tf := timeutil.NewFormatter("24")
+
+	status, err := exportapp.RenderReportTemplate("weekly-status", data, tf)
+	require.NoError(t, err)
+	assert.Contains(t, string(status), "## Status 2026-03-09 – 2026-03-11")
+	assert.Contains(t, string(status), "- **tock** — 3h 0m (60%)")
+	assert.Contains(t, string(status), "- #infra: 4h 0m")
+
+	standup, err := exportapp.RenderReportTemplate("standup", data, tf)
+	require.NoError(t, err)
+	assert.Contains(t, string(standup), "2026-03-11 (1h 0m)\n  • [ops] on-call, night – 1h 0m")
+
+	timesheet, err := exportapp.RenderReportTemplate("timesheet", data, tf)
+	require.NoError(t, err)
+	assert.Contains(t, string(timesheet), `2026-03-10,2026-03-10-01,ops,"on-call, night",23:00,01:00,2.00`)
+	assert.Contains(t, string(timesheet), "total,,,,,,5.00")
+
+	empty, err := exportapp.RenderReportTemplate("weekly-status", exportapp.BuildTemplateData(&models.Report{}, nil, nil, now), tf)
+	require.NoError(t, err)
+	assert.Contains(t, string(empty), "Nothing tracked in this period.")
+}
+
+func TestListReportTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "standup.tmpl"), []byte("{{- /* My standup. */ -}}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, exportapp.HTMLTemplateName), []byte(""), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(""), 0600))
+
+	templates, err := exportapp.ListReportTemplates(dir)
+	require.NoError(t, err)
+	require.Len(t, templates, 3)
+	assert.Equal(t, exportapp.TemplateInfo{
+		Name: "standup", Source: exportapp.TemplateSourceUser, Path: filepath.Join(dir, "standup.tmpl"), Description: "My standup.",
+	}, templates[0])
+	assert.Equal(t, "timesheet", templates[1].Name)
+	assert.Equal(t, exportapp.TemplateSourceBuiltin, templates[2].Source)
+
+	templates, err = exportapp.ListReportTemplates(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Len(t, templates, 3)
+
+	_, err = exportapp.ReportTemplateSource(dir, "missing")
+	require.ErrorIs(t, err, exportapp.ErrTemplateNotFound)
+}
@@ -0,0 +1,98 @@
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func TestBuildReportData(t *testing.T) {
+	now := time.Date(2026, time.March, 5, 11, 0, 0, 0, time.Local)
+	data := exportapp.BuildReportData(timelineReport(), timeutil.NewFormatter("24"), now)
+
+	assert.Equal(t, "2026-03-02", data.From)
+	assert.Equal(t, "2026-03-05", data.To)
+	assert.Equal(t, "3h 30m", data.Total)
+	require.Len(t, data.Projects, 2)
+	assert.Equal(t, exportapp.ProjectSummary{Name: "ops", Total: "0h 30m", Duration: 30 * time.Minute, Activities: 1}, data.Projects[0])
+	assert.Equal(t, "tock", data.Projects[1].Name)
+	assert.Equal(t, "3h 0m", data.Projects[1].Total)
+
+	require.Len(t, data.Activities, 3)
+	assert.Equal(t, "2026-03-02-01", data.Activities[0].ID)
+	assert.Equal(t, "09:00", data.Activities[0].Start)
+	assert.Equal(t, "11:00", data.Activities[0].End)
+	assert.Equal(t, []string{"dev"}, data.Activities[0].Tags)
+	assert.True(t, data.Activities[2].Running)
+	assert.Equal(t, "1h 0m", data.Activities[2].Duration)
+}
+
+func TestRenderOutputMarkdown(t *testing.T) {
+	report := timelineReport()
+	report.Activities[1].Description = "deploy | rollback"
+	report.Activities[1].Notes = "line one\nline two"
+
+	content, err := exportapp.RenderOutput("md", report, timeutil.NewFormatter("24"),
+		exportapp.WithNow(time.Date(2026, time.March, 5, 11, 0, 0, 0, time.Local)))
+	require.NoError(t, err)
+
+	md := string(content)
+	assert.True(t, strings.HasPrefix(md, "# Time Tracking Report\n"))
+	assert.Contains(t, md, "2026-03-02 – 2026-03-05")
+	assert.Contains(t, md, "| ops | 1 | 0h 30m |")
+	assert.Contains(t, md, "| **Total** | **3** | **3h 30m** |")
+	assert.Contains(t, md, "| `2026-03-02-01` | tock | timeline <svg> | 09:00 | 11:00 | 2h 0m | dev | lanes & bars |")
+	assert.Contains(t, md, `deploy \| rollback`)
+	assert.Contains(t, md, "line one<br>line two")
+	assert.Contains(t, md, "| 10:00 | running | 1h 0m |")
+	assert.Contains(t, md, "**Total: 3h 30m**")
+}
+
+func TestRenderOutputHTMLReportEscapesAndEmbedsTimeline(t *testing.T) {
+	content, err := exportapp.RenderOutput("html", timelineReport(), timeutil.NewFormatter("24"))
+	require.NoError(t, err)
+
+	page := string(content)
+	assert.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	assert.Contains(t, page, `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Contains(t, page, `class="bar"`)
+	assert.Contains(t, page, "<td>timeline &lt;svg&gt;</td>")
+	assert.Contains(t, page, `<span class="tag">dev</span>`)
+	assert.Contains(t, page, "<code>2026-03-05-01</code>")
+	assert.NotContains(t, page, `src="http`)
+}
+
+func TestRenderOutputReportsUseTemplatesDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, exportapp.MarkdownTemplateName),
+		[]byte("{{ range .Projects }}{{ .Name }}={{ .Total }};{{ end }}"), 0600))
+
+	content, err := exportapp.RenderOutput("md", timelineReport(), timeutil.NewFormatter("24"),
+		exportapp.WithTemplatesDir(dir), exportapp.WithNow(time.Date(2026, time.March, 5, 11, 0, 0, 0, time.Local)))
+	require.NoError(t, err)
+	assert.Equal(t, "ops=0h 30m;tock=3h 0m;", string(content))
+
+	// No html override in dir: the built-in template is used.
+	content, err = exportapp.RenderOutput("html", timelineReport(), timeutil.NewFormatter("24"), exportapp.WithTemplatesDir(dir))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "<h2>Activities</h2>")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, exportapp.HTMLTemplateName), []byte("{{ .Missing"), 0600))
+	_, err = exportapp.RenderOutput("html", timelineReport(), timeutil.NewFormatter("24"), exportapp.WithTemplatesDir(dir))
+	require.ErrorContains(t, err, "parse html template")
+}
+
+func TestRenderOutputMarkdownWithoutActivities(t *testing.T) {
+	content, err := exportapp.RenderOutput("md", &models.Report{}, timeutil.NewFormatter("24"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "No activities found for the specified period.")
+}
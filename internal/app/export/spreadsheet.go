@@ -0,0 +1,246 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellString
+	cellNumber
+	cellDateTime
+	cellDate
+	cellDuration
+)
+
+// sheetCell is a typed spreadsheet value. Writers map kinds to native cell
+// types, so dates and durations stay sortable and summable.
+type sheetCell struct {
+	kind     cellKind
+	text     string
+	number   float64
+	time     time.Time
+	duration time.Duration
+	bold     bool
+}
+
+// sheet is one worksheet; the first row is a header and stays frozen.
+type sheet struct {
+	name   string
+	widths []float64 // column widths in characters
+	rows   [][]sheetCell
+}
+
+func textCell(s string) sheetCell            { return sheetCell{kind: cellString, text: s} }
+func numberCell(v float64) sheetCell         { return sheetCell{kind: cellNumber, number: v} }
+func dateTimeCell(t time.Time) sheetCell     { return sheetCell{kind: cellDateTime, time: t} }
+func dateCell(t time.Time) sheetCell         { return sheetCell{kind: cellDate, time: t} }
+func durationCell(d time.Duration) sheetCell { return sheetCell{kind: cellDuration, duration: d} }
+
+// hoursCell holds a duration as decimal hours, rounded to the minute.
+func hoursCell(d time.Duration) sheetCell {
+	return numberCell(float64(d.Round(time.Minute)) / float64(time.Hour))
+}
+
+func headerRow(titles ...string) []sheetCell {
+	cells := make([]sheetCell, len(titles))
+	for i, title := range titles {
+		cells[i] = textCell(title)
+	}
+	return emphasize(cells)
+}
+
+func boldRow(cells ...sheetCell) []sheetCell { return emphasize(cells) }
+
+func emphasize(cells []sheetCell) []sheetCell {
+	for i := range cells {
+		cells[i].bold = true
+	}
+	return cells
+}
+
+// buildSpreadsheet lays out the report as a per-project summary, the raw
+// activities and a day × project pivot of durations.
+func buildSpreadsheet(report *models.Report, now time.Time) []sheet {
+	sorted := models.SortActivitiesByStart(report.Activities)
+	return []sheet{
+		buildSummarySheet(sorted, now),
+		buildActivitiesSheet(sorted, now),
+		buildDailySheet(sorted, now),
+	}
+}
+
+func activityDuration(act models.Activity, now time.Time) time.Duration {
+	if act.EndTime != nil {
+		return act.EndTime.Sub(act.StartTime)
+	}
+	return now.Sub(act.StartTime)
+}
+
+func buildSummarySheet(activities []models.Activity, now time.Time) sheet {
+	type projectTotal struct {
+		count    int
+		duration time.Duration
+	}
+	totals := make(map[string]*projectTotal)
+	var total time.Duration
+	for _, act := range activities {
+		t, ok := totals[act.Project]
+		if !ok {
+			t = &projectTotal{}
+			totals[act.Project] = t
+		}
+		d := activityDuration(act, now)
+		t.count++
+		t.duration += d
+		total += d
+	}
+
+	names := make([]string, 0, len(totals))
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := sheet{name: "Summary", widths: []float64{30, 12, 12, 10}}
+	s.rows = append(s.rows, headerRow("Project", "Activities", "Duration", "Hours"))
+	for _, name := range names {
+		t := totals[name]
+		s.rows = append(s.rows, []sheetCell{textCell(name), numberCell(float64(t.count)), durationCell(t.duration), hoursCell(t.duration)})
+	}
+	s.rows = append(s.rows, boldRow(textCell("Total"), numberCell(float64(len(activities))), durationCell(total), hoursCell(total)))
+	return s
+}
+
+func buildActivitiesSheet(activities []models.Activity, now time.Time) sheet {
+	ids := models.ActivitySequenceIDs(activities)
+	s := sheet{name: "Activities", widths: []float64{15, 12, 20, 40, 17, 17, 10, 8, 20, 50}}
+	s.rows = append(s.rows, headerRow("ID", "Date", "Project", "Description", "Start", "End", "Duration", "Hours", "Tags", "Notes"))
+	for _, act := range activities {
+		end := sheetCell{}
+		if act.EndTime != nil {
+			end = dateTimeCell(*act.EndTime)
+		}
+		d := activityDuration(act, now)
+		s.rows = append(s.rows, []sheetCell{
+			textCell(ids[act.StartTime.UnixNano()]),
+			dateCell(act.StartTime),
+			textCell(act.Project),
+			textCell(act.Description),
+			dateTimeCell(act.StartTime),
+			end,
+			durationCell(d),
+			hoursCell(d),
+			textCell(strings.Join(act.Tags, ", ")),
+			textCell(act.Notes),
+		})
+	}
+	return s
+}
+
+// buildDailySheet pivots durations by day and project. Activities crossing
+// midnight are split so each day holds only its own share.
+func buildDailySheet(activities []models.Activity, now time.Time) sheet {
+	byDay := make(map[string]map[string]time.Duration)
+	projectSet := make(map[string]bool)
+	for _, act := range activities {
+		for _, segment := range insights.SplitActivityByDay(act, now) {
+			day := insights.DateKey(segment.StartTime)
+			if byDay[day] == nil {
+				byDay[day] = make(map[string]time.Duration)
+			}
+			byDay[day][act.Project] += activityDuration(segment, now)
+			projectSet[act.Project] = true
+		}
+	}
+
+	projects := make([]string, 0, len(projectSet))
+	for project := range projectSet {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	s := sheet{name: "Daily", widths: []float64{12}}
+	s.rows = append(s.rows, headerRow(append(append([]string{"Date"}, projects...), "Total")...))
+	projectTotals := make([]time.Duration, len(projects))
+	var grandTotal time.Duration
+	for _, day := range days {
+		date, _ := time.ParseInLocation(time.DateOnly, day, time.Local)
+		row := []sheetCell{dateCell(date)}
+		var dayTotal time.Duration
+		for i, project := range projects {
+			d := byDay[day][project]
+			projectTotals[i] += d
+			dayTotal += d
+			if d == 0 {
+				row = append(row, sheetCell{})
+				continue
+			}
+			row = append(row, durationCell(d))
+		}
+		grandTotal += dayTotal
+		s.rows = append(s.rows, append(row, boldRow(durationCell(dayTotal))...))
+	}
+
+	totals := []sheetCell{textCell("Total")}
+	for _, d := range projectTotals {
+		totals = append(totals, durationCell(d))
+	}
+	s.rows = append(s.rows, boldRow(append(totals, durationCell(grandTotal))...))
+	for range len(projects) + 1 {
+		s.widths = append(s.widths, 14)
+	}
+	return s
+}
+
+// zipEntry is a file written into a spreadsheet package.
+type zipEntry struct {
+	name    string
+	content string
+	store   bool // write uncompressed, required for the ODS mimetype
+}
+
+func writeZip(entries []zipEntry, modified time.Time) ([]byte, error) {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: modified}
+		if entry.store {
+			header.Method = zip.Store
+		}
+		f, err := w.CreateHeader(header)
+		if err != nil {
+			return nil, errors.Wrapf(err, "create %s", entry.name)
+		}
+		if _, err = f.Write([]byte(entry.content)); err != nil {
+			return nil, errors.Wrapf(err, "write %s", entry.name)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "close archive")
+	}
+	return b.Bytes(), nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
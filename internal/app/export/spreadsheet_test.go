@@ -0,0 +1,102 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func unzipFiles(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, openErr := f.Open()
+		require.NoError(t, openErr)
+		data, readErr := io.ReadAll(rc)
+		require.NoError(t, readErr)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(data)
+		if strings.HasSuffix(f.Name, ".xml") || strings.HasSuffix(f.Name, ".rels") {
+			require.NoError(t, xml.Unmarshal(data, new(any)), "%s is well-formed XML", f.Name)
+		}
+	}
+	return files
+}
+
+func TestRenderOutputXLSX(t *testing.T) {
+	now := time.Date(2026, time.March, 5, 11, 0, 0, 0, time.Local)
+	content, err := exportapp.RenderOutput("xlsx", timelineReport(), timeutil.NewFormatter("24"), exportapp.WithNow(now))
+	require.NoError(t, err)
+
+	files := unzipFiles(t, content)
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		assert.Contains(t, files, name)
+	}
+	workbook := files["xl/workbook.xml"]
+	assert.Contains(t, workbook, `<sheet name="Summary" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, workbook, `<sheet name="Activities"`)
+	assert.Contains(t, workbook, `<sheet name="Daily"`)
+	assert.Contains(t, files["xl/styles.xml"], `formatCode="[h]:mm"`)
+
+	summary := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, summary, `<t xml:space="preserve">ops</t>`)
+	assert.Contains(t, summary, `<c r="D3" s="6"><v>3</v></c>`, "tock hours include the running activity")
+	assert.Contains(t, summary, `<c r="C4" s="5"><v>0.14583333333333334</v></c>`, "bold total duration of 3h 30m")
+
+	activities := files["xl/worksheets/sheet2.xml"]
+	assert.Contains(t, activities, `<c r="B2" s="3"><v>46083.375</v></c>`, "start is a date serial")
+	assert.Contains(t, activities, `<c r="G2" s="4"><v>0.08333333333333333</v></c>`, "duration is a fraction of a day")
+	assert.Contains(t, activities, `timeline &lt;svg&gt;`)
+	assert.Contains(t, activities, `lanes &amp; bars`)
+	assert.NotContains(t, activities, `r="F4"`, "running activity has no end")
+
+	daily := files["xl/worksheets/sheet3.xml"]
+	assert.Contains(t, daily, `<t xml:space="preserve">Date</t>`)
+	assert.Contains(t, daily, `<c r="A2" s="3"><v>46083</v></c>`)
+	assert.Equal(t, 5, strings.Count(daily, "<row "), "header, three active days and totals")
+}
+
+func TestRenderOutputODS(t *testing.T) {
+	now := time.Date(2026, time.March, 5, 11, 0, 0, 0, time.Local)
+	content, err := exportapp.RenderOutput("ods", timelineReport(), timeutil.NewFormatter("24"), exportapp.WithNow(now))
+	require.NoError(t, err)
+
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "mimetype", r.File[0].Name)
+	assert.Equal(t, zip.Store, r.File[0].Method)
+
+	files := unzipFiles(t, content)
+	assert.Equal(t, "application/vnd.oasis.opendocument.spreadsheet", files["mimetype"])
+	assert.Contains(t, files["META-INF/manifest.xml"], `manifest:full-path="content.xml"`)
+
+	body := files["content.xml"]
+	assert.Equal(t, 3, strings.Count(body, "<table:table "))
+	assert.Contains(t, body, `office:value-type="date" office:date-value="2026-03-02T09:00:00"`)
+	assert.Contains(t, body, `office:value-type="time" office:time-value="PT02H00M00S"`)
+	assert.Contains(t, body, `office:time-value="PT03H30M00S"`, "grand total")
+	assert.Contains(t, body, `<text:p>lanes &amp; bars</text:p>`)
+}
+
+func TestRenderOutputSpreadsheetEmptyReport(t *testing.T) {
+	for _, format := range []string{"xlsx", "ods"} {
+		content, err := exportapp.RenderOutput(format, &models.Report{}, timeutil.NewFormatter("24"))
+		require.NoError(t, err, format)
+		unzipFiles(t, content)
+	}
+}
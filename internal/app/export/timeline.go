@@ -0,0 +1,373 @@
+package export
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+const (
+	timelineLabelWidth = 180
+	timelineChartWidth = 960
+	timelineLaneHeight = 28
+	timelineBarHeight  = 18
+	timelineHeader     = 56
+	timelineFooter     = 24
+	timelineMinBar     = 2
+)
+
+// timelineFallbackPalette colors projects without a configured tag color. The
+// values are ANSI-256 indexes so the timeline matches the terminal views.
+var timelineFallbackPalette = []string{"63", "168", "35", "214", "81", "141", "203", "108", "179", "75"}
+
+// timelineLane is one row of the timeline: a project and its activities.
+type timelineLane struct {
+	project    string
+	activities []models.Activity
+	total      time.Duration
+}
+
+// timeline is the laid out chart shared by the SVG and HTML renderers.
+type timeline struct {
+	from, to  time.Time
+	lanes     []timelineLane
+	tagColors map[string]models.TagColor
+	tf        *timeutil.Formatter
+	now       time.Time
+}
+
+// RenderTimelineSVG draws a Gantt-style chart with one lane per project. Each
+// bar carries a <title>, which browsers and most viewers show on hover. The
+// html report embeds the same chart.
+func RenderTimelineSVG(report *models.Report, tf *timeutil.Formatter, opts ...Option) []byte {
+	o := newRenderOptions(opts)
+	t := newTimeline(report.Activities, tf, o)
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	t.writeSVG(&b)
+	return []byte(b.String())
+}
+
+// RenderTimelineHTML wraps the timeline SVG in a self-contained page with
+// richer hover tooltips and a per-project summary.
+func RenderTimelineHTML(report *models.Report, tf *timeutil.Formatter, opts ...Option) []byte {
+	o := newRenderOptions(opts)
+	t := newTimeline(report.Activities, tf, o)
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(t.title()))
+	b.WriteString(timelineHTMLStyle)
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(t.title()))
+	t.writeSVG(&b)
+	t.writeSummaryTable(&b)
+	b.WriteString("<div id=\"tip\"></div>\n")
+	b.WriteString(timelineHTMLScript)
+	b.WriteString("</body>\n</html>\n")
+	return []byte(b.String())
+}
+
+// RenderTimeline renders the project timeline alone, as svg or as a
+// self-contained html page.
+func RenderTimeline(format string, report *models.Report, tf *timeutil.Formatter, opts ...Option) ([]byte, error) {
+	switch format {
+	case "svg":
+		return RenderTimelineSVG(report, tf, opts...), nil
+	case "html":
+		return RenderTimelineHTML(report, tf, opts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported timeline format: %s (use svg or html)", format)
+	}
+}
+
+func newTimeline(activities []models.Activity, tf *timeutil.Formatter, o renderOptions) timeline {
+	t := timeline{tagColors: o.tagColors, tf: tf, now: o.now}
+	byProject := make(map[string]*timelineLane)
+
+	for _, act := range models.SortActivitiesByStart(activities) {
+		end := t.end(act)
+		if t.from.IsZero() || act.StartTime.Before(t.from) {
+			t.from = act.StartTime
+		}
+		if end.After(t.to) {
+			t.to = end
+		}
+
+		lane, ok := byProject[act.Project]
+		if !ok {
+			lane = &timelineLane{project: act.Project}
+			byProject[act.Project] = lane
+		}
+		lane.activities = append(lane.activities, act)
+		lane.total += end.Sub(act.StartTime)
+	}
+
+	for _, lane := range byProject {
+		t.lanes = append(t.lanes, *lane)
+	}
+	sort.Slice(t.lanes, func(i, j int) bool { return t.lanes[i].project < t.lanes[j].project })
+
+	if !t.from.IsZero() {
+		t.from = time.Date(t.from.Year(), t.from.Month(), t.from.Day(), 0, 0, 0, 0, t.from.Location())
+		t.to = time.Date(t.to.Year(), t.to.Month(), t.to.Day(), 0, 0, 0, 0, t.to.Location()).AddDate(0, 0, 1)
+	}
+	return t
+}
+
+func (t timeline) end(act models.Activity) time.Time {
+	if act.EndTime != nil {
+		return *act.EndTime
+	}
+	return t.now
+}
+
+func (t timeline) title() string {
+	if len(t.lanes) == 0 {
+		return "Tock timeline"
+	}
+	return fmt.Sprintf("Tock timeline: %s – %s", t.from.Format(time.DateOnly), t.to.AddDate(0, 0, -1).Format(time.DateOnly))
+}
+
+func (t timeline) x(at time.Time) float64 {
+	span := t.to.Sub(t.from)
+	if span <= 0 {
+		return timelineLabelWidth
+	}
+	return timelineLabelWidth + float64(at.Sub(t.from))/float64(span)*timelineChartWidth
+}
+
+func (t timeline) writeSVG(b *strings.Builder) {
+	width := timelineLabelWidth + timelineChartWidth + 20
+	height := timelineHeader + len(t.lanes)*timelineLaneHeight + timelineFooter
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" `+
+		`font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+	fmt.Fprintf(b, `<text x="10" y="20" font-size="15" font-weight="bold">%s</text>`+"\n", html.EscapeString(t.title()))
+
+	if len(t.lanes) == 0 {
+		fmt.Fprintf(b, `<text x="10" y="%d" fill="#666666">No activities found for the specified period.</text>`+"\n",
+			timelineHeader)
+		b.WriteString("</svg>\n")
+		return
+	}
+
+	t.writeAxis(b, height)
+	for i, lane := range t.lanes {
+		t.writeLane(b, i, lane)
+	}
+	b.WriteString("</svg>\n")
+}
+
+// writeAxis draws vertical grid lines with date labels. The tick step grows
+// with the covered range so labels stay readable.
+func (t timeline) writeAxis(b *strings.Builder, height int) {
+	step, layout := timelineTickStep(t.to.Sub(t.from))
+	tick := t.from
+	if step == 0 {
+		tick = time.Date(tick.Year(), tick.Month(), 1, 0, 0, 0, 0, tick.Location())
+	}
+
+	b.WriteString(`<g class="axis" stroke="#e0e0e0">` + "\n")
+	var labels strings.Builder
+	for ; tick.Before(t.to); tick = nextTick(tick, step) {
+		if tick.Before(t.from) {
+			continue
+		}
+		x := t.x(tick)
+		fmt.Fprintf(b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d"/>`+"\n", x, timelineHeader-12, x, height-timelineFooter)
+		fmt.Fprintf(&labels, `<text x="%.1f" y="%d" fill="#666666">%s</text>`+"\n", x+3, timelineHeader-16, tick.Format(layout))
+	}
+	b.WriteString("</g>\n")
+	b.WriteString(labels.String())
+}
+
+// timelineTickStep returns the distance between grid lines in days, or 0 for
+// monthly ticks, and the matching label layout.
+func timelineTickStep(span time.Duration) (int, string) {
+	days := int(span.Hours() / 24)
+	switch {
+	case days <= 14:
+		return 1, "Jan 2"
+	case days <= 120:
+		return 7, "Jan 2"
+	default:
+		return 0, "Jan 2006"
+	}
+}
+
+func nextTick(tick time.Time, step int) time.Time {
+	if step == 0 {
+		return tick.AddDate(0, 1, 0)
+	}
+	return tick.AddDate(0, 0, step)
+}
+
+func (t timeline) writeLane(b *strings.Builder, index int, lane timelineLane) {
+	y := timelineHeader + index*timelineLaneHeight
+	if index%2 == 1 {
+		fmt.Fprintf(b, `<rect x="0" y="%d" width="%d" height="%d" fill="#f6f6f6"/>`+"\n",
+			y, timelineLabelWidth+timelineChartWidth+20, timelineLaneHeight)
+	}
+	fmt.Fprintf(b, `<text x="10" y="%d">%s <tspan fill="#888888">%s</tspan></text>`+"\n",
+		y+timelineLaneHeight/2+4, html.EscapeString(lane.project), formatTimelineDuration(lane.total))
+
+	barY := y + (timelineLaneHeight-timelineBarHeight)/2
+	for _, act := range lane.activities {
+		x := t.x(act.StartTime)
+		width := max(t.x(t.end(act))-x, timelineMinBar)
+		tip := html.EscapeString(t.tooltip(act))
+		fmt.Fprintf(b, `<rect class="bar" x="%.1f" y="%d" width="%.1f" height="%d" rx="3" fill="%s" data-tip="%s">`+
+			`<title>%s</title></rect>`+"\n",
+			x, barY, width, timelineBarHeight, t.color(act), tip, tip)
+	}
+}
+
+func (t timeline) tooltip(act models.Activity) string {
+	layout := "2006-01-02 " + t.tf.GetDisplayFormat()
+	end := "running"
+	if act.EndTime != nil {
+		end = act.EndTime.Format(layout)
+	}
+
+	lines := []string{
+		act.Project + ": " + act.Description,
+		fmt.Sprintf("%s – %s (%s)", act.StartTime.Format(layout), end, formatTimelineDuration(t.end(act).Sub(act.StartTime))),
+	}
+	if len(act.Tags) > 0 {
+		lines = append(lines, "#"+strings.Join(act.Tags, " #"))
+	}
+	if act.Notes != "" {
+		lines = append(lines, "", act.Notes)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// color returns the bar color: the first tag with a configured color wins,
+// then a color configured for the project name, then a stable fallback.
+func (t timeline) color(act models.Activity) string {
+	for _, tag := range act.Tags {
+		if c, ok := t.tagColors[tag]; ok {
+			if hex, valid := tagColorHex(c); valid {
+				return hex
+			}
+		}
+	}
+	if c, ok := t.tagColors[act.Project]; ok {
+		if hex, valid := tagColorHex(c); valid {
+			return hex
+		}
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(act.Project))
+	hex, _ := ansiToHex(timelineFallbackPalette[h.Sum32()%uint32(len(timelineFallbackPalette))])
+	return hex
+}
+
+func (t timeline) writeSummaryTable(b *strings.Builder) {
+	if len(t.lanes) == 0 {
+		return
+	}
+	var total time.Duration
+	b.WriteString("<table>\n<tr><th>Project</th><th>Activities</th><th>Duration</th></tr>\n")
+	for _, lane := range t.lanes {
+		total += lane.total
+		fmt.Fprintf(b, "<tr><td>%s</td><td>%d</td><td>%s</td></tr>\n",
+			html.EscapeString(lane.project), len(lane.activities), formatTimelineDuration(lane.total))
+	}
+	fmt.Fprintf(b, "<tr class=\"total\"><td>Total</td><td></td><td>%s</td></tr>\n</table>\n", formatTimelineDuration(total))
+}
+
+// tagColorHex prefers the background color, which is what terminals paint
+// behind the tag, and falls back to the foreground.
+func tagColorHex(c models.TagColor) (string, bool) {
+	if hex, ok := ansiToHex(c.BG); ok {
+		return hex, true
+	}
+	return ansiToHex(c.FG)
+}
+
+// ansiToHex converts an ANSI-256 color index to a CSS hex color. Values that
+// are already hex colors are returned unchanged.
+func ansiToHex(color string) (string, bool) {
+	color = strings.TrimSpace(color)
+	if strings.HasPrefix(color, "#") && (len(color) == 4 || len(color) == 7) {
+		return color, true
+	}
+	index, err := strconv.Atoi(color)
+	if err != nil || index < 0 || index > 255 {
+		return "", false
+	}
+
+	var r, g, b int
+	switch {
+	case index < 16:
+		rgb := ansiBaseColors[index]
+		r, g, b = rgb[0], rgb[1], rgb[2]
+	case index < 232:
+		index -= 16
+		r, g, b = ansiCubeLevel(index/36), ansiCubeLevel(index/6%6), ansiCubeLevel(index%6)
+	default:
+		r = 8 + (index-232)*10
+		g, b = r, r
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b), true
+}
+
+func ansiCubeLevel(v int) int {
+	if v == 0 {
+		return 0
+	}
+	return 55 + v*40
+}
+
+// ansiBaseColors are the xterm defaults for the 16 standard colors.
+var ansiBaseColors = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+func formatTimelineDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+const timelineHTMLStyle = `<style>
+body { font-family: sans-serif; margin: 24px; color: #222; }
+h1 { font-size: 20px; }
+svg { max-width: 100%; height: auto; }
+.bar { cursor: pointer; }
+.bar:hover { opacity: 0.8; stroke: #222; }
+table { border-collapse: collapse; margin-top: 16px; }
+th, td { padding: 4px 12px; text-align: left; border-bottom: 1px solid #e0e0e0; }
+tr.total td { font-weight: bold; }
+#tip { position: fixed; display: none; max-width: 360px; padding: 8px 10px; background: #222; color: #fff;
+  border-radius: 4px; font-size: 12px; white-space: pre-wrap; pointer-events: none; }
+</style>
+`
+
+const timelineHTMLScript = `<script>
+(function () {
+  var tip = document.getElementById("tip");
+  document.querySelectorAll(".bar").forEach(function (bar) {
+    var title = bar.querySelector("title");
+    if (title) { title.remove(); }
+    bar.addEventListener("mousemove", function (e) {
+      tip.textContent = bar.getAttribute("data-tip");
+      tip.style.left = (e.clientX + 12) + "px";
+      tip.style.top = (e.clientY + 12) + "px";
+      tip.style.display = "block";
+    });
+    bar.addEventListener("mouseleave", function () { tip.style.display = "none"; });
+  });
+})();
+</script>
+`
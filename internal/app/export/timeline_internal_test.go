@@ -0,0 +1,42 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsiToHex(t *testing.T) {
+	cases := map[string]string{
+		"1":       "#cd0000",
+		"15":      "#ffffff",
+		"16":      "#000000",
+		"196":     "#ff0000",
+		"33":      "#0087ff",
+		"232":     "#080808",
+		"255":     "#eeeeee",
+		"#abc":    "#abc",
+		"#12ab34": "#12ab34",
+	}
+	for in, want := range cases {
+		got, ok := ansiToHex(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "red", "256", "-1", "#12"} {
+		_, ok := ansiToHex(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestTimelineTickStep(t *testing.T) {
+	step, _ := timelineTickStep(7 * 24 * time.Hour)
+	assert.Equal(t, 1, step)
+	step, _ = timelineTickStep(60 * 24 * time.Hour)
+	assert.Equal(t, 7, step)
+	step, layout := timelineTickStep(365 * 24 * time.Hour)
+	assert.Equal(t, 0, step)
+	assert.Equal(t, "Jan 2006", layout)
+}
@@ -0,0 +1,76 @@
+package export_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportapp "github.com/kriuchkov/tock/internal/app/export"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+func timelineReport() *models.Report {
+	start1 := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.Local)
+	end1 := start1.Add(2 * time.Hour)
+	start2 := time.Date(2026, time.March, 4, 13, 0, 0, 0, time.Local)
+	end2 := start2.Add(30 * time.Minute)
+	start3 := time.Date(2026, time.March, 5, 10, 0, 0, 0, time.Local)
+
+	return &models.Report{Activities: []models.Activity{
+		{Project: "tock", Description: "timeline <svg>", StartTime: start1, EndTime: &end1, Tags: []string{"dev"}, Notes: "lanes & bars"},
+		{Project: "ops", Description: "deploy", StartTime: start2, EndTime: &end2},
+		{Project: "tock", Description: "review", StartTime: start3},
+	}}
+}
+
+func TestRenderOutputTimelineSVG(t *testing.T) {
+	now := time.Date(2026, time.March, 5, 11, 0, 0, 0, time.Local)
+	content, err := exportapp.RenderOutput("svg", timelineReport(), timeutil.NewFormatter("24"),
+		exportapp.WithTagColors(map[string]models.TagColor{"dev": {FG: "15", BG: "196"}, "ops": {FG: "#00aa00"}}),
+		exportapp.WithNow(now),
+	)
+	require.NoError(t, err)
+
+	svg := string(content)
+	require.NoError(t, xml.Unmarshal(content, new(any)), "output is well-formed XML")
+	assert.Contains(t, svg, "Tock timeline: 2026-03-02 – 2026-03-05")
+	assert.Equal(t, 3, strings.Count(svg, `class="bar"`))
+	assert.Contains(t, svg, `fill="#ff0000"`, "tag background color wins")
+	assert.Contains(t, svg, `fill="#00aa00"`, "project color is used without a tag color")
+	assert.Contains(t, svg, "tock: timeline &lt;svg&gt;")
+	assert.Contains(t, svg, "lanes &amp; bars")
+	assert.Contains(t, svg, "2026-03-05 10:00 – running (1h 0m)")
+
+	assert.Less(t, strings.Index(svg, ">ops "), strings.Index(svg, ">tock "), "lanes are sorted by project")
+	assert.Contains(t, svg, ">tock <tspan fill=\"#888888\">3h 0m</tspan>")
+}
+
+func TestRenderTimelineHTMLIsSelfContained(t *testing.T) {
+	content, err := exportapp.RenderTimeline("html", timelineReport(), timeutil.NewFormatter("24"))
+	require.NoError(t, err)
+
+	page := string(content)
+	assert.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	assert.Contains(t, page, "<svg")
+	assert.Contains(t, page, `id="tip"`)
+	assert.Contains(t, page, "<td>ops</td><td>1</td><td>0h 30m</td>")
+	assert.NotContains(t, page, "src=\"http")
+	assert.NotContains(t, page, "href=\"http")
+
+	_, err = exportapp.RenderTimeline("md", timelineReport(), timeutil.NewFormatter("24"))
+	require.ErrorContains(t, err, "use svg or html")
+}
+
+func TestRenderOutputTimelineWithoutActivities(t *testing.T) {
+	content, err := exportapp.RenderOutput("svg", &models.Report{}, timeutil.NewFormatter("24"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "No activities found for the specified period.")
+
+	_, err = exportapp.RenderOutput("pdf", &models.Report{}, timeutil.NewFormatter("24"))
+	require.ErrorContains(t, err, "svg, xlsx, or ods")
+}
@@ -0,0 +1,185 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const (
+	xlsxMainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNS  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPkgNS  = "http://schemas.openxmlformats.org/package/2006/relationships"
+	xmlHeader  = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+)
+
+// Indexes into cellXfs of xlsxStyles.
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleBold
+	xlsxStyleDateTime
+	xlsxStyleDate
+	xlsxStyleDuration
+	xlsxStyleBoldDuration
+	xlsxStyleNumber
+	xlsxStyleBoldNumber
+)
+
+// xlsxEpoch is day zero of the 1900 date system as Excel counts it.
+var xlsxEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// RenderXLSX writes the report as an Office Open XML workbook with Summary,
+// Activities and Daily sheets. Times are stored as local wall-clock dates and
+// durations as fractions of a day formatted [h]:mm.
+func RenderXLSX(report *models.Report, opts ...Option) ([]byte, error) {
+	o := newRenderOptions(opts)
+	sheets := buildSpreadsheet(report, o.now)
+
+	entries := []zipEntry{
+		{name: "[Content_Types].xml", content: xlsxContentTypes(len(sheets))},
+		{name: "_rels/.rels", content: xmlHeader + `<Relationships xmlns="` + xlsxPkgNS + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelNS + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{name: "xl/workbook.xml", content: xlsxWorkbook(sheets)},
+		{name: "xl/_rels/workbook.xml.rels", content: xlsxWorkbookRels(len(sheets))},
+		{name: "xl/styles.xml", content: xlsxStyles},
+	}
+	for i, s := range sheets {
+		entries = append(entries, zipEntry{name: fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), content: xlsxSheet(s)})
+	}
+	return writeZip(entries, o.now)
+}
+
+func xlsxContentTypes(sheetCount int) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" `+
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func xlsxWorkbook(sheets []sheet) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<workbook xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `"><sheets>`)
+	for i, s := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(s.name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func xlsxWorkbookRels(sheetCount int) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<Relationships xmlns="` + xlsxPkgNS + `">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i, xlsxRelNS, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, sheetCount+1, xlsxRelNS)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func xlsxSheet(s sheet) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<worksheet xmlns="` + xlsxMainNS + `">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, w := range s.widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, w)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			writeXLSXCell(&b, xlsxColumn(c)+strconv.Itoa(r+1), cell)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+func writeXLSXCell(b *strings.Builder, ref string, cell sheetCell) {
+	switch cell.kind {
+	case cellEmpty:
+		return
+	case cellString:
+		style := xlsxStyleDefault
+		if cell.bold {
+			style = xlsxStyleBold
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(cell.text))
+	case cellNumber:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, pickStyle(cell.bold, xlsxStyleNumber, xlsxStyleBoldNumber),
+			strconv.FormatFloat(cell.number, 'f', -1, 64))
+	case cellDateTime:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDateTime, xlsxSerial(cell.time))
+	case cellDate:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDate, xlsxSerial(cell.time))
+	case cellDuration:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, pickStyle(cell.bold, xlsxStyleDuration, xlsxStyleBoldDuration),
+			strconv.FormatFloat(cell.duration.Round(time.Second).Hours()/24, 'f', -1, 64))
+	}
+}
+
+func pickStyle(bold bool, regular, emphasized int) int {
+	if bold {
+		return emphasized
+	}
+	return regular
+}
+
+// xlsxSerial converts the wall-clock time of t to an Excel date serial.
+func xlsxSerial(t time.Time) string {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return strconv.FormatFloat(wall.Sub(xlsxEpoch).Hours()/24, 'f', -1, 64)
+}
+
+// xlsxColumn returns the column letters for a zero-based index: A, B, ..., Z, AA.
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+const xlsxStyles = xmlHeader + `<styleSheet xmlns="` + xlsxMainNS + `">` +
+	`<numFmts count="3">` +
+	`<numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm"/>` +
+	`<numFmt numFmtId="165" formatCode="yyyy-mm-dd"/>` +
+	`<numFmt numFmtId="166" formatCode="[h]:mm"/>` +
+	`</numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="8">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="166" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="166" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+	`<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="2" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
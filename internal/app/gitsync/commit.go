@@ -0,0 +1,43 @@
+package gitsync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// Committer commits the data file after every change made through the
+// activity service.
+type Committer struct {
+	repo *Repo
+}
+
+// NewCommitter returns a change listener that commits to repo.
+func NewCommitter(repo *Repo) *Committer {
+	return &Committer{repo: repo}
+}
+
+// ActivityChanged implements ports.ActivityChangeListener.
+func (c *Committer) ActivityChanged(ctx context.Context, changes []models.ActivityChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return c.repo.Commit(ctx, ChangeMessage(changes))
+}
+
+// ChangeMessage describes changes as a commit message such as
+// "start api: fix tests". Further changes of the same operation, like the
+// activity a start stopped, are listed in the body.
+func ChangeMessage(changes []models.ActivityChange) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		act := change.Subject()
+		lines[i] = fmt.Sprintf("%s %s: %s", change.Op, act.Project, act.Description)
+	}
+	if len(lines) == 1 {
+		return lines[0]
+	}
+	return lines[0] + "\n\n" + strings.Join(lines[1:], "\n")
+}
@@ -0,0 +1,165 @@
+package gitsync
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// ParseFunc parses one line of a data file; it returns nil for lines that
+// are not activities.
+type ParseFunc func(line string) (*models.Activity, error)
+
+// ConflictPrefix starts every line the merge driver writes to warn about a
+// conflict, so Sync can pick them out of git's output.
+const ConflictPrefix = "tock merge conflict: "
+
+// MergeFiles is the git merge driver: it merges the three versions of the
+// data file and writes the result to ours, as git expects. Conflicts are
+// written to warn, one per line.
+func MergeFiles(base, ours, theirs string, parse ParseFunc, warn io.Writer) error {
+	contents := make([][]byte, 3)
+	for i, path := range []string{base, ours, theirs} {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrapf(err, "read %s", path)
+		}
+		contents[i] = data
+	}
+
+	merged, conflicts := MergeLines(contents[0], contents[1], contents[2], parse)
+	if err := os.WriteFile(ours, merged, 0600); err != nil {
+		return errors.Wrapf(err, "write %s", ours)
+	}
+	for _, conflict := range conflicts {
+		fmt.Fprintln(warn, ConflictPrefix+conflict)
+	}
+	return nil
+}
+
+// MergeLines merges two versions of a data file that both derive from base.
+// Activities are matched by start time, so entries appended on two machines
+// are simply combined. An activity changed on one side only takes that
+// change, including removal. Two different activities added at the same start
+// are both kept. When both sides changed an activity differently, the version
+// that ends later wins, which prefers a stopped activity over the same one
+// still running. Both cases are returned as conflicts to show the user.
+// Activities are written in start order; lines that are not activities are
+// kept after them.
+func MergeLines(base, ours, theirs []byte, parse ParseFunc) ([]byte, []string) {
+	baseLines := indexLines(base, parse)
+	ourLines := indexLines(ours, parse)
+	theirLines := indexLines(theirs, parse)
+
+	keys := make(map[string]bool)
+	for _, index := range []lineIndex{ourLines, theirLines} {
+		for key := range index.lines {
+			keys[key] = true
+		}
+	}
+
+	var (
+		entries   []lineEntry
+		conflicts []string
+	)
+	for key := range keys {
+		b, o, t := baseLines.lines[key], ourLines.lines[key], theirLines.lines[key]
+		var chosen lineEntry
+		switch {
+		case o.text == t.text:
+			chosen = o
+		case o.text == b.text:
+			chosen = t
+		case t.text == b.text:
+			chosen = o
+		case o.text == "" || t.text == "":
+			// Removed on one side and changed on the other: keep the change.
+			chosen = o
+			if o.text == "" {
+				chosen = t
+			}
+		case b.text == "":
+			entries = append(entries, o, t)
+			conflicts = append(conflicts, fmt.Sprintf("%s: both sides added an activity, kept both: %q and %q",
+				o.start.Format(conflictTimeLayout), o.text, t.text))
+			continue
+		default:
+			chosen, dropped := o, t
+			if t.end.After(o.end) {
+				chosen, dropped = t, o
+			}
+			entries = append(entries, chosen)
+			conflicts = append(conflicts, fmt.Sprintf("%s: both sides changed the activity, kept %q over %q",
+				o.start.Format(conflictTimeLayout), chosen.text, dropped.text))
+			continue
+		}
+		if chosen.text != "" {
+			entries = append(entries, chosen)
+		}
+	}
+	sort.Strings(conflicts)
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].start.Equal(entries[j].start) {
+			return entries[i].start.Before(entries[j].start)
+		}
+		return entries[i].text < entries[j].text
+	})
+
+	var b bytes.Buffer
+	for _, entry := range entries {
+		b.WriteString(entry.text)
+		b.WriteByte('\n')
+	}
+	seen := make(map[string]bool)
+	for _, raw := range append(ourLines.raw, theirLines.raw...) {
+		if !seen[raw] {
+			seen[raw] = true
+			b.WriteString(raw)
+			b.WriteByte('\n')
+		}
+	}
+	return b.Bytes(), conflicts
+}
+
+const conflictTimeLayout = "2006-01-02 15:04"
+
+type lineEntry struct {
+	text  string
+	start time.Time
+	end   time.Time // far future while running
+}
+
+type lineIndex struct {
+	lines map[string]lineEntry // by start time
+	raw   []string             // lines that are not activities
+}
+
+func indexLines(data []byte, parse ParseFunc) lineIndex {
+	index := lineIndex{lines: make(map[string]lineEntry)}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		act, err := parse(line)
+		if err != nil || act == nil {
+			index.raw = append(index.raw, line)
+			continue
+		}
+		entry := lineEntry{text: line, start: act.StartTime, end: time.Unix(1<<40, 0)}
+		if act.EndTime != nil {
+			entry.end = *act.EndTime
+		}
+		index.lines[act.StartTime.UTC().Format(time.RFC3339Nano)] = entry
+	}
+	return index
+}
@@ -0,0 +1,68 @@
+package gitsync_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
+	"github.com/kriuchkov/tock/internal/app/gitsync"
+)
+
+func TestMergeLinesCombinesAppends(t *testing.T) {
+	base := "2026-03-14 09:00 - 2026-03-14 10:00 | core | review\n"
+	ours := base + "2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop\n"
+	theirs := base + "2026-03-14 10:30 - 2026-03-14 11:00 | ops | desktop\n"
+
+	merged, conflicts := gitsync.MergeLines([]byte(base), []byte(ours), []byte(theirs), file.ParseActivity)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "2026-03-14 09:00 - 2026-03-14 10:00 | core | review\n"+
+		"2026-03-14 10:30 - 2026-03-14 11:00 | ops | desktop\n"+
+		"2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop\n", string(merged))
+}
+
+func TestMergeLinesTakesOneSidedChangesAndRemovals(t *testing.T) {
+	base := "2026-03-14 09:00 | core | running\n2026-03-14 08:00 - 2026-03-14 08:30 | core | standup\n"
+	ours := "2026-03-14 09:00 - 2026-03-14 10:00 | core | running\n2026-03-14 08:00 - 2026-03-14 08:30 | core | standup\n"
+	theirs := "2026-03-14 09:00 | core | running\n"
+
+	merged, conflicts := gitsync.MergeLines([]byte(base), []byte(ours), []byte(theirs), file.ParseActivity)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "2026-03-14 09:00 - 2026-03-14 10:00 | core | running\n", string(merged))
+}
+
+func TestMergeLinesKeepsBothSameStartAdditions(t *testing.T) {
+	base := "2026-03-14 09:00 - 2026-03-14 10:00 | core | review\n"
+	ours := base + "2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop\n"
+	theirs := base + "2026-03-14 11:00 - 2026-03-14 11:30 | ops | desktop\n"
+
+	merged, conflicts := gitsync.MergeLines([]byte(base), []byte(ours), []byte(theirs), file.ParseActivity)
+	assert.Equal(t, base+
+		"2026-03-14 11:00 - 2026-03-14 11:30 | ops | desktop\n"+
+		"2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop\n", string(merged))
+	require.Len(t, conflicts, 1)
+	assert.Contains(t, conflicts[0], "2026-03-14 11:00: both sides added an activity, kept both")
+}
+
+func TestMergeLinesPrefersTheVersionThatEndsLater(t *testing.T) {
+	base := "2026-03-14 09:00 | core | running\n"
+	ours := "2026-03-14 09:00 - 2026-03-14 10:00 | core | running\n"
+	theirs := "2026-03-14 09:00 - 2026-03-14 11:00 | core | running\n"
+
+	merged, conflicts := gitsync.MergeLines([]byte(base), []byte(ours), []byte(theirs), file.ParseActivity)
+	assert.Equal(t, theirs, string(merged))
+	require.Len(t, conflicts, 1)
+	assert.Contains(t, conflicts[0], "2026-03-14 09:00: both sides changed the activity")
+
+	merged, _ = gitsync.MergeLines([]byte(base), []byte(theirs), []byte(ours), file.ParseActivity)
+	assert.Equal(t, theirs, string(merged))
+}
+
+func TestMergeLinesKeepsOtherLines(t *testing.T) {
+	ours := "# laptop\n2026-03-14 09:00 - 2026-03-14 10:00 | core | review\n"
+	theirs := "# desktop\n"
+
+	merged, _ := gitsync.MergeLines(nil, []byte(ours), []byte(theirs), file.ParseActivity)
+	assert.Equal(t, "2026-03-14 09:00 - 2026-03-14 10:00 | core | review\n# laptop\n# desktop\n", string(merged))
+}
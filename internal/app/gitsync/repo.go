@@ -0,0 +1,135 @@
+// Package gitsync keeps the plaintext data file and notes under version
+// control: every change is committed, and Sync pulls and pushes them.
+package gitsync
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// Repo runs git in the directory of a data file and only ever stages the data
+// file, the notes directory and .gitattributes.
+type Repo struct {
+	dir      string
+	dataFile string   // relative to dir
+	paths    []string // relative to dir
+}
+
+// NewRepo returns the repository for dataFile. notesDir is tracked too when
+// it lives below the directory of the data file.
+func NewRepo(dataFile, notesDir string) *Repo {
+	dir := filepath.Dir(dataFile)
+	r := &Repo{dir: dir, dataFile: filepath.Base(dataFile)}
+	r.paths = []string{r.dataFile, attributesFile}
+	if rel, err := filepath.Rel(dir, notesDir); err == nil && notesDir != "" && !strings.HasPrefix(rel, "..") {
+		r.paths = append(r.paths, filepath.ToSlash(rel))
+	}
+	return r
+}
+
+// Dir returns the directory git runs in.
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// IsRepository reports whether the directory is the top of a git work tree.
+// A repository further up, such as one for dotfiles in the home directory,
+// does not count: tock must not commit into it.
+func (r *Repo) IsRepository(ctx context.Context) bool {
+	out, err := r.git(ctx, "rev-parse", "--show-toplevel")
+	return err == nil && samePath(out, r.dir)
+}
+
+// samePath compares two directories after resolving symlinks, which git
+// does for --show-toplevel.
+func samePath(a, b string) bool {
+	resolve := func(path string) string {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if real, err := filepath.EvalSymlinks(path); err == nil {
+			path = real
+		}
+		return filepath.Clean(path)
+	}
+	return resolve(a) == resolve(b)
+}
+
+// Commit commits the tracked paths with message. Nothing happens when they
+// did not change.
+func (r *Repo) Commit(ctx context.Context, message string) error {
+	if !r.IsRepository(ctx) {
+		return errors.Errorf("%s is not a git repository (run tock sync init)", r.dir)
+	}
+
+	paths := r.existingPaths(ctx)
+	if len(paths) == 0 {
+		return nil
+	}
+	if _, err := r.git(ctx, append([]string{"add", "-A", "--"}, paths...)...); err != nil {
+		return err
+	}
+	if _, err := r.git(ctx, append([]string{"diff", "--cached", "--quiet", "--"}, paths...)...); err == nil {
+		return nil
+	}
+
+	args := append(r.identity(ctx), "commit", "-q", "-m", message, "--")
+	if _, err := r.git(ctx, append(args, paths...)...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// existingPaths drops paths that neither exist nor are tracked, which git
+// add rejects.
+func (r *Repo) existingPaths(ctx context.Context) []string {
+	var paths []string
+	for _, path := range r.paths {
+		if _, err := os.Stat(filepath.Join(r.dir, path)); err == nil {
+			paths = append(paths, path)
+			continue
+		}
+		if out, err := r.git(ctx, "ls-files", "--", path); err == nil && out != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// identity falls back to a tock author on machines without a git identity,
+// so commits never fail because of it.
+func (r *Repo) identity(ctx context.Context) []string {
+	if out, err := r.git(ctx, "config", "user.email"); err == nil && out != "" {
+		return nil
+	}
+	return []string{"-c", "user.name=tock", "-c", "user.email=tock@localhost"}
+}
+
+func (r *Repo) git(ctx context.Context, args ...string) (string, error) {
+	stdout, _, err := r.gitOutput(ctx, args...)
+	return stdout, err
+}
+
+// gitOutput runs git and also returns its stderr, where merge drivers report.
+func (r *Repo) gitOutput(ctx context.Context, args ...string) (string, string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", "", errors.Wrapf(err, "git %s: %s", args[0], msg)
+		}
+		return "", "", errors.Wrapf(err, "git %s", args[0])
+	}
+	return strings.TrimSpace(stdout.String()), stderr.String(), nil
+}
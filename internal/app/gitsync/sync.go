@@ -0,0 +1,125 @@
+package gitsync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+const (
+	attributesFile = ".gitattributes"
+	mergeDriver    = "tock"
+)
+
+// Init prepares the directory of the data file: it creates the repository
+// when needed, registers driverCommand as the merge driver for the data file
+// and, when remoteURL is set, points the origin remote at it. Every clone
+// needs Init once, because git keeps merge drivers in the local config. It
+// refuses the home directory, where the default data file lives, since a
+// repository there would cover every file below it.
+func (r *Repo) Init(ctx context.Context, driverCommand, remoteURL string) error {
+	if home, err := os.UserHomeDir(); err == nil && samePath(r.dir, home) {
+		return errors.Errorf("refusing to create a git repository in the home directory %s: "+
+			"move the data file into a directory of its own first", r.dir)
+	}
+	if !r.IsRepository(ctx) {
+		if _, err := r.git(ctx, "init", "-q"); err != nil {
+			return err
+		}
+	}
+
+	if err := r.writeAttributes(); err != nil {
+		return err
+	}
+	if _, err := r.git(ctx, "config", "merge."+mergeDriver+".name", "tock activity log"); err != nil {
+		return err
+	}
+	if _, err := r.git(ctx, "config", "merge."+mergeDriver+".driver", driverCommand); err != nil {
+		return err
+	}
+
+	if remoteURL != "" {
+		if _, err := r.git(ctx, "remote", "get-url", "origin"); err == nil {
+			_, err = r.git(ctx, "remote", "set-url", "origin", remoteURL)
+			if err != nil {
+				return err
+			}
+		} else if _, err = r.git(ctx, "remote", "add", "origin", remoteURL); err != nil {
+			return err
+		}
+	}
+
+	return r.Commit(ctx, "track tock data")
+}
+
+// writeAttributes assigns the merge driver to the data file, keeping any
+// other attributes.
+func (r *Repo) writeAttributes() error {
+	path := filepath.Join(r.dir, attributesFile)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "read .gitattributes")
+	}
+
+	line := "/" + r.dataFile + " merge=" + mergeDriver
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if slices.Contains(lines, line) {
+		return nil
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, line+"\n"...)
+	if err = os.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrap(err, "write .gitattributes")
+	}
+	return nil
+}
+
+// Sync commits pending changes, rebases them onto the remote branch and
+// pushes. An empty branch means the current one; a branch the remote does
+// not have yet is created by the push. It returns the conflicts the merge
+// driver resolved on its own, so they can be shown to the user.
+func (r *Repo) Sync(ctx context.Context, remote, branch string) ([]string, error) {
+	if err := r.Commit(ctx, "sync"); err != nil {
+		return nil, err
+	}
+
+	if branch == "" {
+		current, err := r.git(ctx, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if current == "HEAD" {
+			return nil, errors.New("cannot sync a detached HEAD (set git.branch)")
+		}
+		branch = current
+	}
+
+	heads, err := r.git(ctx, "ls-remote", "--heads", remote, "refs/heads/"+branch)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	if heads != "" {
+		_, stderr, pullErr := r.gitOutput(ctx, append(r.identity(ctx), "pull", "-q", "--rebase", remote, branch)...)
+		if pullErr != nil {
+			_, _ = r.git(ctx, "rebase", "--abort")
+			return nil, errors.Wrap(pullErr, "pull")
+		}
+		for line := range strings.SplitSeq(stderr, "\n") {
+			if conflict, ok := strings.CutPrefix(strings.TrimSpace(line), ConflictPrefix); ok {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	if _, err = r.git(ctx, "push", "-q", remote, "HEAD:refs/heads/"+branch); err != nil {
+		return nil, errors.Wrap(err, "push")
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,208 @@
+package gitsync_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
+	"github.com/kriuchkov/tock/internal/app/gitsync"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+const driverEnv = "TOCK_GITSYNC_TEST_MERGE_DRIVER"
+
+// TestMain lets the test binary act as the merge driver that git runs
+// during a rebase, the way `tock sync merge-driver` does in production.
+func TestMain(m *testing.M) {
+	if os.Getenv(driverEnv) == "1" {
+		args := os.Args[len(os.Args)-3:]
+		if err := gitsync.MergeFiles(args[0], args[1], args[2], file.ParseActivity, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func driverCommand(t *testing.T) string {
+	t.Helper()
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	return fmt.Sprintf("%s=1 '%s' %%O %%A %%B", driverEnv, exe)
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func appendLine(t *testing.T, path, line string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(line + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func syncRepo(t *testing.T, repo *gitsync.Repo, branch string) []string {
+	t.Helper()
+	conflicts, err := repo.Sync(context.Background(), "origin", branch)
+	require.NoError(t, err)
+	return conflicts
+}
+
+func TestCommitterCommitsDataFileAndNotes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, ".tock.txt")
+	notesDir := filepath.Join(dir, ".tock", "notes")
+	repo := gitsync.NewRepo(dataFile, notesDir)
+
+	err := gitsync.NewCommitter(repo).ActivityChanged(ctx, []models.ActivityChange{{Op: models.ChangeStart}})
+	require.ErrorContains(t, err, "not a git repository")
+
+	require.NoError(t, repo.Init(ctx, driverCommand(t), ""))
+	assert.Contains(t, runGit(t, dir, "ls-files"), ".gitattributes")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("x"), 0600))
+
+	appendLine(t, dataFile, "2026-03-14 09:00 | api | fix tests")
+	require.NoError(t, os.MkdirAll(filepath.Join(notesDir, "2026-03-14"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(notesDir, "2026-03-14", "090000.txt"), []byte("note"), 0600))
+	act := models.Activity{Project: "api", Description: "fix tests"}
+	err = gitsync.NewCommitter(repo).ActivityChanged(ctx, []models.ActivityChange{{Op: models.ChangeStart, After: &act}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "start api: fix tests", runGit(t, dir, "log", "-1", "--format=%B"))
+	files := runGit(t, dir, "show", "--name-only", "--format=", "HEAD")
+	assert.Contains(t, files, ".tock.txt")
+	assert.Contains(t, files, ".tock/notes/2026-03-14/090000.txt")
+	assert.NotContains(t, runGit(t, dir, "ls-files"), "unrelated.txt")
+
+	// Nothing changed, nothing to commit.
+	require.NoError(t, repo.Commit(ctx, "noop"))
+	assert.Equal(t, "start api: fix tests", runGit(t, dir, "log", "-1", "--format=%s"))
+}
+
+func TestInitKeepsOutOfHomeAndEnclosingRepositories(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	err := gitsync.NewRepo(filepath.Join(home, ".tock.txt"), filepath.Join(home, ".tock", "notes")).Init(ctx, driverCommand(t), "")
+	require.ErrorContains(t, err, "home directory")
+	assert.NoDirExists(t, filepath.Join(home, ".git"))
+
+	// A dotfiles repository above the data file is not tock's repository.
+	runGit(t, home, "init", "-q")
+	dir := filepath.Join(home, "tock")
+	require.NoError(t, os.MkdirAll(dir, 0750))
+	dataFile := filepath.Join(dir, ".tock.txt")
+	appendLine(t, dataFile, "2026-03-14 09:00 | api | fix tests")
+	repo := gitsync.NewRepo(dataFile, filepath.Join(dir, ".tock", "notes"))
+	assert.False(t, repo.IsRepository(ctx))
+	require.ErrorContains(t, repo.Commit(ctx, "sync"), "not a git repository")
+
+	require.NoError(t, repo.Init(ctx, driverCommand(t), ""))
+	assert.True(t, repo.IsRepository(ctx))
+	assert.Contains(t, runGit(t, dir, "ls-files"), ".tock.txt")
+	assert.NotContains(t, runGit(t, home, "status", "--porcelain", "--untracked-files=no"), ".tock.txt")
+}
+
+func TestSyncMergesAppendsFromTwoMachines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, t.TempDir(), "init", "-q", "--bare", remote)
+
+	laptopDir := t.TempDir()
+	laptopFile := filepath.Join(laptopDir, ".tock.txt")
+	laptop := gitsync.NewRepo(laptopFile, filepath.Join(laptopDir, ".tock", "notes"))
+	appendLine(t, laptopFile, "2026-03-14 09:00 - 2026-03-14 10:00 | core | review")
+	require.NoError(t, laptop.Init(ctx, driverCommand(t), remote))
+	syncRepo(t, laptop, "main")
+
+	desktopDir := filepath.Join(t.TempDir(), "desktop")
+	runGit(t, t.TempDir(), "clone", "-q", "--branch", "main", remote, desktopDir)
+	desktopFile := filepath.Join(desktopDir, ".tock.txt")
+	desktop := gitsync.NewRepo(desktopFile, filepath.Join(desktopDir, ".tock", "notes"))
+	require.NoError(t, desktop.Init(ctx, driverCommand(t), ""))
+
+	appendLine(t, laptopFile, "2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop")
+	require.NoError(t, laptop.Commit(ctx, "add core: laptop"))
+	appendLine(t, desktopFile, "2026-03-14 10:30 - 2026-03-14 11:00 | ops | desktop")
+	require.NoError(t, desktop.Commit(ctx, "add ops: desktop"))
+
+	syncRepo(t, desktop, "")
+	syncRepo(t, laptop, "main")
+
+	want := "2026-03-14 09:00 - 2026-03-14 10:00 | core | review\n" +
+		"2026-03-14 10:30 - 2026-03-14 11:00 | ops | desktop\n" +
+		"2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop\n"
+	data, err := os.ReadFile(laptopFile)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(data))
+
+	syncRepo(t, desktop, "")
+	data, err = os.ReadFile(desktopFile)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(data))
+}
+
+func TestSyncKeepsAndReportsSameStartAdditions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, t.TempDir(), "init", "-q", "--bare", remote)
+
+	laptopDir := t.TempDir()
+	laptopFile := filepath.Join(laptopDir, ".tock.txt")
+	laptop := gitsync.NewRepo(laptopFile, filepath.Join(laptopDir, ".tock", "notes"))
+	appendLine(t, laptopFile, "2026-03-14 09:00 - 2026-03-14 10:00 | core | review")
+	require.NoError(t, laptop.Init(ctx, driverCommand(t), remote))
+	syncRepo(t, laptop, "main")
+
+	desktopDir := filepath.Join(t.TempDir(), "desktop")
+	runGit(t, t.TempDir(), "clone", "-q", "--branch", "main", remote, desktopDir)
+	desktopFile := filepath.Join(desktopDir, ".tock.txt")
+	desktop := gitsync.NewRepo(desktopFile, filepath.Join(desktopDir, ".tock", "notes"))
+	require.NoError(t, desktop.Init(ctx, driverCommand(t), ""))
+
+	appendLine(t, laptopFile, "2026-03-14 11:00 - 2026-03-14 12:00 | core | laptop")
+	require.NoError(t, laptop.Commit(ctx, "add core: laptop"))
+	appendLine(t, desktopFile, "2026-03-14 11:00 - 2026-03-14 11:30 | ops | desktop")
+	require.NoError(t, desktop.Commit(ctx, "add ops: desktop"))
+
+	assert.Empty(t, syncRepo(t, desktop, ""))
+	conflicts := syncRepo(t, laptop, "main")
+	require.Len(t, conflicts, 1)
+	assert.Contains(t, conflicts[0], "2026-03-14 11:00: both sides added an activity, kept both")
+
+	data, err := os.ReadFile(laptopFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "| core | laptop\n")
+	assert.Contains(t, string(data), "| ops | desktop\n")
+}
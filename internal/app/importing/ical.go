@@ -0,0 +1,370 @@
+package importing
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// Event is a VEVENT read from an iCalendar file. Recurring events keep their
+// rule; ExpandEvents turns them into single occurrences.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Categories   []string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Status       string // upper-cased, e.g. CONFIRMED or CANCELLED
+	Transparent  bool
+	Attendees    []Attendee
+	RRule        string
+	ExDates      []time.Time
+	RecurrenceID *time.Time // set on an edited occurrence of a recurring event
+
+	duration *time.Duration // DURATION, resolved against DTSTART when the event ends
+}
+
+// Attendee is an ATTENDEE of an event with its participation status.
+type Attendee struct {
+	Email    string // lower-cased, without the mailto: prefix
+	PartStat string // upper-cased, e.g. ACCEPTED or DECLINED
+}
+
+// Declined reports whether the attendee with the given email declined.
+func (e Event) Declined(email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+	for _, attendee := range e.Attendees {
+		if attendee.Email == email {
+			return attendee.PartStat == "DECLINED"
+		}
+	}
+	return false
+}
+
+// property is one unfolded content line: NAME;PARAM=VALUE:VALUE.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICal reads every VEVENT of an iCalendar stream. Nested components
+// such as VALARM are skipped; VTIMEZONE definitions are not needed because
+// TZID parameters are resolved with the system time zone database.
+func ParseICal(r io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	nested := 0
+	for i, line := range lines {
+		prop, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT") && current == nil:
+			current = &Event{}
+			continue
+		case prop.name == "BEGIN" && current != nil:
+			nested++
+			continue
+		case prop.name == "END" && current != nil && nested > 0:
+			nested--
+			continue
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT") && current != nil:
+			if err = finishEvent(current); err != nil {
+				return nil, errors.Wrapf(err, "event %q", current.Summary)
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		}
+
+		if current == nil || nested > 0 {
+			continue
+		}
+		if err = applyProperty(current, prop); err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+	}
+	return events, nil
+}
+
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read calendar")
+	}
+	return lines, nil
+}
+
+func parseProperty(line string) (property, bool) {
+	// The value starts at the first colon outside a quoted parameter value.
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, false
+	}
+
+	parts := splitUnquoted(line[:colon], ';')
+	prop := property{name: strings.ToUpper(parts[0]), params: make(map[string]string), value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, true
+}
+
+func splitUnquoted(s string, sep rune) []string {
+	var parts []string
+	var b strings.Builder
+	inQuotes := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			b.WriteRune(r)
+		case r == sep && !inQuotes:
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(parts, b.String())
+}
+
+func applyProperty(ev *Event, prop property) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		ev.UID = prop.value
+	case "SUMMARY":
+		ev.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		ev.Description = unescapeText(prop.value)
+	case "LOCATION":
+		ev.Location = unescapeText(prop.value)
+	case "CATEGORIES":
+		for _, category := range splitEscaped(prop.value) {
+			if category = strings.TrimSpace(category); category != "" {
+				ev.Categories = append(ev.Categories, category)
+			}
+		}
+	case "STATUS":
+		ev.Status = strings.ToUpper(prop.value)
+	case "TRANSP":
+		ev.Transparent = strings.EqualFold(prop.value, "TRANSPARENT")
+	case "ATTENDEE":
+		email := strings.TrimPrefix(strings.ToLower(prop.value), "mailto:")
+		ev.Attendees = append(ev.Attendees, Attendee{Email: email, PartStat: strings.ToUpper(prop.params["PARTSTAT"])})
+	case "RRULE":
+		ev.RRule = prop.value
+	case "DTSTART":
+		ev.Start, ev.AllDay, err = parseICalTime(prop)
+	case "DTEND":
+		ev.End, _, err = parseICalTime(prop)
+	case "DURATION":
+		var d time.Duration
+		if d, err = parseICalDuration(prop.value); err == nil {
+			ev.duration = &d
+		}
+	case "EXDATE":
+		for value := range strings.SplitSeq(prop.value, ",") {
+			var t time.Time
+			if t, _, err = parseICalTime(property{params: prop.params, value: value}); err != nil {
+				return err
+			}
+			ev.ExDates = append(ev.ExDates, t)
+		}
+	case "RECURRENCE-ID":
+		var t time.Time
+		if t, _, err = parseICalTime(prop); err == nil {
+			ev.RecurrenceID = &t
+		}
+	}
+	return err
+}
+
+// finishEvent resolves a DURATION relative to DTSTART and defaults the end of
+// all-day events to the next day.
+func finishEvent(ev *Event) error {
+	if ev.Start.IsZero() {
+		return errors.New("missing DTSTART")
+	}
+	if ev.duration != nil && ev.End.IsZero() {
+		ev.End = ev.Start.Add(*ev.duration)
+	}
+	if ev.AllDay && !ev.End.After(ev.Start) {
+		ev.End = ev.Start.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+// parseICalTime parses DATE and DATE-TIME values. UTC values end in Z, TZID
+// selects a zone, and floating times are taken as local time. Unknown zones,
+// such as Windows zone names, also fall back to local time.
+func parseICalTime(prop property) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	if prop.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, time.Local)
+		if err != nil {
+			return time.Time{}, false, errors.Wrapf(err, "parse date %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, errors.Wrapf(err, "parse time %q", value)
+		}
+		return t, false, nil
+	}
+
+	loc := time.Local
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, errors.Wrapf(err, "parse time %q", value)
+	}
+	return t, false, nil
+}
+
+// parseICalDuration parses RFC 5545 durations such as PT1H30M, P1D or P2W.
+func parseICalDuration(value string) (time.Duration, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	}
+	s = strings.TrimPrefix(s, "+")
+	if !strings.HasPrefix(s, "P") {
+		return 0, errors.Errorf("invalid duration %q", value)
+	}
+
+	var total time.Duration
+	inTime := false
+	number := ""
+	for _, r := range s[1:] {
+		switch {
+		case r == 'T':
+			inTime = true
+		case r >= '0' && r <= '9':
+			number += string(r)
+		default:
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, errors.Errorf("invalid duration %q", value)
+			}
+			unit, ok := durationUnit(r, inTime)
+			if !ok {
+				return 0, errors.Errorf("invalid duration %q", value)
+			}
+			total += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, errors.Errorf("invalid duration %q", value)
+	}
+	return sign * total, nil
+}
+
+func durationUnit(r rune, inTime bool) (time.Duration, bool) {
+	switch {
+	case r == 'W' && !inTime:
+		return 7 * 24 * time.Hour, true
+	case r == 'D' && !inTime:
+		return 24 * time.Hour, true
+	case r == 'H' && inTime:
+		return time.Hour, true
+	case r == 'M' && inTime:
+		return time.Minute, true
+	case r == 'S' && inTime:
+		return time.Second, true
+	default:
+		return 0, false
+	}
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if escaped {
+			switch r {
+			case 'n', 'N':
+				b.WriteRune('\n')
+			default:
+				b.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// splitEscaped splits a list value on commas that are not escaped.
+func splitEscaped(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			parts = append(parts, unescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, unescapeText(s[start:]))
+}
@@ -0,0 +1,83 @@
+package importing_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/importing"
+)
+
+const sampleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Calendar//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20260302T093000\r\n" +
+	"DURATION:PT15M\r\n" +
+	"SUMMARY:Daily standup\r\n" +
+	"CATEGORIES:Team,Daily Sync\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=6\r\n" +
+	"EXDATE;TZID=Europe/Berlin:20260304T093000\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:review@example.com\r\n" +
+	"DTSTART:20260303T130000Z\r\n" +
+	"DTEND:20260303T140000Z\r\n" +
+	"SUMMARY:Design review\\, part 2\r\n" +
+	"DESCRIPTION:Agenda:\\nfirst item which is a very long line that an exporter\r\n" +
+	"  folded\r\n" +
+	"LOCATION:Room 1\r\n" +
+	"ATTENDEE;CN=\"Doe: Jane\";PARTSTAT=DECLINED:mailto:Jane@Example.com\r\n" +
+	"ATTENDEE;PARTSTAT=ACCEPTED:mailto:bob@example.com\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20260306\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"TRANSP:TRANSPARENT\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICal(t *testing.T) {
+	events, err := importing.ParseICal(strings.NewReader(sampleCalendar))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	standup := events[0]
+	assert.Equal(t, "standup@example.com", standup.UID)
+	assert.True(t, standup.Start.Equal(time.Date(2026, time.March, 2, 9, 30, 0, 0, berlin)))
+	assert.Equal(t, 15*time.Minute, standup.End.Sub(standup.Start))
+	assert.Equal(t, []string{"Team", "Daily Sync"}, standup.Categories)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=6", standup.RRule)
+	require.Len(t, standup.ExDates, 1)
+	assert.Empty(t, standup.Description, "VALARM properties are ignored")
+
+	review := events[1]
+	assert.Equal(t, "Design review, part 2", review.Summary)
+	assert.Equal(t, "Agenda:\nfirst item which is a very long line that an exporter folded", review.Description)
+	assert.Equal(t, time.Date(2026, time.March, 3, 13, 0, 0, 0, time.UTC), review.Start)
+	assert.True(t, review.Declined("jane@example.com"))
+	assert.False(t, review.Declined("bob@example.com"))
+	assert.False(t, review.Declined(""))
+
+	holiday := events[2]
+	assert.True(t, holiday.AllDay)
+	assert.True(t, holiday.Transparent)
+	assert.Equal(t, holiday.Start.AddDate(0, 0, 1), holiday.End)
+}
+
+func TestParseICalRequiresStart(t *testing.T) {
+	_, err := importing.ParseICal(strings.NewReader("BEGIN:VEVENT\nSUMMARY:broken\nEND:VEVENT\n"))
+	require.ErrorContains(t, err, "missing DTSTART")
+}
@@ -0,0 +1,150 @@
+package importing
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// DefaultProject is used for events that no rule or category assigns to a project.
+const DefaultProject = "meetings"
+
+// SkipReason explains why an event was not turned into an activity.
+type SkipReason string
+
+const (
+	SkipNone        SkipReason = ""
+	SkipCancelled   SkipReason = "cancelled"
+	SkipDeclined    SkipReason = "declined"
+	SkipTransparent SkipReason = "transparent"
+	SkipAllDay      SkipReason = "all-day"
+	SkipEmpty       SkipReason = "empty"
+	SkipRule        SkipReason = "rule"
+	SkipDuplicate   SkipReason = "duplicate"
+)
+
+// Rule maps events to a project, description and tags. The first rule whose
+// Match and Category both match an event wins.
+type Rule struct {
+	Match       *regexp.Regexp // matched against SUMMARY; nil matches every event
+	Category    string         // CATEGORIES entry, case-insensitive; empty matches every event
+	Project     string         // may use Match groups such as $1
+	Description string         // replaces SUMMARY; may use Match groups such as $2
+	Tags        []string
+	Skip        bool
+}
+
+// MapOptions configures how events become activities.
+type MapOptions struct {
+	Rules          []Rule
+	DefaultProject string   // falls back to DefaultProject
+	Tags           []string // added to every imported activity
+	Attendee       string   // events this attendee declined are skipped
+}
+
+// MapEvent turns an event into an add request. Cancelled, declined,
+// transparent (free) and all-day events are skipped. Without a rule, the
+// first category becomes the project and the remaining ones become tags.
+// Times are converted to local time like every other activity.
+func MapEvent(ev Event, opts MapOptions) (models.AddActivityRequest, SkipReason) {
+	switch {
+	case ev.Status == "CANCELLED":
+		return models.AddActivityRequest{}, SkipCancelled
+	case ev.Declined(opts.Attendee):
+		return models.AddActivityRequest{}, SkipDeclined
+	case ev.Transparent:
+		return models.AddActivityRequest{}, SkipTransparent
+	case ev.AllDay:
+		return models.AddActivityRequest{}, SkipAllDay
+	case !ev.End.After(ev.Start):
+		return models.AddActivityRequest{}, SkipEmpty
+	}
+
+	req := models.AddActivityRequest{
+		Description: strings.TrimSpace(ev.Summary),
+		StartTime:   ev.Start.Local(),
+		EndTime:     ev.End.Local(),
+		Notes:       eventNotes(ev),
+	}
+	categories := ev.Categories
+	tags := slices.Clone(opts.Tags)
+
+	if rule, ok := matchRule(ev, opts.Rules); ok {
+		if rule.Skip {
+			return models.AddActivityRequest{}, SkipRule
+		}
+		req.Project = expandRuleTemplate(rule, rule.Project, ev.Summary)
+		if rule.Description != "" {
+			req.Description = expandRuleTemplate(rule, rule.Description, ev.Summary)
+		}
+		tags = append(tags, rule.Tags...)
+	}
+
+	if req.Project == "" && len(categories) > 0 {
+		req.Project, categories = categories[0], categories[1:]
+	}
+	if req.Project == "" {
+		req.Project = opts.DefaultProject
+	}
+	if req.Project == "" {
+		req.Project = DefaultProject
+	}
+	if req.Description == "" {
+		req.Description = req.Project
+	}
+
+	req.Tags = normalizeTags(append(tags, categories...))
+	return req, SkipNone
+}
+
+func matchRule(ev Event, rules []Rule) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.Match != nil && !rule.Match.MatchString(ev.Summary) {
+			continue
+		}
+		if rule.Category != "" && !slices.ContainsFunc(ev.Categories, func(c string) bool {
+			return strings.EqualFold(c, rule.Category)
+		}) {
+			continue
+		}
+		return rule, true
+	}
+	return Rule{}, false
+}
+
+func expandRuleTemplate(rule Rule, template, summary string) string {
+	if rule.Match == nil || template == "" {
+		return template
+	}
+	match := rule.Match.FindStringSubmatchIndex(summary)
+	if match == nil {
+		return template
+	}
+	return strings.TrimSpace(string(rule.Match.ExpandString(nil, template, summary, match)))
+}
+
+func eventNotes(ev Event) string {
+	notes := strings.TrimSpace(ev.Description)
+	if ev.Location != "" {
+		location := "Location: " + strings.TrimSpace(ev.Location)
+		if notes == "" {
+			return location
+		}
+		return location + "\n\n" + notes
+	}
+	return notes
+}
+
+// normalizeTags drops duplicates and replaces spaces, which tags cannot contain.
+func normalizeTags(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(tag), "-")
+		if tag != "" && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
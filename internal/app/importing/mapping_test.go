@@ -0,0 +1,84 @@
+package importing_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kriuchkov/tock/internal/app/importing"
+)
+
+func meeting(summary string, categories ...string) importing.Event {
+	start := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.Local)
+	return importing.Event{Summary: summary, Categories: categories, Start: start, End: start.Add(30 * time.Minute)}
+}
+
+func TestMapEventDefaults(t *testing.T) {
+	req, skip := importing.MapEvent(meeting("Planning", "Acme", "Billable Work"), importing.MapOptions{Tags: []string{"meeting"}})
+	assert.Equal(t, importing.SkipNone, skip)
+	assert.Equal(t, "Acme", req.Project)
+	assert.Equal(t, "Planning", req.Description)
+	assert.Equal(t, []string{"meeting", "Billable-Work"}, req.Tags)
+	assert.Equal(t, 30*time.Minute, req.EndTime.Sub(req.StartTime))
+
+	req, _ = importing.MapEvent(meeting("1:1"), importing.MapOptions{})
+	assert.Equal(t, importing.DefaultProject, req.Project)
+
+	req, _ = importing.MapEvent(meeting("1:1"), importing.MapOptions{DefaultProject: "calls"})
+	assert.Equal(t, "calls", req.Project)
+}
+
+func TestMapEventRules(t *testing.T) {
+	opts := importing.MapOptions{Rules: []importing.Rule{
+		{Match: regexp.MustCompile(`(?i)^lunch`), Skip: true},
+		{Match: regexp.MustCompile(`^\[(\w+)\] (.+)$`), Project: "$1", Description: "$2", Tags: []string{"client"}},
+		{Category: "team", Project: "internal", Tags: []string{"sync"}},
+	}}
+
+	_, skip := importing.MapEvent(meeting("Lunch with Bob"), opts)
+	assert.Equal(t, importing.SkipRule, skip)
+
+	req, _ := importing.MapEvent(meeting("[acme] Kickoff", "Extra"), opts)
+	assert.Equal(t, "acme", req.Project)
+	assert.Equal(t, "Kickoff", req.Description)
+	assert.Equal(t, []string{"client", "Extra"}, req.Tags)
+
+	req, _ = importing.MapEvent(meeting("Weekly", "Team"), opts)
+	assert.Equal(t, "internal", req.Project)
+	assert.Equal(t, []string{"sync", "Team"}, req.Tags)
+}
+
+func TestMapEventSkips(t *testing.T) {
+	cancelled := meeting("Cancelled")
+	cancelled.Status = "CANCELLED"
+	declined := meeting("Declined")
+	declined.Attendees = []importing.Attendee{{Email: "me@example.com", PartStat: "DECLINED"}}
+	free := meeting("Focus time")
+	free.Transparent = true
+	allDay := meeting("Offsite")
+	allDay.AllDay = true
+	empty := meeting("Reminder")
+	empty.End = empty.Start
+
+	opts := importing.MapOptions{Attendee: "Me@Example.com"}
+	for ev, want := range map[*importing.Event]importing.SkipReason{
+		&cancelled: importing.SkipCancelled,
+		&declined:  importing.SkipDeclined,
+		&free:      importing.SkipTransparent,
+		&allDay:    importing.SkipAllDay,
+		&empty:     importing.SkipEmpty,
+	} {
+		_, skip := importing.MapEvent(*ev, opts)
+		assert.Equal(t, want, skip, ev.Summary)
+	}
+}
+
+func TestMapEventNotes(t *testing.T) {
+	ev := meeting("Review")
+	ev.Location = "Room 1"
+	ev.Description = "Agenda"
+	req, _ := importing.MapEvent(ev, importing.MapOptions{})
+	assert.Equal(t, "Location: Room 1\n\nAgenda", req.Notes)
+}
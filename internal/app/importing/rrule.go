@@ -0,0 +1,352 @@
+package importing
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// maxOccurrences and maxPeriods stop rules without COUNT or UNTIL, and rules
+// such as BYMONTH=2;BYMONTHDAY=30 that never match.
+const (
+	maxOccurrences = 10000
+	maxPeriods     = 100000
+)
+
+// ExpandEvents returns the events and occurrences of recurring events that
+// overlap [from, to), sorted by start. A zero from or to leaves that side
+// open, but recurring events are only expanded up to to. EXDATEs are
+// removed and occurrences edited in their own VEVENT (RECURRENCE-ID) replace
+// the generated ones.
+func ExpandEvents(events []Event, from, to time.Time) ([]Event, error) {
+	overrides := make(map[string]bool)
+	for _, ev := range events {
+		if ev.RecurrenceID != nil {
+			overrides[occurrenceKey(ev.UID, *ev.RecurrenceID)] = true
+		}
+	}
+
+	var result []Event
+	for _, ev := range events {
+		if ev.RRule == "" || ev.RecurrenceID != nil {
+			if overlaps(ev, from, to) {
+				result = append(result, ev)
+			}
+			continue
+		}
+
+		starts, err := expandRule(ev, to)
+		if err != nil {
+			return nil, errors.Wrapf(err, "expand %q", ev.Summary)
+		}
+		length := ev.End.Sub(ev.Start)
+		for _, start := range starts {
+			if overrides[occurrenceKey(ev.UID, start)] || slices.ContainsFunc(ev.ExDates, start.Equal) {
+				continue
+			}
+			occurrence := ev
+			occurrence.Start, occurrence.End = start, start.Add(length)
+			occurrence.RRule, occurrence.ExDates = "", nil
+			if overlaps(occurrence, from, to) {
+				result = append(result, occurrence)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result, nil
+}
+
+func occurrenceKey(uid string, start time.Time) string {
+	return uid + "@" + strconv.FormatInt(start.UnixNano(), 10)
+}
+
+func overlaps(ev Event, from, to time.Time) bool {
+	if !to.IsZero() && !ev.Start.Before(to) {
+		return false
+	}
+	return from.IsZero() || ev.End.After(from)
+}
+
+// recurrenceRule is the subset of RFC 5545 RRULE that calendar exports use
+// for meetings: FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY and BYMONTH.
+type recurrenceRule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+}
+
+// weekdayNum is a BYDAY entry such as MO, 2TU or -1FR; n is 0 for every
+// matching weekday.
+type weekdayNum struct {
+	n   int
+	day time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func parseRule(value string, start time.Time) (recurrenceRule, error) {
+	rule := recurrenceRule{interval: 1}
+	for part := range strings.SplitSeq(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(val)
+		case "COUNT":
+			rule.count, err = strconv.Atoi(val)
+		case "UNTIL":
+			rule.until, err = parseUntil(val, start.Location())
+		case "BYDAY":
+			rule.byDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseInts(val)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(val)
+			for _, m := range months {
+				rule.byMonth = append(rule.byMonth, time.Month(m))
+			}
+		}
+		if err != nil {
+			return rule, errors.Wrapf(err, "parse RRULE %s", key)
+		}
+	}
+
+	switch rule.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return rule, errors.Errorf("unsupported RRULE frequency %q", rule.freq)
+	}
+	if rule.interval < 1 {
+		rule.interval = 1
+	}
+	return rule, nil
+}
+
+// parseUntil treats a DATE value as inclusive of the whole day.
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	t, allDay, err := parseICalTime(property{value: value, params: map[string]string{}})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if allDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, loc), nil
+	}
+	if !strings.HasSuffix(value, "Z") {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+	}
+	return t, nil
+}
+
+func parseByDay(value string) ([]weekdayNum, error) {
+	var days []weekdayNum
+	for entry := range strings.SplitSeq(strings.ToUpper(value), ",") {
+		if len(entry) < 2 {
+			return nil, errors.Errorf("invalid BYDAY %q", entry)
+		}
+		day, ok := weekdayCodes[entry[len(entry)-2:]]
+		if !ok {
+			return nil, errors.Errorf("invalid BYDAY %q", entry)
+		}
+		n := 0
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil {
+				return nil, errors.Errorf("invalid BYDAY %q", entry)
+			}
+		}
+		days = append(days, weekdayNum{n: n, day: day})
+	}
+	return days, nil
+}
+
+func parseInts(value string) ([]int, error) {
+	var result []int
+	for field := range strings.SplitSeq(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// expandRule returns the occurrence starts of a recurring event up to limit,
+// including the first one at DTSTART. COUNT counts occurrences removed by
+// EXDATE too, as RFC 5545 requires.
+func expandRule(ev Event, limit time.Time) ([]time.Time, error) {
+	rule, err := parseRule(ev.RRule, ev.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	var starts []time.Time
+	emitted := 0
+	for period := 0; period < maxPeriods && emitted < maxOccurrences; period++ {
+		candidates, periodStart := rule.candidates(ev.Start, period)
+		if !limit.IsZero() && !periodStart.Before(limit) {
+			break
+		}
+		if !rule.until.IsZero() && periodStart.After(rule.until) {
+			break
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(ev.Start) {
+				continue
+			}
+			if !rule.until.IsZero() && candidate.After(rule.until) {
+				return starts, nil
+			}
+			if !limit.IsZero() && !candidate.Before(limit) {
+				return starts, nil
+			}
+			emitted++
+			if rule.count > 0 && emitted > rule.count {
+				return starts, nil
+			}
+			starts = append(starts, candidate)
+		}
+	}
+	return starts, nil
+}
+
+// candidates returns the sorted occurrence starts in the n-th period of the
+// rule and the first instant of that period.
+func (r recurrenceRule) candidates(start time.Time, n int) ([]time.Time, time.Time) {
+	loc := start.Location()
+	at := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+	}
+	firstDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+
+	var days []time.Time
+	var periodStart time.Time
+	switch r.freq {
+	case "DAILY":
+		periodStart = firstDay.AddDate(0, 0, n*r.interval)
+		if r.matchesDay(periodStart) {
+			days = append(days, periodStart)
+		}
+	case "WEEKLY":
+		weekStart := firstDay.AddDate(0, 0, -((int(firstDay.Weekday()) + 6) % 7))
+		periodStart = weekStart.AddDate(0, 0, 7*n*r.interval)
+		weekdays := []time.Weekday{start.Weekday()}
+		if len(r.byDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, d := range r.byDay {
+				weekdays = append(weekdays, d.day)
+			}
+		}
+		for _, wd := range weekdays {
+			day := periodStart.AddDate(0, 0, (int(wd)+6)%7)
+			if r.inMonths(day.Month()) {
+				days = append(days, day)
+			}
+		}
+	case "MONTHLY":
+		periodStart = time.Date(start.Year(), start.Month()+time.Month(n*r.interval), 1, 0, 0, 0, 0, loc)
+		if r.inMonths(periodStart.Month()) {
+			days = r.daysInMonth(periodStart, start.Day())
+		}
+	case "YEARLY":
+		periodStart = time.Date(start.Year()+n*r.interval, time.January, 1, 0, 0, 0, 0, loc)
+		months := r.byMonth
+		if len(months) == 0 {
+			months = []time.Month{start.Month()}
+		}
+		for _, month := range months {
+			days = append(days, r.daysInMonth(time.Date(periodStart.Year(), month, 1, 0, 0, 0, 0, loc), start.Day())...)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	result := make([]time.Time, 0, len(days))
+	for i, day := range days {
+		if i > 0 && day.Equal(days[i-1]) {
+			continue
+		}
+		result = append(result, at(day))
+	}
+	return result, periodStart
+}
+
+// daysInMonth applies BYMONTHDAY and BYDAY to the month starting at first.
+// Without either, the day of DTSTART is used and months without it are skipped.
+func (r recurrenceRule) daysInMonth(first time.Time, defaultDay int) []time.Time {
+	length := first.AddDate(0, 1, -1).Day()
+	var days []time.Time
+
+	switch {
+	case len(r.byMonthDay) > 0:
+		for _, d := range r.byMonthDay {
+			if d < 0 {
+				d = length + d + 1
+			}
+			if d >= 1 && d <= length {
+				day := first.AddDate(0, 0, d-1)
+				if len(r.byDay) == 0 || r.matchesWeekday(day.Weekday()) {
+					days = append(days, day)
+				}
+			}
+		}
+	case len(r.byDay) > 0:
+		for _, wd := range r.byDay {
+			var matches []time.Time
+			for d := range length {
+				if day := first.AddDate(0, 0, d); day.Weekday() == wd.day {
+					matches = append(matches, day)
+				}
+			}
+			switch {
+			case wd.n == 0:
+				days = append(days, matches...)
+			case wd.n > 0 && wd.n <= len(matches):
+				days = append(days, matches[wd.n-1])
+			case wd.n < 0 && -wd.n <= len(matches):
+				days = append(days, matches[len(matches)+wd.n])
+			}
+		}
+	default:
+		if defaultDay <= length {
+			days = append(days, first.AddDate(0, 0, defaultDay-1))
+		}
+	}
+	return days
+}
+
+func (r recurrenceRule) matchesDay(day time.Time) bool {
+	if !r.inMonths(day.Month()) {
+		return false
+	}
+	if len(r.byDay) > 0 && !r.matchesWeekday(day.Weekday()) {
+		return false
+	}
+	if len(r.byMonthDay) > 0 {
+		length := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		return slices.ContainsFunc(r.byMonthDay, func(d int) bool { return d == day.Day() || length+d+1 == day.Day() })
+	}
+	return true
+}
+
+func (r recurrenceRule) matchesWeekday(wd time.Weekday) bool {
+	return slices.ContainsFunc(r.byDay, func(d weekdayNum) bool { return d.day == wd })
+}
+
+func (r recurrenceRule) inMonths(month time.Month) bool {
+	return len(r.byMonth) == 0 || slices.Contains(r.byMonth, month)
+}
@@ -0,0 +1,114 @@
+package importing_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/importing"
+)
+
+func startDates(events []importing.Event) []string {
+	dates := make([]string, len(events))
+	for i, ev := range events {
+		dates[i] = ev.Start.Format("2006-01-02 15:04")
+	}
+	return dates
+}
+
+func TestExpandEventsWeeklyWithExdateAndCount(t *testing.T) {
+	events, err := importing.ParseICal(strings.NewReader(sampleCalendar))
+	require.NoError(t, err)
+
+	expanded, err := importing.ExpandEvents(events[:1], time.Time{}, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	// COUNT=6 includes the excluded Wednesday.
+	assert.Equal(t, []string{"2026-03-02 09:30", "2026-03-06 09:30", "2026-03-09 09:30", "2026-03-11 09:30", "2026-03-13 09:30"},
+		startDates(expanded))
+	assert.Empty(t, expanded[0].RRule)
+	assert.Equal(t, 15*time.Minute, expanded[1].End.Sub(expanded[1].Start))
+}
+
+func TestExpandEventsRange(t *testing.T) {
+	start := time.Date(2026, time.January, 5, 10, 0, 0, 0, time.Local)
+	ev := importing.Event{UID: "daily", Summary: "daily", Start: start, End: start.Add(time.Hour), RRule: "FREQ=DAILY;INTERVAL=2"}
+
+	from := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.Local)
+	expanded, err := importing.ExpandEvents([]importing.Event{ev}, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-01-11 10:00", "2026-01-13 10:00"}, startDates(expanded))
+}
+
+func TestExpandEventsMonthlyRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		rule  string
+		want  []string
+	}{
+		{
+			name:  "last friday",
+			start: time.Date(2026, time.January, 30, 16, 0, 0, 0, time.Local),
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			want:  []string{"2026-01-30 16:00", "2026-02-27 16:00", "2026-03-27 16:00"},
+		},
+		{
+			name:  "second tuesday until date",
+			start: time.Date(2026, time.January, 13, 9, 0, 0, 0, time.Local),
+			rule:  "FREQ=MONTHLY;BYDAY=2TU;UNTIL=20260310",
+			want:  []string{"2026-01-13 09:00", "2026-02-10 09:00", "2026-03-10 09:00"},
+		},
+		{
+			name:  "31st skips short months",
+			start: time.Date(2026, time.January, 31, 9, 0, 0, 0, time.Local),
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			want:  []string{"2026-01-31 09:00", "2026-03-31 09:00", "2026-05-31 09:00"},
+		},
+		{
+			name:  "last day of month",
+			start: time.Date(2026, time.January, 31, 9, 0, 0, 0, time.Local),
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2",
+			want:  []string{"2026-01-31 09:00", "2026-02-28 09:00"},
+		},
+		{
+			name:  "yearly",
+			start: time.Date(2026, time.March, 1, 9, 0, 0, 0, time.Local),
+			rule:  "FREQ=YEARLY;COUNT=2",
+			want:  []string{"2026-03-01 09:00", "2027-03-01 09:00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := importing.Event{UID: tt.name, Start: tt.start, End: tt.start.Add(time.Hour), RRule: tt.rule}
+			expanded, err := importing.ExpandEvents([]importing.Event{ev}, time.Time{}, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.Local))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, startDates(expanded))
+		})
+	}
+}
+
+func TestExpandEventsAppliesOverrides(t *testing.T) {
+	start := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.Local)
+	moved := time.Date(2026, time.March, 3, 10, 0, 0, 0, time.Local)
+	movedTo := time.Date(2026, time.March, 3, 15, 0, 0, 0, time.Local)
+	events := []importing.Event{
+		{UID: "sync", Summary: "sync", Start: start, End: start.Add(time.Hour), RRule: "FREQ=DAILY;COUNT=3"},
+		{UID: "sync", Summary: "sync (moved)", Start: movedTo, End: movedTo.Add(time.Hour), RecurrenceID: &moved},
+	}
+
+	expanded, err := importing.ExpandEvents(events, time.Time{}, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.Local))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-03-02 10:00", "2026-03-03 15:00", "2026-03-04 10:00"}, startDates(expanded))
+	assert.Equal(t, "sync (moved)", expanded[1].Summary)
+}
+
+func TestExpandEventsRejectsUnsupportedFrequency(t *testing.T) {
+	start := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.Local)
+	ev := importing.Event{Start: start, End: start.Add(time.Hour), RRule: "FREQ=HOURLY"}
+	_, err := importing.ExpandEvents([]importing.Event{ev}, time.Time{}, start.AddDate(0, 1, 0))
+	require.ErrorContains(t, err, `unsupported RRULE frequency "HOURLY"`)
+}
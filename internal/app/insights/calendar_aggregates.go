@@ -68,15 +68,21 @@ func BuildMonthData(activities []models.Activity, year int, month time.Month, no
 }
 
 func ComputeProductivityStats(monthReports map[int]*models.Report, daysInMonth int) ProductivityStats {
-	stats := ProductivityStats{}
-	currentStreak := 0
-
+	durations := make([]time.Duration, daysInMonth)
 	for day := 1; day <= daysInMonth; day++ {
-		dur := time.Duration(0)
 		if report, ok := monthReports[day]; ok {
-			dur = report.TotalDuration
+			durations[day-1] = report.TotalDuration
 		}
+	}
+	return computeProductivityStats(durations)
+}
 
+// computeProductivityStats summarizes consecutive daily totals.
+func computeProductivityStats(durations []time.Duration) ProductivityStats {
+	stats := ProductivityStats{}
+	currentStreak := 0
+
+	for _, dur := range durations {
 		if dur > 0 {
 			stats.ActiveDays++
 			stats.TotalDuration += dur
@@ -124,16 +130,8 @@ func AggregateProjectDurations(monthReports map[int]*models.Report) []ProjectDur
 	return projects
 }
 
-func BuildWeeklyActivityData(dailyReports map[string]*models.Report, currentDate time.Time) WeeklyActivityData {
-	weekday := int(currentDate.Weekday())
-	if weekday == 0 {
-		weekday = 7
-	}
-
-	startOfWeek := time.Date(
-		currentDate.Year(), currentDate.Month(), currentDate.Day(),
-		0, 0, 0, 0, time.Local,
-	).AddDate(0, 0, -weekday+1)
+func BuildWeeklyActivityData(dailyReports map[string]*models.Report, currentDate time.Time, weekStart time.Weekday) WeeklyActivityData {
+	startOfWeek := StartOfWeek(currentDate, weekStart)
 	startOfPrevWeek := startOfWeek.AddDate(0, 0, -7)
 
 	data := WeeklyActivityData{StartOfWeek: startOfWeek}
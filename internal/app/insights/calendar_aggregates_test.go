@@ -73,7 +73,7 @@ func TestBuildWeeklyActivityData(t *testing.T) {
 		"2026-03-05": {TotalDuration: 3 * time.Hour},
 	}
 
-	data := insights.BuildWeeklyActivityData(dailyReports, time.Date(2026, time.March, 12, 12, 0, 0, 0, time.Local))
+	data := insights.BuildWeeklyActivityData(dailyReports, time.Date(2026, time.March, 12, 12, 0, 0, 0, time.Local), time.Monday)
 
 	assert.Equal(t, time.Date(2026, time.March, 9, 0, 0, 0, 0, time.Local), data.StartOfWeek)
 	assert.Equal(t, time.Hour, data.CurrentWeekDurations[0])
@@ -96,7 +96,7 @@ func TestBuildWeeklyActivityData_CurrentWeekProjects(t *testing.T) {
 		},
 	}
 
-	data := insights.BuildWeeklyActivityData(dailyReports, time.Date(2026, time.March, 12, 12, 0, 0, 0, time.Local))
+	data := insights.BuildWeeklyActivityData(dailyReports, time.Date(2026, time.March, 12, 12, 0, 0, 0, time.Local), time.Monday)
 
 	// Tuesday of the week that starts on 2026-03-09.
 	require.Len(t, data.CurrentWeekProjects[1], 2)
@@ -0,0 +1,93 @@
+package insights
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProjectNode is a project in the hierarchy that a separator spells out in
+// project names: acme/api/auth is the node auth below acme/api, below acme.
+// Parent nodes exist even when nothing was tracked on them directly.
+type ProjectNode struct {
+	Name     string        // the last part of the path, such as auth
+	Path     string        // the full project name, such as acme/api/auth
+	Own      time.Duration // tracked on this project itself
+	Duration time.Duration // Own plus the durations of all children
+	Children []*ProjectNode
+}
+
+// BuildProjectTree rolls the durations of projects up to their parents and
+// returns the top-level nodes, each level sorted by path. With an empty
+// separator every project is a top-level node.
+func BuildProjectTree(durations map[string]time.Duration, separator string) []*ProjectNode {
+	root := &ProjectNode{}
+	nodes := make(map[string]*ProjectNode)
+	for project, duration := range durations {
+		parts := []string{project}
+		if separator != "" {
+			parts = strings.Split(project, separator)
+		}
+
+		parent := root
+		for i, part := range parts {
+			path := strings.Join(parts[:i+1], separator)
+			node, ok := nodes[path]
+			if !ok {
+				node = &ProjectNode{Name: part, Path: path}
+				nodes[path] = node
+				parent.Children = append(parent.Children, node)
+			}
+			node.Duration += duration
+			parent = node
+		}
+		parent.Own += duration
+	}
+
+	sortProjectNodes(root.Children, func(a, b *ProjectNode) bool { return a.Path < b.Path })
+	return root.Children
+}
+
+// SortProjectTreeByDuration orders every level of the tree longest first.
+func SortProjectTreeByDuration(nodes []*ProjectNode) {
+	sortProjectNodes(nodes, func(a, b *ProjectNode) bool {
+		if a.Duration != b.Duration {
+			return a.Duration > b.Duration
+		}
+		return a.Path < b.Path
+	})
+}
+
+func sortProjectNodes(nodes []*ProjectNode, less func(a, b *ProjectNode) bool) {
+	sort.Slice(nodes, func(i, j int) bool { return less(nodes[i], nodes[j]) })
+	for _, node := range nodes {
+		sortProjectNodes(node.Children, less)
+	}
+}
+
+// WalkProjectTree calls fn for the nodes depth first, parents before their
+// children, with depth 1 for top-level nodes. A maxDepth above 0 skips the
+// nodes below that depth, whose durations are already in their ancestors;
+// fn is told whether it hides children of the node.
+func WalkProjectTree(nodes []*ProjectNode, maxDepth int, fn func(node *ProjectNode, depth int, collapsed bool)) {
+	walkProjectTree(nodes, 1, maxDepth, fn)
+}
+
+func walkProjectTree(nodes []*ProjectNode, depth, maxDepth int, fn func(*ProjectNode, int, bool)) {
+	for _, node := range nodes {
+		collapsed := maxDepth > 0 && depth >= maxDepth && len(node.Children) > 0
+		fn(node, depth, collapsed)
+		if !collapsed {
+			walkProjectTree(node.Children, depth+1, maxDepth, fn)
+		}
+	}
+}
+
+// Paths returns the path of the node and of all its descendants.
+func (n *ProjectNode) Paths() []string {
+	paths := []string{n.Path}
+	for _, child := range n.Children {
+		paths = append(paths, child.Paths()...)
+	}
+	return paths
+}
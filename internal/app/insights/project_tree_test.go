@@ -0,0 +1,67 @@
+package insights_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+)
+
+func renderProjectTree(nodes []*insights.ProjectNode, maxDepth int) string {
+	var b strings.Builder
+	insights.WalkProjectTree(nodes, maxDepth, func(node *insights.ProjectNode, depth int, collapsed bool) {
+		marker := ""
+		if collapsed {
+			marker = " +"
+		}
+		fmt.Fprintf(&b, "%s%s %s (own %s)%s\n", strings.Repeat("  ", depth-1), node.Name, node.Duration, node.Own, marker)
+	})
+	return b.String()
+}
+
+func TestBuildProjectTreeRollsUpDurations(t *testing.T) {
+	tree := insights.BuildProjectTree(map[string]time.Duration{
+		"acme/api/auth": 2 * time.Hour,
+		"acme/api":      time.Hour,
+		"acme/web":      30 * time.Minute,
+		"internal":      time.Hour,
+	}, "/")
+
+	assert.Equal(t, `acme 3h30m0s (own 0s)
+  api 3h0m0s (own 1h0m0s)
+    auth 2h0m0s (own 2h0m0s)
+  web 30m0s (own 30m0s)
+internal 1h0m0s (own 1h0m0s)
+`, renderProjectTree(tree, 0))
+
+	assert.Equal(t, `acme 3h30m0s (own 0s) +
+internal 1h0m0s (own 1h0m0s)
+`, renderProjectTree(tree, 1))
+
+	assert.Equal(t, []string{"acme", "acme/api", "acme/api/auth", "acme/web"}, tree[0].Paths())
+}
+
+func TestBuildProjectTreeWithoutSeparatorIsFlat(t *testing.T) {
+	tree := insights.BuildProjectTree(map[string]time.Duration{"acme/api": time.Hour, "acme": time.Hour}, "")
+
+	assert.Equal(t, "acme 1h0m0s (own 1h0m0s)\nacme/api 1h0m0s (own 1h0m0s)\n", renderProjectTree(tree, 0))
+}
+
+func TestSortProjectTreeByDuration(t *testing.T) {
+	tree := insights.BuildProjectTree(map[string]time.Duration{
+		"acme/api": time.Hour,
+		"acme/web": 2 * time.Hour,
+		"beta":     4 * time.Hour,
+	}, "/")
+	insights.SortProjectTreeByDuration(tree)
+
+	assert.Equal(t, `beta 4h0m0s (own 4h0m0s)
+acme 3h0m0s (own 0s)
+  web 2h0m0s (own 2h0m0s)
+  api 1h0m0s (own 1h0m0s)
+`, renderProjectTree(tree, 0))
+}
@@ -0,0 +1,83 @@
+package insights
+
+import (
+	"time"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/timeutil"
+)
+
+const (
+	defaultGridFirstHour = 8
+	defaultGridLastHour  = 18
+)
+
+// WeekGrid holds one week of activities split into per-day segments, from the
+// first day of the week.
+type WeekGrid struct {
+	StartOfWeek time.Time
+	Days        [7][]models.Activity // segments sorted by start time
+	Totals      [7]time.Duration
+	Total       time.Duration
+}
+
+// StartOfWeek returns local midnight of the first day of date's week, for
+// weeks that start on weekStart.
+func StartOfWeek(date time.Time, weekStart time.Weekday) time.Time {
+	return timeutil.Calendar{WeekStart: weekStart}.StartOfWeek(date)
+}
+
+// BuildWeekGrid splits activities by day and keeps the segments that fall into
+// the week starting at startOfWeek. Running activities end at now.
+func BuildWeekGrid(activities []models.Activity, startOfWeek, now time.Time) WeekGrid {
+	grid := WeekGrid{StartOfWeek: startOfWeek}
+	endOfWeek := startOfWeek.AddDate(0, 0, 7)
+
+	for _, act := range models.SortActivitiesByStart(activities) {
+		for _, segment := range SplitActivityByDay(act, now) {
+			if segment.StartTime.Before(startOfWeek) || !segment.StartTime.Before(endOfWeek) {
+				continue
+			}
+			day := dayIndex(startOfWeek, segment.StartTime)
+			grid.Days[day] = append(grid.Days[day], segment)
+			grid.Totals[day] += segment.Duration()
+			grid.Total += segment.Duration()
+		}
+	}
+	return grid
+}
+
+// HourRange returns the first and last (exclusive) hour that contain activity
+// across the week, widened to include the default working hours.
+func (g WeekGrid) HourRange() (int, int) {
+	first, last := defaultGridFirstHour, defaultGridLastHour
+	for _, day := range g.Days {
+		for _, segment := range day {
+			first = min(first, segment.StartTime.Hour())
+			end := segment.StartTime.Add(segment.Duration())
+			endHour := end.Hour()
+			if end.Minute() > 0 || end.Second() > 0 {
+				endHour++
+			}
+			if !sameDate(end, segment.StartTime) {
+				endHour = 24
+			}
+			last = max(last, endHour)
+		}
+	}
+	return first, last
+}
+
+func dayIndex(startOfWeek, t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	for i := range 7 {
+		if startOfWeek.AddDate(0, 0, i).Equal(day) {
+			return i
+		}
+	}
+	return 0
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
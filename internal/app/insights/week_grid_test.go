@@ -0,0 +1,65 @@
+package insights_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func TestStartOfWeekReturnsMonday(t *testing.T) {
+	monday := time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, monday, insights.StartOfWeek(time.Date(2026, time.April, 6, 15, 0, 0, 0, time.Local), time.Monday))
+	assert.Equal(t, monday, insights.StartOfWeek(time.Date(2026, time.April, 12, 23, 59, 0, 0, time.Local), time.Monday))
+}
+
+func TestStartOfWeekFollowsWeekStart(t *testing.T) {
+	sunday := time.Date(2026, time.April, 5, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, sunday, insights.StartOfWeek(time.Date(2026, time.April, 5, 8, 0, 0, 0, time.Local), time.Sunday))
+	assert.Equal(t, sunday, insights.StartOfWeek(time.Date(2026, time.April, 11, 23, 59, 0, 0, time.Local), time.Sunday))
+}
+
+func TestBuildWeekGridSplitsAcrossMidnightAndWeekBounds(t *testing.T) {
+	monday := time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local)
+	lateStart := monday.Add(-time.Hour) // Sunday 23:00 of the previous week
+	lateEnd := monday.Add(2 * time.Hour)
+	wedStart := monday.AddDate(0, 0, 2).Add(9 * time.Hour)
+	wedEnd := wedStart.Add(90 * time.Minute)
+
+	grid := insights.BuildWeekGrid([]models.Activity{
+		{Project: "ops", StartTime: lateStart, EndTime: &lateEnd},
+		{Project: "tock", StartTime: wedStart, EndTime: &wedEnd},
+	}, monday, monday.AddDate(0, 0, 7))
+
+	require.Len(t, grid.Days[0], 1)
+	assert.Equal(t, monday, grid.Days[0][0].StartTime, "only the part inside the week is kept")
+	assert.Equal(t, 2*time.Hour, grid.Totals[0])
+	require.Len(t, grid.Days[2], 1)
+	assert.Equal(t, 90*time.Minute, grid.Totals[2])
+	assert.Equal(t, 3*time.Hour+30*time.Minute, grid.Total)
+}
+
+func TestWeekGridHourRange(t *testing.T) {
+	monday := time.Date(2026, time.April, 6, 0, 0, 0, 0, time.Local)
+
+	first, last := insights.WeekGrid{StartOfWeek: monday}.HourRange()
+	assert.Equal(t, 8, first)
+	assert.Equal(t, 18, last)
+
+	early := monday.Add(6*time.Hour + 30*time.Minute)
+	earlyEnd := early.Add(time.Hour)
+	late := monday.AddDate(0, 0, 1).Add(21 * time.Hour)
+	lateEnd := monday.AddDate(0, 0, 2)
+	grid := insights.BuildWeekGrid([]models.Activity{
+		{Project: "a", StartTime: early, EndTime: &earlyEnd},
+		{Project: "b", StartTime: late, EndTime: &lateEnd},
+	}, monday, monday.AddDate(0, 0, 7))
+
+	first, last = grid.HourRange()
+	assert.Equal(t, 6, first)
+	assert.Equal(t, 24, last)
+}
@@ -0,0 +1,54 @@
+package insights
+
+import (
+	"time"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// HeatLevels is the number of intensity levels for a non-empty day.
+const HeatLevels = 4
+
+// YearData holds daily totals for one calendar year.
+type YearData struct {
+	Year  int
+	Daily map[string]time.Duration // keyed by DateKey; days without activity are absent
+	Stats ProductivityStats
+}
+
+// BuildYearData aggregates activities into daily totals for year, splitting
+// entries that cross midnight. Stats cover every day of the year.
+func BuildYearData(activities []models.Activity, year int, now time.Time) YearData {
+	data := YearData{Year: year, Daily: make(map[string]time.Duration)}
+	durations := make([]time.Duration, 0, 366)
+
+	for month := time.January; month <= time.December; month++ {
+		monthData := BuildMonthData(activities, year, month, now)
+		daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.Local).Day()
+		for day := 1; day <= daysInMonth; day++ {
+			var dur time.Duration
+			if report, ok := monthData.MonthReports[day]; ok {
+				dur = report.TotalDuration
+			}
+			if dur > 0 {
+				data.Daily[DateKey(time.Date(year, month, day, 0, 0, 0, 0, time.Local))] = dur
+			}
+			durations = append(durations, dur)
+		}
+	}
+
+	data.Stats = computeProductivityStats(durations)
+	return data
+}
+
+// Level maps a day's total to 0 (no activity) through HeatLevels, relative to
+// the busiest day of the year.
+func (d YearData) Level(date time.Time) int {
+	dur := d.Daily[DateKey(date)]
+	peak := d.Stats.MaxDailyDuration
+	if dur <= 0 || peak <= 0 {
+		return 0
+	}
+	level := int((dur*HeatLevels + peak - 1) / peak)
+	return min(max(level, 1), HeatLevels)
+}
@@ -0,0 +1,56 @@
+package insights_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kriuchkov/tock/internal/app/insights"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func yearActivity(project string, start time.Time, d time.Duration) models.Activity {
+	end := start.Add(d)
+	return models.Activity{Project: project, StartTime: start, EndTime: &end}
+}
+
+func TestBuildYearDataAggregatesDailyTotalsAndStreaks(t *testing.T) {
+	day := func(month time.Month, d, hour int) time.Time {
+		return time.Date(2026, month, d, hour, 0, 0, 0, time.Local)
+	}
+
+	data := insights.BuildYearData([]models.Activity{
+		yearActivity("tock", day(time.March, 2, 9), 2*time.Hour),
+		yearActivity("tock", day(time.March, 3, 9), time.Hour),
+		yearActivity("ops", day(time.March, 3, 14), time.Hour),
+		yearActivity("ops", day(time.March, 4, 23), 2*time.Hour), // crosses midnight
+		yearActivity("tock", day(time.November, 20, 9), 8*time.Hour),
+		yearActivity("old", time.Date(2025, time.December, 31, 10, 0, 0, 0, time.Local), time.Hour),
+	}, 2026, day(time.December, 31, 23))
+
+	assert.Equal(t, 2026, data.Year)
+	assert.Equal(t, 2*time.Hour, data.Daily["2026-03-03"])
+	assert.Equal(t, time.Hour, data.Daily["2026-03-04"])
+	assert.Equal(t, time.Hour, data.Daily["2026-03-05"])
+	assert.NotContains(t, data.Daily, "2025-12-31")
+
+	assert.Equal(t, 5, data.Stats.ActiveDays)
+	assert.Equal(t, 4, data.Stats.LongestStreak)
+	assert.Equal(t, 14*time.Hour, data.Stats.TotalDuration)
+	assert.Equal(t, 8*time.Hour, data.Stats.MaxDailyDuration)
+}
+
+func TestYearDataLevelIsRelativeToBusiestDay(t *testing.T) {
+	data := insights.BuildYearData([]models.Activity{
+		yearActivity("a", time.Date(2026, time.May, 1, 9, 0, 0, 0, time.Local), 8*time.Hour),
+		yearActivity("a", time.Date(2026, time.May, 2, 9, 0, 0, 0, time.Local), 4*time.Hour),
+		yearActivity("a", time.Date(2026, time.May, 3, 9, 0, 0, 0, time.Local), 10*time.Minute),
+	}, 2026, time.Date(2027, time.January, 1, 0, 0, 0, 0, time.Local))
+
+	assert.Equal(t, insights.HeatLevels, data.Level(time.Date(2026, time.May, 1, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, 2, data.Level(time.Date(2026, time.May, 2, 12, 0, 0, 0, time.Local)))
+	assert.Equal(t, 1, data.Level(time.Date(2026, time.May, 3, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, 0, data.Level(time.Date(2026, time.May, 4, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, 0, insights.YearData{}.Level(time.Now()))
+}
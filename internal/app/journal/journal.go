@@ -0,0 +1,292 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// Ops of the entries that revert or reapply an earlier entry.
+const (
+	OpUndo = "undo"
+	OpRedo = "redo"
+)
+
+// Entry is one line of the journal.
+type Entry struct {
+	Seq     int       `json:"seq"`
+	Time    time.Time `json:"time"`
+	Op      string    `json:"op"` // a models.ChangeOp, OpUndo or OpRedo
+	Changes []Change  `json:"changes,omitempty"`
+	Target  int       `json:"target,omitempty"` // the entry an undo or redo applies to
+}
+
+// Change is a models.ActivityChange as stored in the journal.
+type Change struct {
+	Op     models.ChangeOp  `json:"op"`
+	Before *models.Activity `json:"before,omitempty"`
+	After  *models.Activity `json:"after,omitempty"`
+}
+
+// Subject returns the activity after the change, or before it when the
+// activity was removed.
+func (c Change) Subject() models.Activity {
+	return models.ActivityChange{Op: c.Op, Before: c.Before, After: c.After}.Subject()
+}
+
+// Journal is an append-only JSON Lines file with every change made through
+// the activity service, with snapshots of the activities before and after.
+type Journal struct {
+	path       string
+	maxEntries int
+	now        func() time.Time
+}
+
+// Option customizes a Journal.
+type Option func(*Journal)
+
+// WithMaxEntries rotates the journal once it holds n entries: the file moves
+// to a .1 backup, replacing an older one, and a new file is started. The
+// backup is still read, so at least the last n entries can be undone right
+// after a rotation. Zero keeps every entry.
+func WithMaxEntries(n int) Option {
+	return func(j *Journal) { j.maxEntries = n }
+}
+
+// New returns the journal at path; the file is created on the first change.
+func New(path string, opts ...Option) *Journal {
+	j := &Journal{path: path, now: time.Now}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// Path returns the journal file.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Entries returns every entry of the backup and the current file, oldest
+// first.
+func (j *Journal) Entries() ([]Entry, error) {
+	rotated, err := readEntries(j.backupPath())
+	if err != nil {
+		return nil, err
+	}
+	entries, err := readEntries(j.path)
+	if err != nil {
+		return nil, err
+	}
+	return append(rotated, entries...), nil
+}
+
+func readEntries(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "open journal")
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrapf(err, "parse %s line %d", filepath.Base(path), line)
+		}
+		entries = append(entries, entry)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read journal")
+	}
+	return entries, nil
+}
+
+// ActivityChanged implements ports.ActivityChangeListener. Changes made
+// while reverting an entry are recorded as that undo or redo instead.
+func (j *Journal) ActivityChanged(ctx context.Context, changes []models.ActivityChange) error {
+	if len(changes) == 0 || replaying(ctx) {
+		return nil
+	}
+
+	entry := Entry{Op: string(changes[0].Op)}
+	for _, change := range changes {
+		entry.Changes = append(entry.Changes, Change{Op: change.Op, Before: change.Before, After: change.After})
+	}
+	_, err := j.append(entry)
+	return err
+}
+
+func (j *Journal) append(entry Entry) (Entry, error) {
+	first, last, err := j.bounds(j.path)
+	if err != nil {
+		return entry, err
+	}
+	if last == 0 {
+		// Keep numbering after a rotation.
+		if _, last, err = j.bounds(j.backupPath()); err != nil {
+			return entry, err
+		}
+	}
+	if j.maxEntries > 0 && first > 0 && last-first+1 >= j.maxEntries {
+		if err = os.Rename(j.path, j.backupPath()); err != nil {
+			return entry, errors.Wrap(err, "rotate journal")
+		}
+	}
+	entry.Seq = last + 1
+	entry.Time = j.now()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return entry, errors.Wrap(err, "encode journal entry")
+	}
+	if err = os.MkdirAll(filepath.Dir(j.path), 0750); err != nil {
+		return entry, errors.Wrap(err, "create journal directory")
+	}
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return entry, errors.Wrap(err, "open journal")
+	}
+	defer f.Close()
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		return entry, errors.Wrap(err, "write journal")
+	}
+	return entry, nil
+}
+
+func (j *Journal) backupPath() string {
+	return j.path + ".1"
+}
+
+// journalTailChunk is how much of the end of the file is read at a time to
+// find its last entry.
+const journalTailChunk = 4096
+
+// bounds returns the sequence numbers of the first and the last entry of the
+// journal file at path, reading only its first and last lines, or zeros when
+// the file is missing or empty.
+func (j *Journal) bounds(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, 0, nil
+		}
+		return 0, 0, errors.Wrap(err, "open journal")
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "stat journal")
+	}
+
+	firstLine, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, 0, errors.Wrap(err, "read journal")
+	}
+	if len(bytes.TrimSpace(firstLine)) == 0 {
+		return 0, 0, nil
+	}
+	first, err := entrySeq(firstLine)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Read backwards until the buffer holds the whole last non-empty line.
+	var tail []byte
+	for offset := info.Size(); offset > 0; {
+		size := min(int64(journalTailChunk), offset)
+		offset -= size
+		chunk := make([]byte, size)
+		if _, err = f.ReadAt(chunk, offset); err != nil {
+			return 0, 0, errors.Wrap(err, "read journal")
+		}
+		tail = append(chunk, tail...)
+		trimmed := bytes.TrimRight(tail, "\r\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 || offset == 0 {
+			last, seqErr := entrySeq(trimmed[i+1:])
+			return first, last, seqErr
+		}
+	}
+	return first, first, nil
+}
+
+func entrySeq(line []byte) (int, error) {
+	var entry struct {
+		Seq int `json:"seq"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(line), &entry); err != nil {
+		return 0, errors.Wrap(err, "parse journal entry")
+	}
+	return entry.Seq, nil
+}
+
+// State is where undo and redo stand after replaying the journal.
+type State struct {
+	Entries []Entry
+	Applied []int // entries in effect, oldest first; undo takes the last
+	Undone  []int // entries that can be redone; redo takes the last
+}
+
+// State replays the journal, which WithMaxEntries keeps short. An undo or
+// redo whose target was rotated away is skipped. Like in an
+// editor, a new change after an undo drops what could have been redone.
+func (j *Journal) State() (State, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return State{}, err
+	}
+
+	state := State{Entries: entries}
+	for _, entry := range entries {
+		switch entry.Op {
+		case OpUndo:
+			if i := slices.Index(state.Applied, entry.Target); i >= 0 {
+				state.Applied = slices.Delete(state.Applied, i, i+1)
+				state.Undone = append(state.Undone, entry.Target)
+			}
+		case OpRedo:
+			if i := slices.Index(state.Undone, entry.Target); i >= 0 {
+				state.Undone = slices.Delete(state.Undone, i, i+1)
+				state.Applied = append(state.Applied, entry.Target)
+			}
+		default:
+			state.Applied = append(state.Applied, entry.Seq)
+			state.Undone = nil
+		}
+	}
+	return state, nil
+}
+
+// Entry returns the entry with the sequence number seq.
+func (s State) Entry(seq int) (Entry, bool) {
+	i := slices.IndexFunc(s.Entries, func(e Entry) bool { return e.Seq == seq })
+	if i < 0 {
+		return Entry{}, false
+	}
+	return s.Entries[i], true
+}
+
+// IsUndone reports whether the entry is currently undone.
+func (s State) IsUndone(seq int) bool {
+	return slices.Contains(s.Undone, seq)
+}
@@ -0,0 +1,125 @@
+package journal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/journal"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 3, 2, hour, minute, 0, 0, time.Local)
+}
+
+func TestJournalRecordsChanges(t *testing.T) {
+	j := journal.New(filepath.Join(t.TempDir(), ".tock", "journal.jsonl"))
+	end := at(10, 0)
+	running := models.Activity{Project: "api", Description: "tests", StartTime: at(9, 0), Tags: []string{"ci"}}
+	stopped := running
+	stopped.EndTime = &end
+	started := models.Activity{Project: "web", Description: "css", StartTime: at(10, 0)}
+
+	require.NoError(t, j.ActivityChanged(context.Background(), []models.ActivityChange{
+		{Op: models.ChangeStart, After: &started},
+		{Op: models.ChangeStop, Before: &running, After: &stopped},
+	}))
+	require.NoError(t, j.ActivityChanged(context.Background(), []models.ActivityChange{
+		{Op: models.ChangeRemove, Before: &started},
+	}))
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, 1, entries[0].Seq)
+	assert.Equal(t, "start", entries[0].Op)
+	require.Len(t, entries[0].Changes, 2)
+	assert.Nil(t, entries[0].Changes[0].Before)
+	assert.Equal(t, "css", entries[0].Changes[0].After.Description)
+	assert.Equal(t, []string{"ci"}, entries[0].Changes[1].Before.Tags)
+	assert.Nil(t, entries[0].Changes[1].Before.EndTime)
+	assert.True(t, entries[0].Changes[1].After.EndTime.Equal(end))
+
+	assert.Equal(t, 2, entries[1].Seq)
+	assert.Equal(t, "remove", entries[1].Op)
+	assert.Nil(t, entries[1].Changes[0].After)
+	assert.Equal(t, "css", entries[1].Changes[0].Subject().Description)
+}
+
+func TestJournalRotatesAfterMaxEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j := journal.New(path, journal.WithMaxEntries(2))
+	act := models.Activity{Project: "api", Description: "tests", StartTime: at(9, 0)}
+
+	for range 5 {
+		require.NoError(t, j.ActivityChanged(context.Background(), []models.ActivityChange{
+			{Op: models.ChangeAdd, After: &act},
+		}))
+	}
+
+	backup, err := journal.New(path + ".1").Entries()
+	require.NoError(t, err)
+	require.Len(t, backup, 2)
+	assert.Equal(t, 3, backup[0].Seq)
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3, "the backup is read along with the current file")
+	assert.Equal(t, 5, entries[2].Seq, "numbering continues across rotations")
+
+	state, err := j.State()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5}, state.Applied, "a rotation keeps at least max entries undoable")
+}
+
+func TestJournalMissingFileIsEmpty(t *testing.T) {
+	j := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	state, err := j.State()
+	require.NoError(t, err)
+	assert.Empty(t, state.Applied)
+}
+
+func TestJournalRejectsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"seq\":1,\"op\":\"add\"}\nnot json\n"), 0600))
+
+	_, err := journal.New(path).Entries()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestJournalState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	lines := `{"seq":1,"op":"add"}
+{"seq":2,"op":"add"}
+{"seq":3,"op":"add"}
+{"seq":4,"op":"undo","target":3}
+{"seq":5,"op":"undo","target":2}
+{"seq":6,"op":"redo","target":2}
+`
+	require.NoError(t, os.WriteFile(path, []byte(lines), 0600))
+
+	state, err := journal.New(path).State()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, state.Applied)
+	assert.Equal(t, []int{3}, state.Undone)
+	assert.True(t, state.IsUndone(3))
+
+	require.NoError(t, os.WriteFile(path, []byte(lines+`{"seq":7,"op":"stop"}`+"\n"), 0600))
+	state, err = journal.New(path).State()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 7}, state.Applied)
+	assert.Empty(t, state.Undone, "a new change clears what can be redone")
+}
@@ -0,0 +1,182 @@
+package journal
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+// ErrNothingToUndo and ErrNothingToRedo are returned when the journal has no
+// entry left to revert or reapply.
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+type replayKey struct{}
+
+// replaying reports whether ctx belongs to an undo or redo, whose changes
+// must not be journaled as new entries.
+func replaying(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey{}).(bool)
+	return replay
+}
+
+// Undo reverts the latest entry in effect through svc and records it. svc
+// must notify this journal, so other listeners, such as git commits, see the
+// reverted changes as well.
+func (j *Journal) Undo(ctx context.Context, svc ports.ActivityResolver) (Entry, error) {
+	state, err := j.State()
+	if err != nil {
+		return Entry{}, err
+	}
+	if len(state.Applied) == 0 {
+		return Entry{}, ErrNothingToUndo
+	}
+	return j.revert(ctx, svc, state, state.Applied[len(state.Applied)-1], OpUndo)
+}
+
+// Redo reapplies the entry undone last.
+func (j *Journal) Redo(ctx context.Context, svc ports.ActivityResolver) (Entry, error) {
+	state, err := j.State()
+	if err != nil {
+		return Entry{}, err
+	}
+	if len(state.Undone) == 0 {
+		return Entry{}, ErrNothingToRedo
+	}
+	return j.revert(ctx, svc, state, state.Undone[len(state.Undone)-1], OpRedo)
+}
+
+// revert moves the activities of an entry back to their state before it for
+// an undo, or forward to their state after it for a redo. Nothing is changed
+// when an activity no longer looks like the journal expects, for example
+// because the data file was edited by hand in the meantime.
+func (j *Journal) revert(ctx context.Context, svc ports.ActivityResolver, state State, seq int, op string) (Entry, error) {
+	entry, ok := state.Entry(seq)
+	if !ok {
+		return Entry{}, errors.Errorf("journal entry %d not found", seq)
+	}
+
+	type step struct{ from, to *models.Activity }
+	steps := make([]step, len(entry.Changes))
+	for i, change := range entry.Changes {
+		if op == OpUndo {
+			steps[len(steps)-1-i] = step{from: change.After, to: change.Before}
+		} else {
+			steps[i] = step{from: change.Before, to: change.After}
+		}
+	}
+
+	current, err := svc.List(ctx, models.ActivityFilter{})
+	if err != nil {
+		return Entry{}, errors.Wrap(err, "list activities")
+	}
+	for _, s := range steps {
+		if err = checkCurrent(current, s.from, s.to); err != nil {
+			return Entry{}, errors.Wrapf(err, "%s #%d", op, seq)
+		}
+	}
+
+	ctx = context.WithValue(ctx, replayKey{}, true)
+	// An entry that only edited activities, such as a project rename, is
+	// reverted as one change set again.
+	edits := make([]models.ActivityEdit, 0, len(steps))
+	for _, s := range steps {
+		if s.from == nil || s.to == nil {
+			edits = nil
+			break
+		}
+		edits = append(edits, models.ActivityEdit{Activity: *s.from, Request: editRequest(*s.to)})
+	}
+	if edits != nil {
+		if _, err = svc.EditAll(ctx, edits); err != nil {
+			return Entry{}, errors.Wrapf(err, "%s #%d", op, seq)
+		}
+	} else {
+		for _, s := range steps {
+			if err = apply(ctx, svc, s.from, s.to); err != nil {
+				return Entry{}, errors.Wrapf(err, "%s #%d", op, seq)
+			}
+		}
+	}
+
+	if _, err = j.append(Entry{Op: op, Target: seq}); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// checkCurrent makes sure that the activity is still in the state from, or
+// that nothing is in the way of creating to when from is nil.
+func checkCurrent(current []models.Activity, from, to *models.Activity) error {
+	if from == nil {
+		if found := findByStart(current, *to); found != nil {
+			return errors.Errorf("another activity already starts at %s", to.StartTime.Format("2006-01-02 15:04"))
+		}
+		return nil
+	}
+
+	found := findByStart(current, *from)
+	if found == nil || !sameActivity(*found, *from) {
+		return errors.Errorf("%s: %s was changed since", from.Project, from.Description)
+	}
+	return nil
+}
+
+func apply(ctx context.Context, svc ports.ActivityResolver, from, to *models.Activity) error {
+	if to == nil {
+		if err := svc.Remove(ctx, *from); err != nil {
+			return errors.Wrapf(err, "remove %q", from.Description)
+		}
+		return nil
+	}
+
+	base := to
+	if from != nil {
+		base = from
+	}
+	if _, err := svc.Edit(ctx, *base, editRequest(*to)); err != nil {
+		return errors.Wrapf(err, "restore %q", to.Description)
+	}
+	return nil
+}
+
+func editRequest(to models.Activity) models.EditActivityRequest {
+	return models.EditActivityRequest{
+		Description: to.Description,
+		Project:     to.Project,
+		StartTime:   to.StartTime,
+		EndTime:     to.EndTime,
+		Notes:       to.Notes,
+		Tags:        to.Tags,
+	}
+}
+
+func findByStart(activities []models.Activity, act models.Activity) *models.Activity {
+	for i := range activities {
+		if sameMinute(activities[i].StartTime, act.StartTime) {
+			return &activities[i]
+		}
+	}
+	return nil
+}
+
+// sameActivity compares what the plaintext backends store, to the minute.
+func sameActivity(a, b models.Activity) bool {
+	if a.Project != b.Project || a.Description != b.Description {
+		return false
+	}
+	if a.EndTime == nil || b.EndTime == nil {
+		return a.EndTime == nil && b.EndTime == nil
+	}
+	return sameMinute(*a.EndTime, *b.EndTime)
+}
+
+func sameMinute(a, b time.Time) bool {
+	return a.Truncate(time.Minute).Equal(b.Truncate(time.Minute))
+}
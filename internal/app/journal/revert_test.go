@@ -0,0 +1,123 @@
+package journal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
+	"github.com/kriuchkov/tock/internal/adapters/repositories/notes"
+	"github.com/kriuchkov/tock/internal/app/journal"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+	"github.com/kriuchkov/tock/internal/services/activity"
+)
+
+type journaledService struct {
+	ports.ActivityResolver
+
+	journal  *journal.Journal
+	dataFile string
+}
+
+func newJournaledService(t *testing.T) journaledService {
+	t.Helper()
+	dir := t.TempDir()
+	j := journal.New(filepath.Join(dir, ".tock", "journal.jsonl"))
+	dataFile := filepath.Join(dir, ".tock.txt")
+	svc := activity.NewService(file.NewRepository(dataFile), notes.NewRepository(filepath.Join(dir, ".tock", "notes")), j)
+	return journaledService{ActivityResolver: svc, journal: j, dataFile: dataFile}
+}
+
+func (s journaledService) data(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(s.dataFile)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func (s journaledService) activity(t *testing.T, description string) models.Activity {
+	t.Helper()
+	activities, err := s.List(context.Background(), models.ActivityFilter{Description: &description})
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	return activities[0]
+}
+
+func TestUndoRedoStartThatStoppedAnotherActivity(t *testing.T) {
+	ctx := context.Background()
+	svc := newJournaledService(t)
+
+	_, err := svc.Start(ctx, models.StartActivityRequest{Project: "api", Description: "tests", StartTime: at(9, 0), Tags: []string{"ci"}})
+	require.NoError(t, err)
+	_, err = svc.Start(ctx, models.StartActivityRequest{Project: "web", Description: "css", StartTime: at(10, 0)})
+	require.NoError(t, err)
+	afterStart := svc.data(t)
+
+	entry, err := svc.journal.Undo(ctx, svc)
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.Seq)
+	assert.Equal(t, "2026-03-02 09:00 | api | tests\n", svc.data(t))
+	assert.Equal(t, []string{"ci"}, svc.activity(t, "tests").Tags)
+
+	entry, err = svc.journal.Redo(ctx, svc)
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.Seq)
+	assert.Equal(t, afterStart, svc.data(t))
+
+	state, err := svc.journal.State()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, state.Applied)
+	assert.Len(t, state.Entries, 4, "undo and redo are journaled, their changes are not")
+}
+
+func TestUndoRemoveRestoresNotes(t *testing.T) {
+	ctx := context.Background()
+	svc := newJournaledService(t)
+
+	_, err := svc.Add(ctx, models.AddActivityRequest{Project: "api", Description: "tests", StartTime: at(9, 0), EndTime: at(10, 0)})
+	require.NoError(t, err)
+	_, err = svc.AddNote(ctx, svc.activity(t, "tests"), "flaky on CI")
+	require.NoError(t, err)
+
+	// Remove an activity without its notes, as `tock remove` does for the last one.
+	last, err := svc.GetLast(ctx)
+	require.NoError(t, err)
+	require.NoError(t, svc.Remove(ctx, *last))
+
+	_, err = svc.journal.Undo(ctx, svc)
+	require.NoError(t, err)
+	assert.Equal(t, "flaky on CI", svc.activity(t, "tests").Notes)
+
+	_, err = svc.journal.Undo(ctx, svc)
+	require.NoError(t, err)
+	assert.Empty(t, svc.activity(t, "tests").Notes)
+}
+
+func TestUndoRefusesChangedActivities(t *testing.T) {
+	ctx := context.Background()
+	svc := newJournaledService(t)
+
+	_, err := svc.Add(ctx, models.AddActivityRequest{Project: "api", Description: "tests", StartTime: at(9, 0), EndTime: at(10, 0)})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(svc.dataFile, []byte("2026-03-02 09:00 - 2026-03-02 11:00 | api | tests\n"), 0600))
+
+	_, err = svc.journal.Undo(ctx, svc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api: tests was changed since")
+	assert.Equal(t, "2026-03-02 09:00 - 2026-03-02 11:00 | api | tests\n", svc.data(t))
+}
+
+func TestUndoAndRedoWithEmptyJournal(t *testing.T) {
+	ctx := context.Background()
+	svc := newJournaledService(t)
+
+	_, err := svc.journal.Undo(ctx, svc)
+	require.ErrorIs(t, err, journal.ErrNothingToUndo)
+	_, err = svc.journal.Redo(ctx, svc)
+	require.ErrorIs(t, err, journal.ErrNothingToRedo)
+}
@@ -2,9 +2,12 @@ package localization
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/timeutil"
 )
 
 func TestNewLoadsCatalogs(t *testing.T) {
@@ -26,3 +29,13 @@ func TestNewFallsBackToEnglishForUnsupportedLanguage(t *testing.T) {
 	assert.Equal(t, LanguageEnglish, loc.Language())
 	assert.Equal(t, "Activity description", loc.Text("add.flag.description"))
 }
+
+func TestEnglishTimeInputVocabulary(t *testing.T) {
+	v := timeutil.NewVocabulary(Default().Text)
+	assert.Equal(t, []string{"yesterday"}, v.Yesterday)
+	assert.Equal(t, []string{"ago"}, v.Ago)
+	assert.Equal(t, timeutil.UnitMinute, v.Units["min"])
+	assert.Equal(t, timeutil.UnitHour, v.Units["hours"])
+	assert.Equal(t, time.Friday, v.Weekdays["fri"])
+	assert.Equal(t, time.Monday, v.Weekdays["monday"])
+}
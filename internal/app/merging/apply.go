@@ -0,0 +1,105 @@
+package merging
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+// DecideFunc picks the policy for one conflict.
+type DecideFunc func(Conflict) (Policy, error)
+
+// Result counts what Apply did.
+type Result struct {
+	Added      int // remote activities without a local counterpart
+	Duplicates int // identical intervals, not added again
+	Enriched   int // duplicates that got notes or tags of the remote copy
+	Replaced   int // conflicts resolved with the remote activity
+	KeptBoth   int // conflicts where both sides were kept
+	KeptLocal  int // conflicts resolved with the local activities
+	Running    int // running remote activities that were skipped
+}
+
+// Apply merges the plan into the local activities through svc. Conflicts go
+// to decide in start order. A conflict that keeps both sides while starting
+// in the same minute as a local activity keeps the local one, because the
+// plaintext backends can only store one activity per minute. All conflicts
+// are decided before anything is written, and the merge is applied as one
+// change set, so a refused or failed change leaves the activities as they
+// were instead of half merged.
+func Apply(ctx context.Context, svc ports.ActivityResolver, plan Plan, decide DecideFunc) (Result, error) {
+	result := Result{Duplicates: len(plan.Duplicates), Running: len(plan.Running)}
+	var set models.ChangeSet
+
+	for _, dup := range plan.Duplicates {
+		if !dup.Changed() {
+			continue
+		}
+		set.Edit = append(set.Edit, models.ActivityEdit{Activity: dup.Local, Request: editRequest(dup.Merged)})
+		result.Enriched++
+	}
+
+	for _, act := range plan.Add {
+		set.Add = append(set.Add, addRequest(act))
+		result.Added++
+	}
+
+	removed := make(map[int64]bool)
+	for _, conflict := range plan.Conflicts {
+		policy, err := decide(conflict)
+		if err != nil {
+			return Result{}, err
+		}
+		if policy == PolicyKeepBoth && conflict.SameStart() {
+			policy = PolicyPreferLocal
+		}
+
+		switch policy {
+		case PolicyPreferRemote:
+			for _, local := range conflict.Local {
+				if removed[local.StartTime.UnixNano()] {
+					continue
+				}
+				set.Remove = append(set.Remove, local)
+				removed[local.StartTime.UnixNano()] = true
+			}
+			set.Add = append(set.Add, addRequest(conflict.Remote))
+			result.Replaced++
+		case PolicyKeepBoth:
+			set.Add = append(set.Add, addRequest(conflict.Remote))
+			result.KeptBoth++
+		default:
+			result.KeptLocal++
+		}
+	}
+
+	if err := svc.ApplyChanges(ctx, set); err != nil {
+		return Result{}, errors.Wrap(err, "apply merge")
+	}
+	return result, nil
+}
+
+func addRequest(act models.Activity) models.AddActivityRequest {
+	return models.AddActivityRequest{
+		Description: act.Description,
+		Project:     act.Project,
+		StartTime:   act.StartTime,
+		EndTime:     *act.EndTime,
+		Notes:       act.Notes,
+		Tags:        act.Tags,
+	}
+}
+
+func editRequest(act models.Activity) models.EditActivityRequest {
+	return models.EditActivityRequest{
+		Description: act.Description,
+		Project:     act.Project,
+		StartTime:   act.StartTime,
+		EndTime:     act.EndTime,
+		Notes:       act.Notes,
+		Tags:        act.Tags,
+	}
+}
@@ -0,0 +1,173 @@
+package merging_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
+	"github.com/kriuchkov/tock/internal/adapters/repositories/notes"
+	"github.com/kriuchkov/tock/internal/app/merging"
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+	"github.com/kriuchkov/tock/internal/services/activity"
+)
+
+func newFileService(t *testing.T, activities ...models.Activity) ports.ActivityResolver {
+	t.Helper()
+	dir := t.TempDir()
+	svc := activity.NewService(file.NewRepository(filepath.Join(dir, ".tock.txt")), notes.NewRepository(filepath.Join(dir, "notes")))
+	for _, act := range activities {
+		_, err := svc.Add(context.Background(), models.AddActivityRequest{
+			Description: act.Description,
+			Project:     act.Project,
+			StartTime:   act.StartTime,
+			EndTime:     *act.EndTime,
+			Notes:       act.Notes,
+			Tags:        act.Tags,
+		})
+		require.NoError(t, err)
+	}
+	return svc
+}
+
+func descriptions(t *testing.T, svc ports.ActivityResolver) []string {
+	t.Helper()
+	activities, err := svc.List(context.Background(), models.ActivityFilter{})
+	require.NoError(t, err)
+
+	var result []string
+	for _, act := range models.SortActivitiesByStart(activities) {
+		result = append(result, act.Description)
+	}
+	return result
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		policy merging.Policy
+		want   []string
+		check  func(t *testing.T, result merging.Result)
+	}{
+		{
+			policy: merging.PolicyPreferLocal,
+			want:   []string{"tests", "review", "readme"},
+			check:  func(t *testing.T, result merging.Result) { assert.Equal(t, 1, result.KeptLocal) },
+		},
+		{
+			policy: merging.PolicyPreferRemote,
+			want:   []string{"tests", "deploy", "readme"},
+			check:  func(t *testing.T, result merging.Result) { assert.Equal(t, 1, result.Replaced) },
+		},
+		{
+			policy: merging.PolicyKeepBoth,
+			want:   []string{"tests", "review", "deploy", "readme"},
+			check:  func(t *testing.T, result merging.Result) { assert.Equal(t, 1, result.KeptBoth) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			local := newActivity("api", "tests", at(9, 0), at(10, 0))
+			local.Tags = []string{"backend"}
+			svc := newFileService(t, local, newActivity("api", "review", at(11, 0), at(12, 0)))
+
+			remoteTests := newActivity("api", "tests", at(9, 0), at(10, 0))
+			remoteTests.Tags = []string{"ci"}
+			remote := []models.Activity{
+				remoteTests,
+				newActivity("ops", "deploy", at(11, 30), at(12, 30)),
+				newActivity("docs", "readme", at(13, 0), at(14, 0)),
+			}
+
+			localActivities, err := svc.List(ctx, models.ActivityFilter{})
+			require.NoError(t, err)
+			plan := merging.NewPlan(localActivities, remote, at(18, 0))
+
+			var decided []merging.Conflict
+			result, err := merging.Apply(ctx, svc, plan, func(c merging.Conflict) (merging.Policy, error) {
+				decided = append(decided, c)
+				return tt.policy, nil
+			})
+			require.NoError(t, err)
+
+			assert.Len(t, decided, 1)
+			assert.Equal(t, 1, result.Added)
+			assert.Equal(t, 1, result.Duplicates)
+			assert.Equal(t, 1, result.Enriched)
+			tt.check(t, result)
+			assert.Equal(t, tt.want, descriptions(t, svc))
+
+			merged, err := svc.List(ctx, models.ActivityFilter{})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"backend", "ci"}, models.SortActivitiesByStart(merged)[0].Tags)
+		})
+	}
+}
+
+func TestApplyKeepBothWithSameStartKeepsLocal(t *testing.T) {
+	ctx := context.Background()
+	svc := newFileService(t, newActivity("api", "tests", at(9, 0), at(10, 0)))
+
+	local, err := svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	plan := merging.NewPlan(local, []models.Activity{newActivity("web", "layout", at(9, 0), at(9, 30))}, at(18, 0))
+
+	result, err := merging.Apply(ctx, svc, plan, func(merging.Conflict) (merging.Policy, error) {
+		return merging.PolicyKeepBoth, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.KeptLocal)
+	assert.Equal(t, []string{"tests"}, descriptions(t, svc))
+}
+
+type countingListener struct{ calls [][]models.ActivityChange }
+
+func (l *countingListener) ActivityChanged(_ context.Context, changes []models.ActivityChange) error {
+	l.calls = append(l.calls, changes)
+	return nil
+}
+
+func TestApplyIsOneChangeSetAndRefusesLockedActivities(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	listener := &countingListener{}
+	svc := activity.NewService(file.NewRepository(filepath.Join(dir, ".tock.txt")), notes.NewRepository(filepath.Join(dir, "notes")), listener)
+	for _, act := range []models.Activity{
+		newActivity("api", "tests", at(9, 0), at(10, 0)),
+		newActivity("api", "review", at(11, 0), at(12, 0)),
+	} {
+		_, err := svc.Add(ctx, models.AddActivityRequest{
+			Description: act.Description, Project: act.Project, StartTime: act.StartTime, EndTime: *act.EndTime,
+		})
+		require.NoError(t, err)
+	}
+	listener.calls = nil
+
+	local, err := svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	plan := merging.NewPlan(local, []models.Activity{
+		newActivity("ops", "deploy", at(11, 30), at(12, 30)),
+		newActivity("docs", "readme", at(13, 0), at(14, 0)),
+	}, at(18, 0))
+	preferRemote := func(merging.Conflict) (merging.Policy, error) { return merging.PolicyPreferRemote, nil }
+
+	_, err = merging.Apply(ctx, activity.WithLock(svc, at(11, 15)), plan, preferRemote)
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	assert.Empty(t, listener.calls, "nothing is written when one change is locked")
+	assert.Equal(t, []string{"tests", "review"}, descriptions(t, svc))
+
+	result, err := merging.Apply(ctx, svc, plan, preferRemote)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Replaced)
+	assert.Equal(t, []string{"tests", "deploy", "readme"}, descriptions(t, svc))
+	require.Len(t, listener.calls, 1)
+	assert.Len(t, listener.calls[0], 3)
+}
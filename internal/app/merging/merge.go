@@ -0,0 +1,145 @@
+package merging
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+// Policy decides a conflict between a remote activity and the local
+// activities it overlaps.
+type Policy string
+
+const (
+	PolicyPreferLocal  Policy = "prefer-local"
+	PolicyPreferRemote Policy = "prefer-remote"
+	PolicyKeepBoth     Policy = "keep-both"
+)
+
+// Policies lists the valid policies, in the order they are documented.
+var Policies = []Policy{PolicyPreferLocal, PolicyPreferRemote, PolicyKeepBoth}
+
+// ParsePolicy validates a policy name; the empty name is valid and means the
+// caller decides every conflict itself.
+func ParsePolicy(name string) (Policy, error) {
+	policy := Policy(strings.ToLower(strings.TrimSpace(name)))
+	if policy == "" || slices.Contains(Policies, policy) {
+		return policy, nil
+	}
+	return "", errors.Errorf("unknown merge policy %q (use prefer-local, prefer-remote or keep-both)", name)
+}
+
+// Duplicate is a remote activity that covers the same interval as a local
+// one with the same project and description. Merged is the local activity
+// with the notes and tags of both.
+type Duplicate struct {
+	Local  models.Activity
+	Remote models.Activity
+	Merged models.Activity
+}
+
+// Changed reports whether Merged adds notes or tags to the local activity.
+func (d Duplicate) Changed() bool {
+	return d.Merged.Notes != d.Local.Notes || !slices.Equal(d.Merged.Tags, d.Local.Tags)
+}
+
+// Conflict is a remote activity that overlaps local activities.
+type Conflict struct {
+	Remote models.Activity
+	Local  []models.Activity
+}
+
+// SameStart reports whether the remote activity starts in the same minute as
+// one of the local ones. Plaintext backends identify activities by that
+// minute, so such a conflict cannot keep both.
+func (c Conflict) SameStart() bool {
+	return slices.ContainsFunc(c.Local, func(local models.Activity) bool {
+		return sameMinute(local.StartTime, c.Remote.StartTime)
+	})
+}
+
+// Plan is what merging remote activities into local ones takes.
+type Plan struct {
+	Add        []models.Activity // remote activities that overlap nothing local
+	Duplicates []Duplicate
+	Conflicts  []Conflict
+	Running    []models.Activity // running remote activities, which are not merged
+}
+
+// NewPlan compares remote activities with local ones. Times are compared to
+// the minute, the precision of the plaintext backends. Running local
+// activities are taken to run until now.
+func NewPlan(local, remote []models.Activity, now time.Time) Plan {
+	var plan Plan
+	for _, act := range models.SortActivitiesByStart(remote) {
+		if act.EndTime == nil {
+			plan.Running = append(plan.Running, act)
+			continue
+		}
+
+		if i := slices.IndexFunc(local, func(l models.Activity) bool { return isDuplicate(l, act) }); i >= 0 {
+			plan.Duplicates = append(plan.Duplicates, Duplicate{Local: local[i], Remote: act, Merged: mergeDetails(local[i], act)})
+			continue
+		}
+
+		var overlapping []models.Activity
+		for _, l := range local {
+			if overlaps(l, act, now) {
+				overlapping = append(overlapping, l)
+			}
+		}
+		if len(overlapping) == 0 {
+			plan.Add = append(plan.Add, act)
+			continue
+		}
+		plan.Conflicts = append(plan.Conflicts, Conflict{Remote: act, Local: models.SortActivitiesByStart(overlapping)})
+	}
+	return plan
+}
+
+func isDuplicate(local, remote models.Activity) bool {
+	return local.EndTime != nil &&
+		sameMinute(local.StartTime, remote.StartTime) &&
+		sameMinute(*local.EndTime, *remote.EndTime) &&
+		local.Project == remote.Project &&
+		local.Description == remote.Description
+}
+
+func overlaps(local, remote models.Activity, now time.Time) bool {
+	localEnd := now
+	if local.EndTime != nil {
+		localEnd = *local.EndTime
+	}
+	return local.StartTime.Before(*remote.EndTime) && remote.StartTime.Before(localEnd) ||
+		sameMinute(local.StartTime, remote.StartTime)
+}
+
+func sameMinute(a, b time.Time) bool {
+	return a.Truncate(time.Minute).Equal(b.Truncate(time.Minute))
+}
+
+// mergeDetails adds the notes and tags of remote to local. Notes that differ
+// are both kept, local first.
+func mergeDetails(local, remote models.Activity) models.Activity {
+	merged := local
+	merged.Tags = slices.Clone(local.Tags)
+	for _, tag := range remote.Tags {
+		if !slices.Contains(merged.Tags, tag) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+
+	localNotes, remoteNotes := strings.TrimSpace(local.Notes), strings.TrimSpace(remote.Notes)
+	switch {
+	case remoteNotes == "" || strings.Contains(localNotes, remoteNotes):
+	case localNotes == "" || strings.Contains(remoteNotes, localNotes):
+		merged.Notes = remote.Notes
+	default:
+		merged.Notes = localNotes + "\n\n" + remoteNotes
+	}
+	return merged
+}
@@ -0,0 +1,103 @@
+package merging_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/merging"
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 3, 2, hour, minute, 0, 0, time.Local)
+}
+
+func newActivity(project, description string, start, end time.Time) models.Activity {
+	act := models.Activity{Project: project, Description: description, StartTime: start}
+	if !end.IsZero() {
+		act.EndTime = &end
+	}
+	return act
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := merging.ParsePolicy(" Prefer-Remote ")
+	require.NoError(t, err)
+	assert.Equal(t, merging.PolicyPreferRemote, policy)
+
+	policy, err = merging.ParsePolicy("")
+	require.NoError(t, err)
+	assert.Empty(t, policy)
+
+	_, err = merging.ParsePolicy("newest")
+	require.Error(t, err)
+}
+
+func TestNewPlan(t *testing.T) {
+	local := []models.Activity{
+		newActivity("api", "tests", at(9, 0), at(10, 0)),
+		newActivity("api", "review", at(11, 0), at(12, 0)),
+		newActivity("web", "layout", at(15, 0), time.Time{}),
+	}
+	local[0].Tags = []string{"backend"}
+	local[0].Notes = "flaky"
+
+	dup := newActivity("api", "tests", at(9, 0).Add(30*time.Second), at(10, 0))
+	dup.Tags = []string{"ci", "backend"}
+	dup.Notes = "fixed on desktop"
+
+	remote := []models.Activity{
+		newActivity("docs", "readme", at(13, 0), at(14, 0)),
+		dup,
+		newActivity("ops", "deploy", at(11, 30), at(12, 30)),
+		newActivity("web", "header", at(15, 30), at(16, 0)),
+		newActivity("mail", "inbox", at(17, 0), time.Time{}),
+	}
+
+	plan := merging.NewPlan(local, remote, at(18, 0))
+
+	require.Len(t, plan.Add, 1)
+	assert.Equal(t, "readme", plan.Add[0].Description)
+
+	require.Len(t, plan.Duplicates, 1)
+	merged := plan.Duplicates[0]
+	assert.True(t, merged.Changed())
+	assert.Equal(t, []string{"backend", "ci"}, merged.Merged.Tags)
+	assert.Equal(t, "flaky\n\nfixed on desktop", merged.Merged.Notes)
+	assert.Equal(t, local[0].StartTime, merged.Merged.StartTime)
+
+	require.Len(t, plan.Conflicts, 2)
+	assert.Equal(t, "deploy", plan.Conflicts[0].Remote.Description)
+	assert.Equal(t, "review", plan.Conflicts[0].Local[0].Description)
+	assert.False(t, plan.Conflicts[0].SameStart())
+	assert.Equal(t, "header", plan.Conflicts[1].Remote.Description, "a running local activity runs until now")
+
+	require.Len(t, plan.Running, 1)
+	assert.Equal(t, "inbox", plan.Running[0].Description)
+}
+
+func TestNewPlanDuplicateWithoutNewDetails(t *testing.T) {
+	local := newActivity("api", "tests", at(9, 0), at(10, 0))
+	local.Notes = "flaky\n\nfixed"
+	remote := newActivity("api", "tests", at(9, 0), at(10, 0))
+	remote.Notes = "fixed"
+
+	plan := merging.NewPlan([]models.Activity{local}, []models.Activity{remote}, at(18, 0))
+
+	require.Len(t, plan.Duplicates, 1)
+	assert.False(t, plan.Duplicates[0].Changed())
+}
+
+func TestNewPlanSameIntervalDifferentProjectConflicts(t *testing.T) {
+	local := newActivity("api", "tests", at(9, 0), at(10, 0))
+	remote := newActivity("web", "tests", at(9, 0), at(10, 0))
+
+	plan := merging.NewPlan([]models.Activity{local}, []models.Activity{remote}, at(18, 0))
+
+	assert.Empty(t, plan.Duplicates)
+	require.Len(t, plan.Conflicts, 1)
+	assert.True(t, plan.Conflicts[0].SameStart())
+}
@@ -0,0 +1,83 @@
+// Package periodlock stores the last day of the closed period, whose
+// activities must not change any more, in a sidecar file next to the data.
+package periodlock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// DateLayout is the format of the lock date, in the file and on the command line.
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a lock date as a day in the local time zone.
+func ParseDate(value string) (time.Time, error) {
+	day, err := time.ParseInLocation(DateLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+	}
+	return day, nil
+}
+
+// Boundary returns the first moment after the period that ends on until.
+func Boundary(until time.Time) time.Time {
+	year, month, day := until.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.Local)
+}
+
+// File is the lock file, holding the last locked day.
+type File struct {
+	path string
+}
+
+// New returns the lock file at path.
+func New(path string) *File {
+	return &File{path: path}
+}
+
+// Path returns the lock file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Until returns the last locked day, or the zero time when nothing is locked.
+func (f *File) Until() (time.Time, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "read lock file")
+	}
+	until, err := ParseDate(string(data))
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "parse lock file %s", f.path)
+	}
+	return until, nil
+}
+
+// Set locks everything up to and including the day of until.
+func (f *File) Set(until time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0750); err != nil {
+		return errors.Wrap(err, "create lock directory")
+	}
+	if err := os.WriteFile(f.path, []byte(until.Format(DateLayout)+"\n"), 0600); err != nil {
+		return errors.Wrap(err, "write lock file")
+	}
+	return nil
+}
+
+// Clear removes the lock and reports whether there was one.
+func (f *File) Clear() (bool, error) {
+	if err := os.Remove(f.path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "remove lock file")
+	}
+	return true, nil
+}
@@ -0,0 +1,51 @@
+package periodlock_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/periodlock"
+)
+
+func TestFileSetUntilClear(t *testing.T) {
+	f := periodlock.New(filepath.Join(t.TempDir(), ".tock", "lock"))
+
+	until, err := f.Until()
+	require.NoError(t, err)
+	assert.True(t, until.IsZero(), "a missing file locks nothing")
+
+	require.NoError(t, f.Set(time.Date(2026, time.September, 30, 15, 4, 0, 0, time.Local)))
+	data, err := os.ReadFile(f.Path())
+	require.NoError(t, err)
+	assert.Equal(t, "2026-09-30\n", string(data))
+
+	until, err = f.Until()
+	require.NoError(t, err)
+	assert.True(t, until.Equal(time.Date(2026, time.September, 30, 0, 0, 0, 0, time.Local)))
+
+	cleared, err := f.Clear()
+	require.NoError(t, err)
+	assert.True(t, cleared)
+	cleared, err = f.Clear()
+	require.NoError(t, err)
+	assert.False(t, cleared)
+}
+
+func TestFileRejectsInvalidDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock")
+	require.NoError(t, os.WriteFile(path, []byte("end of september\n"), 0600))
+
+	_, err := periodlock.New(path).Until()
+	require.ErrorContains(t, err, "expected YYYY-MM-DD")
+}
+
+func TestBoundary(t *testing.T) {
+	until, err := periodlock.ParseDate("2026-12-31")
+	require.NoError(t, err)
+	assert.True(t, periodlock.Boundary(until).Equal(time.Date(2027, time.January, 1, 0, 0, 0, 0, time.Local)))
+}
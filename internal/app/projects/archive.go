@@ -0,0 +1,139 @@
+// Package projects renames, merges and archives projects across all
+// activities of a backend.
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// Archive is the sidecar file listing archived projects, one per line.
+// Archiving a project also hides the projects below it.
+type Archive struct {
+	path      string
+	separator string
+}
+
+// NewArchive returns the archive at path for project names nested with
+// separator.
+func NewArchive(path, separator string) *Archive {
+	return &Archive{path: path, separator: separator}
+}
+
+// Path returns the archive file.
+func (a *Archive) Path() string {
+	return a.path
+}
+
+// Projects returns the archived projects, sorted.
+func (a *Archive) Projects() ([]string, error) {
+	data, err := os.ReadFile(a.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "read project archive")
+	}
+
+	var projects []string
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if project := strings.TrimSpace(line); project != "" && !slices.Contains(projects, project) {
+			projects = append(projects, project)
+		}
+	}
+	slices.Sort(projects)
+	return projects, nil
+}
+
+// Hidden returns a function reporting whether a project is archived itself
+// or lies below an archived project.
+func (a *Archive) Hidden() (func(project string) bool, error) {
+	archived, err := a.Projects()
+	if err != nil {
+		return nil, err
+	}
+	return func(project string) bool {
+		for _, name := range archived {
+			if project == name || (a.separator != "" && strings.HasPrefix(project, name+a.separator)) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// Add archives project and reports whether it was not archived yet.
+func (a *Archive) Add(project string) (bool, error) {
+	archived, err := a.Projects()
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(archived, project) {
+		return false, nil
+	}
+	return true, a.write(append(archived, project))
+}
+
+// Remove restores project and reports whether it was archived.
+func (a *Archive) Remove(project string) (bool, error) {
+	archived, err := a.Projects()
+	if err != nil {
+		return false, err
+	}
+	kept := slices.DeleteFunc(slices.Clone(archived), func(name string) bool { return name == project })
+	if len(kept) == len(archived) {
+		return false, nil
+	}
+	return true, a.write(kept)
+}
+
+// Move follows a rename of from to to, for from and the projects below it.
+// When to is already in use, as after a merge, the moved entries are
+// dropped so the target keeps its own state.
+func (a *Archive) Move(from, to string, toInUse bool) error {
+	archived, err := a.Projects()
+	if err != nil {
+		return err
+	}
+
+	moved := make([]string, 0, len(archived))
+	changed := false
+	for _, name := range archived {
+		renamed, ok := Rename(name, from, to, a.separator)
+		if !ok {
+			moved = append(moved, name)
+			continue
+		}
+		changed = true
+		if !toInUse {
+			moved = append(moved, renamed)
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return a.write(moved)
+}
+
+func (a *Archive) write(projects []string) error {
+	slices.Sort(projects)
+	projects = slices.Compact(projects)
+	if len(projects) == 0 {
+		if err := os.Remove(a.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "remove project archive")
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0750); err != nil {
+		return errors.Wrap(err, "create project archive directory")
+	}
+	if err := os.WriteFile(a.path, []byte(strings.Join(projects, "\n")+"\n"), 0600); err != nil {
+		return errors.Wrap(err, "write project archive")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package projects_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/projects"
+)
+
+func TestArchiveAddRemove(t *testing.T) {
+	archive := projects.NewArchive(filepath.Join(t.TempDir(), ".tock", "archived-projects"), "/")
+
+	archived, err := archive.Projects()
+	require.NoError(t, err)
+	assert.Empty(t, archived, "a missing file archives nothing")
+
+	added, err := archive.Add("old")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = archive.Add("acme")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = archive.Add("old")
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	data, err := os.ReadFile(archive.Path())
+	require.NoError(t, err)
+	assert.Equal(t, "acme\nold\n", string(data))
+
+	hidden, err := archive.Hidden()
+	require.NoError(t, err)
+	assert.True(t, hidden("old"))
+	assert.True(t, hidden("acme/api"))
+	assert.False(t, hidden("acme-corp"))
+	assert.False(t, hidden("new"))
+
+	removed, err := archive.Remove("old")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = archive.Remove("acme")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = archive.Remove("acme")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	_, err = os.Stat(archive.Path())
+	assert.ErrorIs(t, err, os.ErrNotExist, "the file is removed with the last project")
+}
+
+func TestArchiveMove(t *testing.T) {
+	archive := projects.NewArchive(filepath.Join(t.TempDir(), "archived-projects"), "/")
+	for _, project := range []string{"acme/legacy", "old"} {
+		_, err := archive.Add(project)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, archive.Move("acme", "acme-corp", false))
+	archived, err := archive.Projects()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-corp/legacy", "old"}, archived)
+
+	require.NoError(t, archive.Move("old", "current", true))
+	archived, err = archive.Projects()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-corp/legacy"}, archived, "a merge keeps the state of the target")
+}
@@ -0,0 +1,177 @@
+package projects
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-faster/errors"
+)
+
+// Wildcard ends a catalog entry that allows every project below it, as in
+// acme/..., like the project filter of report.
+const Wildcard = "..."
+
+// Catalog is the list of valid projects and their short codes. A nil
+// catalog allows every project and resolves no aliases.
+type Catalog struct {
+	allowed []string
+	aliases map[string]string // lower-case alias to project
+	strict  bool
+}
+
+// NewCatalog returns the catalog of allowed projects and aliases. Aliases
+// are matched case-insensitively and their projects are allowed too. In
+// strict mode, Check rejects projects that are not in the catalog.
+func NewCatalog(allowed []string, aliases map[string]string, strict bool) *Catalog {
+	c := &Catalog{aliases: make(map[string]string, len(aliases)), strict: strict}
+	for alias, project := range aliases {
+		alias, project = strings.TrimSpace(alias), strings.TrimSpace(project)
+		if alias == "" || project == "" {
+			continue
+		}
+		c.aliases[strings.ToLower(alias)] = project
+		allowed = append(allowed, project)
+	}
+	for _, project := range allowed {
+		if project = strings.TrimSpace(project); project != "" && !slices.Contains(c.allowed, project) {
+			c.allowed = append(c.allowed, project)
+		}
+	}
+	slices.Sort(c.allowed)
+	return c
+}
+
+// ReadCatalogFile reads a shared catalog: one project per line, or
+// ALIAS = PROJECT to add a short code. Blank lines and lines starting with #
+// are skipped.
+func ReadCatalogFile(path string) ([]string, map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open project catalog")
+	}
+	defer f.Close()
+
+	var allowed []string
+	aliases := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if alias, project, ok := strings.Cut(line, "="); ok {
+			aliases[strings.TrimSpace(alias)] = strings.TrimSpace(project)
+			continue
+		}
+		allowed = append(allowed, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, nil, errors.Wrap(err, "read project catalog")
+	}
+	return allowed, aliases, nil
+}
+
+// Strict reports whether Check rejects projects outside the catalog.
+func (c *Catalog) Strict() bool {
+	return c != nil && c.strict && len(c.allowed) > 0
+}
+
+// Projects returns the allowed projects, sorted. Wildcard entries are
+// included as written.
+func (c *Catalog) Projects() []string {
+	if c == nil {
+		return nil
+	}
+	return slices.Clone(c.allowed)
+}
+
+// Resolve returns the project an alias stands for, or project itself.
+func (c *Catalog) Resolve(project string) string {
+	if c == nil {
+		return project
+	}
+	if resolved, ok := c.aliases[strings.ToLower(strings.TrimSpace(project))]; ok {
+		return resolved
+	}
+	return project
+}
+
+// Allows reports whether project is in the catalog, directly or below a
+// wildcard entry.
+func (c *Catalog) Allows(project string) bool {
+	if c == nil {
+		return true
+	}
+	for _, entry := range c.allowed {
+		if prefix, ok := strings.CutSuffix(entry, Wildcard); ok {
+			if strings.HasPrefix(project, prefix) {
+				return true
+			}
+			continue
+		}
+		if project == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// Check reports whether project may be used. When the catalog is strict and
+// does not allow it, Check also returns the nearest allowed project, or ""
+// when none is close.
+func (c *Catalog) Check(project string) (suggestion string, ok bool) {
+	if !c.Strict() || c.Allows(project) {
+		return "", true
+	}
+	return c.Suggest(project), false
+}
+
+// Suggest returns the allowed project closest to project, or "" when none
+// is close enough to be a typo of it.
+func (c *Catalog) Suggest(project string) string {
+	if c == nil {
+		return ""
+	}
+	needle := strings.ToLower(project)
+	best, bestDistance := "", -1
+	for _, entry := range c.allowed {
+		// acme/... suggests acme.
+		candidate := strings.TrimRightFunc(strings.TrimSuffix(entry, Wildcard), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		distance := levenshtein(needle, strings.ToLower(candidate))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if bestDistance < 0 || bestDistance > max(2, len([]rune(project))/3) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the number of rune insertions, deletions and
+// substitutions that turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
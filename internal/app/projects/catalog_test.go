@@ -0,0 +1,68 @@
+package projects_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/app/projects"
+)
+
+func TestCatalogResolveAndCheck(t *testing.T) {
+	catalog := projects.NewCatalog([]string{"backend", "frontend", "acme/..."}, map[string]string{"BE": "backend", "ops": "operations"}, true)
+
+	assert.Equal(t, "backend", catalog.Resolve("be"))
+	assert.Equal(t, "operations", catalog.Resolve("OPS"))
+	assert.Equal(t, "bakend", catalog.Resolve("bakend"))
+	assert.Equal(t, []string{"acme/...", "backend", "frontend", "operations"}, catalog.Projects())
+
+	_, ok := catalog.Check("backend")
+	assert.True(t, ok)
+	_, ok = catalog.Check("operations")
+	assert.True(t, ok, "the projects of aliases are allowed")
+	_, ok = catalog.Check("acme/api")
+	assert.True(t, ok, "a wildcard allows the projects below it")
+
+	suggestion, ok := catalog.Check("bakend")
+	assert.False(t, ok)
+	assert.Equal(t, "backend", suggestion)
+
+	suggestion, ok = catalog.Check("Acme")
+	assert.False(t, ok)
+	assert.Equal(t, "acme", suggestion)
+
+	suggestion, ok = catalog.Check("marketing")
+	assert.False(t, ok)
+	assert.Empty(t, suggestion, "nothing is close enough")
+}
+
+func TestCatalogNotStrict(t *testing.T) {
+	catalog := projects.NewCatalog([]string{"backend"}, nil, false)
+	_, ok := catalog.Check("anything")
+	assert.True(t, ok)
+	assert.Equal(t, "backend", catalog.Suggest("backnd"))
+
+	var none *projects.Catalog
+	_, ok = none.Check("anything")
+	assert.True(t, ok)
+	assert.Equal(t, "be", none.Resolve("be"))
+
+	_, ok = projects.NewCatalog(nil, nil, true).Check("anything")
+	assert.True(t, ok, "an empty catalog allows everything")
+}
+
+func TestReadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "projects.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# team projects\nbackend\n\nMy Project\nbe = backend\n"), 0600))
+
+	allowed, aliases, err := projects.ReadCatalogFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "My Project"}, allowed)
+	assert.Equal(t, map[string]string{"be": "backend"}, aliases)
+
+	_, _, err = projects.ReadCatalogFile(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
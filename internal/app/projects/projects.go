@@ -0,0 +1,135 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+// Summary is a project with the totals of its activities.
+type Summary struct {
+	Name       string        `json:"name"`
+	Duration   time.Duration `json:"duration"`
+	Activities int           `json:"activities"`
+	FirstUsed  time.Time     `json:"first_used"`
+	LastUsed   time.Time     `json:"last_used"`
+	Archived   bool          `json:"archived"`
+}
+
+// MarshalJSON writes the duration as HH:MM:SS, like the duration of an
+// activity.
+func (s Summary) MarshalJSON() ([]byte, error) {
+	type Alias Summary
+	d := s.Duration.Round(time.Second)
+	return json.Marshal(&struct {
+		Alias
+
+		Duration string `json:"duration"`
+	}{
+		Alias:    (Alias)(s),
+		Duration: fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60),
+	})
+}
+
+// Summarize totals the activities per project, sorted by name. LastUsed is
+// the latest start of an activity.
+func Summarize(activities []models.Activity, hidden func(string) bool) []Summary {
+	byName := make(map[string]*Summary)
+	for _, act := range activities {
+		summary, ok := byName[act.Project]
+		if !ok {
+			summary = &Summary{Name: act.Project, FirstUsed: act.StartTime, LastUsed: act.StartTime}
+			if hidden != nil {
+				summary.Archived = hidden(act.Project)
+			}
+			byName[act.Project] = summary
+		}
+		summary.Duration += act.Duration()
+		summary.Activities++
+		if act.StartTime.Before(summary.FirstUsed) {
+			summary.FirstUsed = act.StartTime
+		}
+		if act.StartTime.After(summary.LastUsed) {
+			summary.LastUsed = act.StartTime
+		}
+	}
+
+	summaries := make([]Summary, 0, len(byName))
+	for _, summary := range byName {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries
+}
+
+// Rename returns project with from replaced by to, when project is from or,
+// with a separator, lies below it.
+func Rename(project, from, to, separator string) (string, bool) {
+	if project == from {
+		return to, true
+	}
+	if separator != "" {
+		if rest, ok := strings.CutPrefix(project, from+separator); ok {
+			return to + separator + rest, true
+		}
+	}
+	return "", false
+}
+
+// InUse reports whether an activity is tracked on project or below it.
+func InUse(activities []models.Activity, project, separator string) bool {
+	for _, act := range activities {
+		if _, ok := Rename(act.Project, project, project, separator); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Move rewrites the project of every activity on one of sources, and below
+// it, to target and returns how many activities were moved from each source.
+// The activities are edited through svc as one change set, so notes stay
+// attached, a locked activity refuses the whole move before anything is
+// written, and the move is a single journal entry for undo.
+func Move(ctx context.Context, svc ports.ActivityResolver, sources []string, target, separator string) ([]int, error) {
+	activities, err := svc.List(ctx, models.ActivityFilter{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list activities")
+	}
+
+	moved := make([]int, len(sources))
+	var edits []models.ActivityEdit
+	for _, act := range activities {
+		for i, source := range sources {
+			project, ok := Rename(act.Project, source, target, separator)
+			if !ok {
+				continue
+			}
+			edits = append(edits, models.ActivityEdit{Activity: act, Request: models.EditActivityRequest{
+				Description: act.Description,
+				Project:     project,
+				StartTime:   act.StartTime,
+				EndTime:     act.EndTime,
+				Notes:       act.Notes,
+				Tags:        act.Tags,
+			}})
+			moved[i]++
+			break
+		}
+	}
+	if len(edits) == 0 {
+		return moved, nil
+	}
+	if _, err = svc.EditAll(ctx, edits); err != nil {
+		return nil, errors.Wrapf(err, "move to %s", target)
+	}
+	return moved, nil
+}
@@ -0,0 +1,124 @@
+package projects_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/file"
+	"github.com/kriuchkov/tock/internal/adapters/repositories/notes"
+	"github.com/kriuchkov/tock/internal/app/projects"
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/services/activity"
+)
+
+func at(day, hour int) time.Time {
+	return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local)
+}
+
+func TestSummarize(t *testing.T) {
+	end := func(tm time.Time) *time.Time { return &tm }
+	activities := []models.Activity{
+		{Project: "backend", StartTime: at(3, 9), EndTime: end(at(3, 11))},
+		{Project: "Backend", StartTime: at(2, 9), EndTime: end(at(2, 10))},
+		{Project: "backend", StartTime: at(1, 9), EndTime: end(at(1, 10))},
+	}
+
+	summaries := projects.Summarize(activities, func(project string) bool { return project == "Backend" })
+	require.Len(t, summaries, 2)
+	assert.Equal(t, projects.Summary{
+		Name: "Backend", Duration: time.Hour, Activities: 1, FirstUsed: at(2, 9), LastUsed: at(2, 9), Archived: true,
+	}, summaries[0])
+	assert.Equal(t, projects.Summary{
+		Name: "backend", Duration: 3 * time.Hour, Activities: 2, FirstUsed: at(1, 9), LastUsed: at(3, 9),
+	}, summaries[1])
+}
+
+func TestRename(t *testing.T) {
+	project, ok := projects.Rename("acme/api", "acme", "acme-corp", "/")
+	assert.True(t, ok)
+	assert.Equal(t, "acme-corp/api", project)
+
+	_, ok = projects.Rename("acme-web", "acme", "acme-corp", "/")
+	assert.False(t, ok)
+	_, ok = projects.Rename("acme/api", "acme", "acme-corp", "")
+	assert.False(t, ok, "without a separator projects are flat")
+}
+
+func TestMoveKeepsNotesAndTags(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	svc := activity.NewService(
+		file.NewRepository(filepath.Join(dir, ".tock.txt")),
+		notes.NewRepository(filepath.Join(dir, ".tock", "notes")),
+	)
+
+	end := at(2, 10)
+	_, err := svc.Add(ctx, models.AddActivityRequest{
+		Project: "Backend", Description: "api", StartTime: at(2, 9), EndTime: end, Notes: "flaky", Tags: []string{"ci"},
+	})
+	require.NoError(t, err)
+	_, err = svc.Start(ctx, models.StartActivityRequest{Project: "Backend/db", Description: "migration", StartTime: at(2, 11)})
+	require.NoError(t, err)
+	_, err = svc.Add(ctx, models.AddActivityRequest{Project: "frontend", Description: "css", StartTime: at(1, 9), EndTime: end})
+	require.NoError(t, err)
+
+	moved, err := projects.Move(ctx, svc, []string{"Backend"}, "backend", "/")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, moved)
+
+	activities, err := svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	byDescription := make(map[string]models.Activity)
+	for _, act := range activities {
+		byDescription[act.Description] = act
+	}
+	assert.Equal(t, "backend", byDescription["api"].Project)
+	assert.Equal(t, "flaky", byDescription["api"].Notes)
+	assert.Equal(t, []string{"ci"}, byDescription["api"].Tags)
+	assert.Equal(t, "backend/db", byDescription["migration"].Project)
+	assert.Nil(t, byDescription["migration"].EndTime, "running activities keep running")
+	assert.Equal(t, "frontend", byDescription["css"].Project)
+}
+
+type countingListener struct{ calls [][]models.ActivityChange }
+
+func (l *countingListener) ActivityChanged(_ context.Context, changes []models.ActivityChange) error {
+	l.calls = append(l.calls, changes)
+	return nil
+}
+
+func TestMoveIsOneChangeSetAndRefusesLockedActivities(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	listener := &countingListener{}
+	svc := activity.NewService(file.NewRepository(filepath.Join(dir, ".tock.txt")), nil, listener)
+
+	for _, day := range []int{1, 2, 3} {
+		_, err := svc.Add(ctx, models.AddActivityRequest{
+			Project: "old", Description: "work", StartTime: at(day, 9), EndTime: at(day, 10),
+		})
+		require.NoError(t, err)
+	}
+	listener.calls = nil
+
+	_, err := projects.Move(ctx, activity.WithLock(svc, at(2, 0)), []string{"old"}, "new", "")
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	assert.Empty(t, listener.calls, "nothing is written when one activity is locked")
+	activities, err := svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	for _, act := range activities {
+		assert.Equal(t, "old", act.Project)
+	}
+
+	moved, err := projects.Move(ctx, svc, []string{"old"}, "new", "")
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, moved)
+	require.Len(t, listener.calls, 1)
+	assert.Len(t, listener.calls[0], 3)
+}
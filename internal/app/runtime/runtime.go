@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"context"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,7 +15,12 @@ import (
 	"github.com/kriuchkov/tock/internal/adapters/repositories/sqlite"
 	"github.com/kriuchkov/tock/internal/adapters/repositories/timewarrior"
 	"github.com/kriuchkov/tock/internal/adapters/repositories/todotxt"
+	"github.com/kriuchkov/tock/internal/app/audit"
+	"github.com/kriuchkov/tock/internal/app/gitsync"
+	"github.com/kriuchkov/tock/internal/app/journal"
 	"github.com/kriuchkov/tock/internal/app/localization"
+	"github.com/kriuchkov/tock/internal/app/periodlock"
+	"github.com/kriuchkov/tock/internal/app/projects"
 	"github.com/kriuchkov/tock/internal/config"
 	"github.com/kriuchkov/tock/internal/core/models"
 	"github.com/kriuchkov/tock/internal/core/ports"
@@ -23,6 +29,7 @@ import (
 )
 
 const (
+	backendFile        = "file"
 	backendTodoTXT     = "todotxt"
 	backendTimewarrior = "timewarrior"
 	backendSqlite      = "sqlite"
@@ -33,6 +40,7 @@ type Request struct {
 	FilePath   string
 	ConfigPath string
 	Language   string
+	Force      bool // ignore the period lock
 }
 
 type contextKey struct{}
@@ -46,6 +54,12 @@ type Runtime struct {
 	TimeFormatter   *timeutil.Formatter
 	Localizer       *localization.Localizer
 	TagColors       map[string]models.TagColor
+	Git             *gitsync.Repo    // nil for backends without a plaintext data file
+	Journal         *journal.Journal // nil when journal.enabled is off
+	Audit           *audit.Log       // recorded to only when audit.enabled is on
+	Lock            *periodlock.File
+	Archive         *projects.Archive
+	Catalog         *projects.Catalog
 }
 
 func (rt *Runtime) WithContext(ctx context.Context) context.Context {
@@ -72,6 +86,13 @@ func Load(ctx context.Context, req Request) (*Runtime, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "init localization")
 	}
+	calendar, err := timeutil.ParseCalendar(cfg.WeekStart, cfg.FiscalYearStart)
+	if err != nil {
+		return nil, errors.Wrap(err, "load config")
+	}
+	timeFormatter := timeutil.NewFormatter(cfg.TimeFormat).
+		WithVocabulary(timeutil.NewVocabulary(loc.Text)).
+		WithCalendar(calendar)
 
 	backend := strings.TrimSpace(req.Backend)
 	if backend == "" {
@@ -84,13 +105,53 @@ func Load(ctx context.Context, req Request) (*Runtime, error) {
 		return nil, err
 	}
 
+	var gitRepo *gitsync.Repo
+	if backend == backendFile || backend == backendTodoTXT {
+		gitRepo = gitsync.NewRepo(filePath, notesPath(filePath))
+	}
+	var listeners []ports.ActivityChangeListener
+	var changeJournal *journal.Journal
+	if cfg.Journal.Enabled {
+		changeJournal = journal.New(resolveJournalPath(cfg.Journal.Path, filePath),
+			journal.WithMaxEntries(cfg.Journal.MaxEntries))
+		listeners = append(listeners, changeJournal)
+	}
+	// The audit log reads the data back through a service of its own, which
+	// has no listeners to notify.
+	auditLog := audit.New(resolveAuditPath(cfg.Audit.Path, filePath), activity.NewService(repo, notesRepo))
+	if cfg.Audit.Enabled {
+		listeners = append(listeners, auditLog)
+	}
+	if cfg.Git.Enabled {
+		if gitRepo == nil {
+			return nil, errors.Errorf("git integration needs the file or todotxt backend, not %s", backend)
+		}
+		listeners = append(listeners, gitsync.NewCommitter(gitRepo))
+	}
+
+	service := activity.NewService(repo, notesRepo, listeners...)
+	sidecarDir := filepath.Dir(notesPath(filePath))
+	lock := periodlock.New(filepath.Join(sidecarDir, "lock"))
+	lockedUntil, err := lock.Until()
+	if err != nil {
+		return nil, err
+	}
+	if !lockedUntil.IsZero() && !req.Force {
+		service = activity.WithLock(service, periodlock.Boundary(lockedUntil))
+	}
+
+	catalog, err := loadCatalog(cfg.Projects, loadedViper.ConfigFileUsed())
+	if err != nil {
+		return nil, err
+	}
+
 	rt := &Runtime{
-		ActivityService: activity.NewService(repo, notesRepo),
+		ActivityService: service,
 		Backend:         backend,
 		DataPath:        filePath,
 		Config:          cfg,
 		Viper:           loadedViper,
-		TimeFormatter:   timeutil.NewFormatter(cfg.TimeFormat),
+		TimeFormatter:   timeFormatter,
 		Localizer:       loc,
 		TagColors: buildTagColors(
 			cfg.Theme.TagColors,
@@ -99,10 +160,36 @@ func Load(ctx context.Context, req Request) (*Runtime, error) {
 			cfg.Timewarrior.ConfigPath,
 			cfg.Timewarrior.UseTockTagColors,
 		),
+		Git:     gitRepo,
+		Journal: changeJournal,
+		Audit:   auditLog,
+		Lock:    lock,
+		Archive: projects.NewArchive(filepath.Join(sidecarDir, "archived-projects"), cfg.Projects.Separator),
+		Catalog: catalog,
 	}
 	return rt, nil
 }
 
+// loadCatalog merges the projects and aliases of the config with those of
+// the catalog file, whose relative path is relative to the config file.
+func loadCatalog(cfg config.ProjectsConfig, configFile string) (*projects.Catalog, error) {
+	allowed := append([]string(nil), cfg.Allowed...)
+	aliases := make(map[string]string, len(cfg.Aliases))
+	if path := expandTilde(strings.TrimSpace(cfg.AllowedFile)); path != "" {
+		if !filepath.IsAbs(path) && configFile != "" {
+			path = filepath.Join(filepath.Dir(configFile), path)
+		}
+		fileAllowed, fileAliases, err := projects.ReadCatalogFile(path)
+		if err != nil {
+			return nil, err
+		}
+		allowed = append(allowed, fileAllowed...)
+		maps.Copy(aliases, fileAliases)
+	}
+	maps.Copy(aliases, cfg.Aliases)
+	return projects.NewCatalog(allowed, aliases, cfg.Strict), nil
+}
+
 // buildTagColors merges per-tag colors from two sources. Config-defined colors
 // are the base; backend-specific colors (e.g. TimeWarrior tags.*.color) are
 // overlaid on top so that the backend's own palette takes precedence unless
@@ -150,20 +237,59 @@ func (rt *Runtime) DefaultExportDir() (string, error) {
 	return filepath.Dir(dataPath), nil
 }
 
-func LoadCompletionService(ctx context.Context, req Request) (ports.ActivityResolver, error) {
-	deps, err := Load(ctx, req)
+// OpenActivityService opens another data source, such as a copy of the data
+// file from another machine. Unlike Load, it ignores the configuration, so
+// changes to it are not committed to git.
+func OpenActivityService(ctx context.Context, backend, filePath string) (ports.ActivityResolver, error) {
+	repo, notesRepo, err := initRepositories(ctx, backend, expandTilde(filePath))
 	if err != nil {
 		return nil, err
 	}
-	return deps.ActivityService, nil
+	return activity.NewService(repo, notesRepo), nil
 }
 
-func initRepositories(ctx context.Context, backend, filePath string) (ports.ActivityRepository, ports.NotesRepository, error) {
+// LineParser returns the parser for one line of the data file of a
+// plaintext backend.
+func LineParser(backend string) (gitsync.ParseFunc, bool) {
+	switch backend {
+	case backendFile, "":
+		return file.ParseActivity, true
+	case backendTodoTXT:
+		return todotxt.ParseActivity, true
+	default:
+		return nil, false
+	}
+}
+
+// notesPath is the notes directory next to the data file.
+func notesPath(filePath string) string {
 	notesBase := filePath
 	if notesBase == "" {
 		notesBase, _ = os.UserHomeDir()
 	}
-	notesPath := filepath.Join(filepath.Dir(notesBase), ".tock", "notes")
+	return filepath.Join(filepath.Dir(notesBase), ".tock", "notes")
+}
+
+// resolveJournalPath returns the configured journal, or the one next to the
+// notes directory.
+func resolveJournalPath(configured, filePath string) string {
+	if configured != "" {
+		return expandTilde(configured)
+	}
+	return filepath.Join(filepath.Dir(notesPath(filePath)), "journal.jsonl")
+}
+
+// resolveAuditPath returns the configured audit log, or the one next to the
+// notes directory.
+func resolveAuditPath(configured, filePath string) string {
+	if configured != "" {
+		return expandTilde(configured)
+	}
+	return filepath.Join(filepath.Dir(notesPath(filePath)), "audit.jsonl")
+}
+
+func initRepositories(ctx context.Context, backend, filePath string) (ports.ActivityRepository, ports.NotesRepository, error) {
+	notesPath := notesPath(filePath)
 
 	switch backend {
 	case backendTodoTXT:
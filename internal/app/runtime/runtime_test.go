@@ -1,14 +1,17 @@
 package runtime
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kriuchkov/tock/internal/config"
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
 	"github.com/kriuchkov/tock/internal/core/models"
 )
 
@@ -57,6 +60,19 @@ func TestResolveFilePathExplicitOverrideExpandsTilde(t *testing.T) {
 	assert.Equal(t, filepath.Join(home, ".local/share/timewarrior/data"), got)
 }
 
+func TestResolveJournalPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, "/data/.tock/journal.jsonl", resolveJournalPath("", "/data/.tock.txt"))
+	assert.Equal(t, filepath.Join(home, "journal.jsonl"), resolveJournalPath("~/journal.jsonl", "/data/.tock.txt"))
+}
+
+func TestResolveAuditPath(t *testing.T) {
+	assert.Equal(t, "/data/.tock/audit.jsonl", resolveAuditPath("", "/data/.tock.txt"))
+	assert.Equal(t, "/srv/audit.jsonl", resolveAuditPath("/srv/audit.jsonl", "/data/.tock.txt"))
+}
+
 func TestBuildTagColors_ConfigOnly(t *testing.T) {
 	cfgColors := map[string]string{"Work": "3", "Coding": "33"}
 	got := buildTagColors(cfgColors, "file", "/irrelevant/path", "", false)
@@ -120,3 +136,51 @@ func TestBuildTagColors_BothEmpty(t *testing.T) {
 	got := buildTagColors(nil, "file", "", "", false)
 	assert.Nil(t, got)
 }
+
+func TestLoadEnforcesPeriodLockUnlessForced(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tock.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("backend: file\n"), 0600))
+	req := Request{FilePath: filepath.Join(dir, ".tock.txt"), ConfigPath: configPath}
+
+	rt, err := Load(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".tock", "lock"), rt.Lock.Path())
+	assert.Equal(t, filepath.Join(dir, ".tock", "archived-projects"), rt.Archive.Path())
+	require.NoError(t, rt.Lock.Set(time.Date(2026, time.September, 30, 0, 0, 0, 0, time.Local)))
+
+	add := models.AddActivityRequest{
+		Project:   "api",
+		StartTime: time.Date(2026, time.September, 30, 9, 0, 0, 0, time.Local),
+		EndTime:   time.Date(2026, time.September, 30, 10, 0, 0, 0, time.Local),
+	}
+	rt, err = Load(context.Background(), req)
+	require.NoError(t, err)
+	_, err = rt.ActivityService.Add(context.Background(), add)
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+
+	req.Force = true
+	rt, err = Load(context.Background(), req)
+	require.NoError(t, err)
+	_, err = rt.ActivityService.Add(context.Background(), add)
+	require.NoError(t, err)
+}
+
+func TestLoadCatalogMergesFileAndConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "projects.txt"), []byte("backend\nbe = backend-old\n"), 0600))
+
+	catalog, err := loadCatalog(config.ProjectsConfig{
+		Allowed:     []string{"frontend"},
+		AllowedFile: "projects.txt",
+		Strict:      true,
+		Aliases:     map[string]string{"be": "backend"},
+	}, filepath.Join(dir, "tock.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "frontend"}, catalog.Projects())
+	assert.Equal(t, "backend", catalog.Resolve("be"), "aliases in the config win over the file")
+	assert.True(t, catalog.Strict())
+
+	_, err = loadCatalog(config.ProjectsConfig{AllowedFile: "missing.txt"}, filepath.Join(dir, "tock.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+// Package tags renames, merges and deletes tags across all activities,
+// whether a backend keeps them in its records or in the notes.
+package tags
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/go-faster/errors"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+// Summary is a tag with the number of activities that carry it.
+type Summary struct {
+	Name       string `json:"name"`
+	Activities int    `json:"activities"`
+	Color      string `json:"color,omitempty"`
+	Background string `json:"background,omitempty"`
+}
+
+// Summarize counts the activities per tag, most used first, with the color
+// of each tag from colors.
+func Summarize(activities []models.Activity, colors map[string]models.TagColor) []Summary {
+	counts := make(map[string]int)
+	for _, act := range activities {
+		for _, tag := range act.Tags {
+			counts[tag]++
+		}
+	}
+
+	summaries := make([]Summary, 0, len(counts))
+	for name, count := range counts {
+		color := colors[name]
+		summaries = append(summaries, Summary{Name: name, Activities: count, Color: color.FG, Background: color.BG})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Activities != summaries[j].Activities {
+			return summaries[i].Activities > summaries[j].Activities
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
+
+// Rewrite maps a tag to its new name, or reports false to drop it.
+type Rewrite func(tag string) (string, bool)
+
+// Rename rewrites each of sources to target.
+func Rename(sources []string, target string) Rewrite {
+	return func(tag string) (string, bool) {
+		if slices.Contains(sources, tag) {
+			return target, true
+		}
+		return tag, true
+	}
+}
+
+// Delete drops names.
+func Delete(names []string) Rewrite {
+	return func(tag string) (string, bool) {
+		return tag, !slices.Contains(names, tag)
+	}
+}
+
+// Change is an activity whose tags a rewrite changes.
+type Change struct {
+	Activity models.Activity
+	Tags     []string // the tags after the change
+}
+
+// Plan returns the activities that rewrite changes, in the order of
+// activities. A tag that a rewrite produces twice is kept once.
+func Plan(activities []models.Activity, rewrite Rewrite) []Change {
+	var changes []Change
+	for _, act := range activities {
+		tags := make([]string, 0, len(act.Tags))
+		for _, tag := range act.Tags {
+			if renamed, keep := rewrite(tag); keep && !slices.Contains(tags, renamed) {
+				tags = append(tags, renamed)
+			}
+		}
+		if !slices.Equal(tags, act.Tags) {
+			changes = append(changes, Change{Activity: act, Tags: tags})
+		}
+	}
+	return changes
+}
+
+// Apply saves the changes through svc as one change set, which writes the
+// tags to the backend and to the notes and journals them as a single entry.
+// A change to an activity in a locked period refuses the whole set before
+// anything is written. It returns how many activities were changed.
+func Apply(ctx context.Context, svc ports.ActivityResolver, changes []Change) (int, error) {
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	edits := make([]models.ActivityEdit, len(changes))
+	for i, change := range changes {
+		act := change.Activity
+		edits[i] = models.ActivityEdit{Activity: act, Request: models.EditActivityRequest{
+			Description: act.Description,
+			Project:     act.Project,
+			StartTime:   act.StartTime,
+			EndTime:     act.EndTime,
+			Notes:       act.Notes,
+			Tags:        change.Tags,
+		}}
+	}
+	if _, err := svc.EditAll(ctx, edits); err != nil {
+		return 0, errors.Wrap(err, "change tags")
+	}
+	return len(changes), nil
+}
@@ -0,0 +1,125 @@
+package tags_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kriuchkov/tock/internal/adapters/repositories/notes"
+	"github.com/kriuchkov/tock/internal/adapters/repositories/timewarrior"
+	"github.com/kriuchkov/tock/internal/app/tags"
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/services/activity"
+)
+
+func at(hour int) time.Time {
+	return time.Date(2026, 3, 2, hour, 0, 0, 0, time.Local)
+}
+
+func TestSummarize(t *testing.T) {
+	activities := []models.Activity{
+		{Tags: []string{"ci", "urgent"}},
+		{Tags: []string{"ci"}},
+		{Tags: []string{"bug"}},
+	}
+
+	summaries := tags.Summarize(activities, map[string]models.TagColor{"ci": {FG: "2", BG: "0"}})
+	assert.Equal(t, []tags.Summary{
+		{Name: "ci", Activities: 2, Color: "2", Background: "0"},
+		{Name: "bug", Activities: 1},
+		{Name: "urgent", Activities: 1},
+	}, summaries)
+}
+
+func TestPlan(t *testing.T) {
+	activities := []models.Activity{
+		{Description: "a", Tags: []string{"CI", "ci", "urgent"}},
+		{Description: "b", Tags: []string{"bug"}},
+		{Description: "c", Tags: []string{"wip"}},
+	}
+
+	changes := tags.Plan(activities, tags.Rename([]string{"CI"}, "ci"))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "a", changes[0].Activity.Description)
+	assert.Equal(t, []string{"ci", "urgent"}, changes[0].Tags, "merged tags are kept once")
+
+	changes = tags.Plan(activities, tags.Delete([]string{"wip", "bug"}))
+	require.Len(t, changes, 2)
+	assert.Empty(t, changes[0].Tags)
+	assert.Empty(t, changes[1].Tags)
+}
+
+func TestApplyRewritesBackendAndNotes(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo := timewarrior.NewRepository(filepath.Join(dir, "data"))
+	svc := activity.NewService(repo, notes.NewRepository(filepath.Join(dir, ".tock", "notes")))
+
+	end := at(10)
+	_, err := svc.Add(ctx, models.AddActivityRequest{
+		Project: "api", Description: "tests", StartTime: at(9), EndTime: end, Tags: []string{"CI"}, Notes: "flaky",
+	})
+	require.NoError(t, err)
+	_, err = svc.Add(ctx, models.AddActivityRequest{Project: "api", Description: "docs", StartTime: at(11), EndTime: at(12)})
+	require.NoError(t, err)
+
+	activities, err := svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	changed, err := tags.Apply(ctx, svc, tags.Plan(activities, tags.Rename([]string{"CI"}, "ci")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+
+	stored, err := repo.Find(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, []string{"ci"}, stored[0].Tags, "the backend record is rewritten")
+
+	activities, err = svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ci"}, activities[0].Tags, "the notes are rewritten")
+	assert.Equal(t, "flaky", activities[0].Notes)
+}
+
+type countingListener struct{ calls [][]models.ActivityChange }
+
+func (l *countingListener) ActivityChanged(_ context.Context, changes []models.ActivityChange) error {
+	l.calls = append(l.calls, changes)
+	return nil
+}
+
+func TestApplyIsOneChangeSetAndRefusesLockedActivities(t *testing.T) {
+	ctx := context.Background()
+	listener := &countingListener{}
+	svc := activity.NewService(timewarrior.NewRepository(filepath.Join(t.TempDir(), "data")), nil, listener)
+
+	for _, hour := range []int{9, 11, 13} {
+		_, err := svc.Add(ctx, models.AddActivityRequest{
+			Project: "api", Description: "work", StartTime: at(hour), EndTime: at(hour + 1), Tags: []string{"wip"},
+		})
+		require.NoError(t, err)
+	}
+	listener.calls = nil
+	activities, err := svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	changes := tags.Plan(activities, tags.Delete([]string{"wip"}))
+
+	_, err = tags.Apply(ctx, activity.WithLock(svc, at(12)), changes)
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	assert.Empty(t, listener.calls, "nothing is written when one activity is locked")
+	activities, err = svc.List(ctx, models.ActivityFilter{})
+	require.NoError(t, err)
+	for _, act := range activities {
+		assert.Equal(t, []string{"wip"}, act.Tags)
+	}
+
+	changed, err := tags.Apply(ctx, svc, changes)
+	require.NoError(t, err)
+	assert.Equal(t, 3, changed)
+	require.Len(t, listener.calls, 1)
+	assert.Len(t, listener.calls[0], 3)
+}
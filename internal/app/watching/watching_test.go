@@ -70,6 +70,18 @@ func (s stubResolver) AddTags(context.Context, models.Activity, []string) (*mode
 	return nil, unconfiguredResolverCall()
 }
 
+func (s stubResolver) Edit(context.Context, models.Activity, models.EditActivityRequest) (*models.Activity, error) {
+	return nil, unconfiguredResolverCall()
+}
+
+func (s stubResolver) EditAll(context.Context, []models.ActivityEdit) ([]models.Activity, error) {
+	return nil, unconfiguredResolverCall()
+}
+
+func (s stubResolver) ApplyChanges(context.Context, models.ChangeSet) error {
+	return unconfiguredResolverCall()
+}
+
 func (s stubResolver) Remove(context.Context, models.Activity) error {
 	return unconfiguredResolverCall()
 }
@@ -22,7 +22,15 @@ type Config struct {
 	Calendar        CalendarConfig     `mapstructure:"calendar"`
 	TimeFormat      string             `mapstructure:"time_format"`
 	Export          ExportConfig       `mapstructure:"export"`
+	Import          ImportConfig       `mapstructure:"import"`
+	Sync            SyncConfig         `mapstructure:"sync"`
+	Git             GitConfig          `mapstructure:"git"`
+	Journal         JournalConfig      `mapstructure:"journal"`
+	Audit           AuditConfig        `mapstructure:"audit"`
+	Projects        ProjectsConfig     `mapstructure:"projects"`
 	WeeklyTarget    time.Duration      `mapstructure:"weekly_target"`
+	WeekStart       string             `mapstructure:"week_start"`        // weekday that weeks start on
+	FiscalYearStart string             `mapstructure:"fiscal_year_start"` // month that years and quarters start in
 	CheckUpdates    bool               `mapstructure:"check_updates"`
 	LastUpdateCheck time.Time          `mapstructure:"last_update_check"`
 }
@@ -36,11 +44,98 @@ type CalendarConfig struct {
 }
 
 type ExportConfig struct {
-	ICal ICalConfig `mapstructure:"ical"`
+	ICal         ICalConfig `mapstructure:"ical"`
+	CSV          CSVConfig  `mapstructure:"csv"`
+	TemplatesDir string     `mapstructure:"templates_dir"` // overrides for report.md.tmpl and report.html.tmpl
+}
+
+// CSVConfig holds the defaults for `tock export -m csv`; flags override them.
+type CSVConfig struct {
+	Columns   string `mapstructure:"columns"`   // comma-separated column names
+	Delimiter string `mapstructure:"delimiter"` // single character or "tab"
+	Decimal   string `mapstructure:"decimal"`   // "." or ","
+	Timezone  string `mapstructure:"timezone"`  // IANA name; empty keeps stored offsets
+	Header    bool   `mapstructure:"header"`
+}
+
+type ImportConfig struct {
+	ICal ICalImportConfig `mapstructure:"ical"`
+}
+
+// ICalImportConfig controls how `tock import --format ical` maps events.
+type ICalImportConfig struct {
+	Project  string           `mapstructure:"project"`  // for events without a rule or category
+	Attendee string           `mapstructure:"attendee"` // your address; events you declined are skipped
+	Tags     []string         `mapstructure:"tags"`     // added to every imported activity
+	Rules    []ICalImportRule `mapstructure:"rules"`
+}
+
+// ICalImportRule maps matching events; the first matching rule wins.
+type ICalImportRule struct {
+	Match       string   `mapstructure:"match"`    // regular expression on the event summary
+	Category    string   `mapstructure:"category"` // event category, case-insensitive
+	Project     string   `mapstructure:"project"`
+	Description string   `mapstructure:"description"`
+	Tags        []string `mapstructure:"tags"`
+	Skip        bool     `mapstructure:"skip"`
+}
+
+// GitConfig keeps the plaintext data file in git: every change is committed
+// and `tock sync` pulls and pushes.
+type GitConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Remote  string `mapstructure:"remote"` // remote name or URL
+	Branch  string `mapstructure:"branch"` // defaults to the current branch
+}
+
+// JournalConfig configures the operation journal behind `tock undo`,
+// `tock redo` and `tock history`.
+type JournalConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Path       string `mapstructure:"path"`        // defaults to .tock/journal.jsonl next to the data
+	MaxEntries int    `mapstructure:"max_entries"` // rotate after this many entries; 0 keeps all
+}
+
+// AuditConfig configures the hash-chained audit log checked by
+// `tock audit verify`.
+type AuditConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"` // defaults to .tock/audit.jsonl next to the data
+}
+
+// ProjectsConfig configures how project names are read.
+type ProjectsConfig struct {
+	// Separator splits names such as acme/api/auth into a hierarchy whose
+	// durations roll up to the parent projects. Empty, the default, keeps
+	// projects flat.
+	Separator string `mapstructure:"separator"`
+	// Allowed is the catalog of valid projects; an entry ending in /...
+	// allows every project below it.
+	Allowed []string `mapstructure:"allowed"`
+	// AllowedFile is a shared catalog with one project per line, or
+	// ALIAS = PROJECT; a relative path is relative to the config file.
+	AllowedFile string `mapstructure:"allowed_file"`
+	// Strict makes start, add and continue reject projects outside the catalog.
+	Strict bool `mapstructure:"strict"`
+	// Aliases maps short codes, matched case-insensitively, to projects.
+	Aliases map[string]string `mapstructure:"aliases"`
+}
+
+type SyncConfig struct {
+	CalDAV CalDAVConfig `mapstructure:"caldav"`
+}
+
+// CalDAVConfig configures `tock sync caldav`.
+type CalDAVConfig struct {
+	URL       string `mapstructure:"url"` // calendar collection, e.g. https://dav.example.com/calendars/me/work/
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+	StateFile string `mapstructure:"state_file"` // what was pushed, to update and delete events later
 }
 
 type ICalConfig struct {
-	FileName string `mapstructure:"file_name"`
+	FileName     string `mapstructure:"file_name"`
+	CalendarName string `mapstructure:"calendar_name"`
 }
 
 type FileConfig struct {
@@ -137,7 +232,21 @@ func Load(opts ...Option) (*Config, *viper.Viper, error) {
 	v.SetDefault("language", "eng")
 	v.SetDefault("time_format", "24")
 	v.SetDefault("export.ical.file_name", "tock_export.ics")
+	v.SetDefault("export.ical.calendar_name", "Tock")
+	v.SetDefault("export.csv.columns", "project,description,start_time,end_time,duration_minutes")
+	v.SetDefault("export.csv.delimiter", ",")
+	v.SetDefault("export.csv.decimal", ".")
+	v.SetDefault("export.csv.header", true)
 	v.SetDefault("check_updates", true)
+	v.SetDefault("week_start", "monday")
+	v.SetDefault("fiscal_year_start", "january")
+	v.SetDefault("git.enabled", false)
+	v.SetDefault("git.remote", "origin")
+	v.SetDefault("journal.enabled", true)
+	v.SetDefault("journal.max_entries", 1000)
+	v.SetDefault("audit.enabled", false)
+	v.SetDefault("projects.separator", "")
+	v.SetDefault("projects.strict", false)
 	v.SetDefault("working_hours.enabled", false)
 	v.SetDefault("working_hours.stop_at", "")
 	v.SetDefault("working_hours.weekdays", "mon,tue,wed,thu,fri")
@@ -151,6 +260,8 @@ func Load(opts ...Option) (*Config, *viper.Viper, error) {
 	if homeDir != "" {
 		v.SetDefault("file.path", filepath.Join(homeDir, ".tock.txt"))
 		v.SetDefault("sqlite.path", filepath.Join(homeDir, ".tock.db"))
+		v.SetDefault("export.templates_dir", filepath.Join(homeDir, ".config", "tock", "templates"))
+		v.SetDefault("sync.caldav.state_file", filepath.Join(homeDir, ".config", "tock", "caldav_state.json"))
 	}
 
 	// Explicit Bindings for all supported variables
@@ -165,7 +276,33 @@ func Load(opts ...Option) (*Config, *viper.Viper, error) {
 	_ = v.BindEnv("sqlite.path", "TOCK_SQLITE_PATH")
 	_ = v.BindEnv("file.path", "TOCK_FILE", "TOCK_FILE_PATH")
 	_ = v.BindEnv("time_format", "TOCK_TIME_FORMAT")
+	_ = v.BindEnv("week_start", "TOCK_WEEK_START")
+	_ = v.BindEnv("fiscal_year_start", "TOCK_FISCAL_YEAR_START")
 	_ = v.BindEnv("export.ical.file_name", "TOCK_EXPORT_ICAL_FILE_NAME")
+	_ = v.BindEnv("export.ical.calendar_name", "TOCK_EXPORT_ICAL_CALENDAR_NAME")
+	_ = v.BindEnv("export.templates_dir", "TOCK_EXPORT_TEMPLATES_DIR")
+	_ = v.BindEnv("import.ical.project", "TOCK_IMPORT_ICAL_PROJECT")
+	_ = v.BindEnv("import.ical.attendee", "TOCK_IMPORT_ICAL_ATTENDEE")
+	_ = v.BindEnv("git.enabled", "TOCK_GIT_ENABLED")
+	_ = v.BindEnv("git.remote", "TOCK_GIT_REMOTE")
+	_ = v.BindEnv("git.branch", "TOCK_GIT_BRANCH")
+	_ = v.BindEnv("journal.enabled", "TOCK_JOURNAL_ENABLED")
+	_ = v.BindEnv("journal.path", "TOCK_JOURNAL_PATH")
+	_ = v.BindEnv("journal.max_entries", "TOCK_JOURNAL_MAX_ENTRIES")
+	_ = v.BindEnv("audit.enabled", "TOCK_AUDIT_ENABLED")
+	_ = v.BindEnv("audit.path", "TOCK_AUDIT_PATH")
+	_ = v.BindEnv("projects.separator", "TOCK_PROJECTS_SEPARATOR")
+	_ = v.BindEnv("projects.allowed_file", "TOCK_PROJECTS_ALLOWED_FILE")
+	_ = v.BindEnv("projects.strict", "TOCK_PROJECTS_STRICT")
+	_ = v.BindEnv("sync.caldav.url", "TOCK_SYNC_CALDAV_URL")
+	_ = v.BindEnv("sync.caldav.username", "TOCK_SYNC_CALDAV_USERNAME")
+	_ = v.BindEnv("sync.caldav.password", "TOCK_SYNC_CALDAV_PASSWORD")
+	_ = v.BindEnv("sync.caldav.state_file", "TOCK_SYNC_CALDAV_STATE_FILE")
+	_ = v.BindEnv("export.csv.columns", "TOCK_EXPORT_CSV_COLUMNS")
+	_ = v.BindEnv("export.csv.delimiter", "TOCK_EXPORT_CSV_DELIMITER")
+	_ = v.BindEnv("export.csv.decimal", "TOCK_EXPORT_CSV_DECIMAL")
+	_ = v.BindEnv("export.csv.timezone", "TOCK_EXPORT_CSV_TIMEZONE")
+	_ = v.BindEnv("export.csv.header", "TOCK_EXPORT_CSV_HEADER")
 	_ = v.BindEnv("theme.name", "TOCK_THEME", "TOCK_THEME_NAME")
 	_ = v.BindEnv("theme.primary", "TOCK_COLOR_PRIMARY")
 	_ = v.BindEnv("theme.secondary", "TOCK_COLOR_SECONDARY")
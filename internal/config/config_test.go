@@ -205,3 +205,189 @@ func TestTimewarriorUseTockTagColorsScopedEnvOverrides(t *testing.T) {
 	assert.True(t, cfg.Timewarrior.UseTockTagColorsWeeklyActivity)
 	assert.False(t, cfg.Timewarrior.UseTockTagColorsTopProjects)
 }
+
+func TestExportTemplatesDirDefaultAndEnvOverride(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TOCK_EXPORT_TEMPLATES_DIR", "")
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".config", "tock", "templates"), cfg.Export.TemplatesDir)
+
+	t.Setenv("TOCK_EXPORT_TEMPLATES_DIR", "/custom/templates")
+	cfg, _, err = Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, "/custom/templates", cfg.Export.TemplatesDir)
+}
+
+func TestExportCSVDefaultsAndConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, "project,description,start_time,end_time,duration_minutes", cfg.Export.CSV.Columns)
+	assert.Equal(t, ",", cfg.Export.CSV.Delimiter)
+	assert.Equal(t, ".", cfg.Export.CSV.Decimal)
+	assert.True(t, cfg.Export.CSV.Header)
+	assert.Equal(t, "Tock", cfg.Export.ICal.CalendarName)
+
+	configPath := filepath.Join(t.TempDir(), "tock.yaml")
+	err = os.WriteFile(configPath, []byte(
+		"export:\n  csv:\n    columns: id,project,duration_hours\n    delimiter: \";\"\n    decimal: \",\"\n    timezone: UTC\n    header: false\n",
+	), 0600)
+	require.NoError(t, err)
+
+	cfg, _, err = Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.Equal(t, CSVConfig{Columns: "id,project,duration_hours", Delimiter: ";", Decimal: ",", Timezone: "UTC"}, cfg.Export.CSV)
+}
+
+func TestImportICalRulesFromConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TOCK_IMPORT_ICAL_ATTENDEE", "me@example.com")
+
+	configPath := filepath.Join(t.TempDir(), "tock.yaml")
+	err := os.WriteFile(configPath, []byte(
+		"import:\n  ical:\n    project: calls\n    tags: [meeting]\n    rules:\n"+
+			"      - match: \"^\\\\[(\\\\w+)\\\\] (.+)$\"\n        project: $1\n        description: $2\n"+
+			"      - category: Lunch\n        skip: true\n",
+	), 0600)
+	require.NoError(t, err)
+
+	cfg, _, err := Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.Equal(t, ICalImportConfig{
+		Project:  "calls",
+		Attendee: "me@example.com",
+		Tags:     []string{"meeting"},
+		Rules: []ICalImportRule{
+			{Match: `^\[(\w+)\] (.+)$`, Project: "$1", Description: "$2"},
+			{Category: "Lunch", Skip: true},
+		},
+	}, cfg.Import.ICal)
+}
+
+func TestSyncCalDAVDefaultsAndEnvOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TOCK_SYNC_CALDAV_URL", "https://dav.example.com/cal/")
+	t.Setenv("TOCK_SYNC_CALDAV_PASSWORD", "secret")
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, CalDAVConfig{
+		URL:       "https://dav.example.com/cal/",
+		Password:  "secret",
+		StateFile: filepath.Join(home, ".config", "tock", "caldav_state.json"),
+	}, cfg.Sync.CalDAV)
+}
+
+func TestGitDefaultsAndEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, GitConfig{Remote: "origin"}, cfg.Git)
+
+	t.Setenv("TOCK_GIT_ENABLED", "true")
+	t.Setenv("TOCK_GIT_BRANCH", "main")
+	cfg, _, err = Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, GitConfig{Enabled: true, Remote: "origin", Branch: "main"}, cfg.Git)
+}
+
+func TestJournalDefaultsAndEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, JournalConfig{Enabled: true, MaxEntries: 1000}, cfg.Journal)
+
+	t.Setenv("TOCK_JOURNAL_ENABLED", "false")
+	t.Setenv("TOCK_JOURNAL_PATH", "/tmp/tock-journal.jsonl")
+	t.Setenv("TOCK_JOURNAL_MAX_ENTRIES", "0")
+	cfg, _, err = Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, JournalConfig{Path: "/tmp/tock-journal.jsonl"}, cfg.Journal)
+}
+
+func TestAuditDefaultsAndEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, AuditConfig{}, cfg.Audit)
+
+	t.Setenv("TOCK_AUDIT_ENABLED", "true")
+	t.Setenv("TOCK_AUDIT_PATH", "/srv/audit.jsonl")
+	cfg, _, err = Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, AuditConfig{Enabled: true, Path: "/srv/audit.jsonl"}, cfg.Audit)
+}
+
+func TestProjectsSeparatorDefaultAndOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Projects.Separator, "projects are flat unless a separator is set")
+
+	configPath := filepath.Join(t.TempDir(), "tock.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("projects:\n  separator: \"/\"\n"), 0600))
+	cfg, _, err = Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.Equal(t, "/", cfg.Projects.Separator)
+
+	t.Setenv("TOCK_PROJECTS_SEPARATOR", "::")
+	cfg, _, err = Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, "::", cfg.Projects.Separator)
+}
+
+func TestProjectsCatalogConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	configPath := filepath.Join(t.TempDir(), "tock.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`projects:
+  allowed: [backend, frontend, acme/...]
+  strict: true
+  aliases:
+    be: backend
+`), 0600))
+	cfg, _, err := Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "frontend", "acme/..."}, cfg.Projects.Allowed)
+	assert.True(t, cfg.Projects.Strict)
+	assert.Equal(t, map[string]string{"be": "backend"}, cfg.Projects.Aliases)
+
+	t.Setenv("TOCK_PROJECTS_STRICT", "false")
+	t.Setenv("TOCK_PROJECTS_ALLOWED_FILE", "/team/projects.txt")
+	cfg, _, err = Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.False(t, cfg.Projects.Strict)
+	assert.Equal(t, "/team/projects.txt", cfg.Projects.AllowedFile)
+}
+
+func TestCalendarDefaultsAndOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, _, err := Load(WithConfigFile(filepath.Join(t.TempDir(), "tock.yaml")))
+	require.NoError(t, err)
+	assert.Equal(t, "monday", cfg.WeekStart)
+	assert.Equal(t, "january", cfg.FiscalYearStart)
+
+	configPath := filepath.Join(t.TempDir(), "tock.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("week_start: sunday\nfiscal_year_start: april\n"), 0600))
+	cfg, _, err = Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.Equal(t, "sunday", cfg.WeekStart)
+	assert.Equal(t, "april", cfg.FiscalYearStart)
+
+	t.Setenv("TOCK_WEEK_START", "saturday")
+	t.Setenv("TOCK_FISCAL_YEAR_START", "10")
+	cfg, _, err = Load(WithConfigFile(configPath))
+	require.NoError(t, err)
+	assert.Equal(t, "saturday", cfg.WeekStart)
+	assert.Equal(t, "10", cfg.FiscalYearStart)
+}
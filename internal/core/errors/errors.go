@@ -8,4 +8,5 @@ var (
 	ErrActivityAlreadyStarted = errors.New("activity already started")
 	ErrCancelled              = errors.New("operation cancelled")
 	ErrNotesUnavailable       = errors.New("notes repository is not configured")
+	ErrPeriodLocked           = errors.New("period is locked")
 )
@@ -0,0 +1,33 @@
+package models
+
+// ChangeOp names the service operation that changed activities.
+type ChangeOp string
+
+const (
+	ChangeStart  ChangeOp = "start"
+	ChangeStop   ChangeOp = "stop"
+	ChangeAdd    ChangeOp = "add"
+	ChangeNote   ChangeOp = "note"
+	ChangeTag    ChangeOp = "tag"
+	ChangeEdit   ChangeOp = "edit"
+	ChangeRemove ChangeOp = "remove"
+)
+
+// ActivityChange describes how one activity was changed.
+type ActivityChange struct {
+	Op     ChangeOp
+	Before *Activity // nil when the activity was created
+	After  *Activity // nil when the activity was removed
+}
+
+// Subject returns the activity after the change, or before it when the
+// activity was removed.
+func (c ActivityChange) Subject() Activity {
+	if c.After != nil {
+		return *c.After
+	}
+	if c.Before != nil {
+		return *c.Before
+	}
+	return Activity{}
+}
@@ -10,12 +10,17 @@ type StartActivityRequest struct {
 	StartTime   time.Time
 	Notes       string
 	Tags        []string
+	Parallel    bool // keep running activities going instead of stopping them
 }
 
 type StopActivityRequest struct {
 	EndTime time.Time
 	Notes   string
 	Tags    []string
+	// Target is the running activity to stop, matched by its start time,
+	// project and description.
+	// When nil, the latest running activity is stopped.
+	Target *Activity
 }
 
 type AddActivityRequest struct {
@@ -27,12 +32,43 @@ type AddActivityRequest struct {
 	Tags        []string
 }
 
+// EditActivityRequest holds the complete replacement state for an existing
+// activity. A nil EndTime keeps (or makes) the activity running.
+type EditActivityRequest struct {
+	Description string
+	Project     string
+	StartTime   time.Time
+	EndTime     *time.Time
+	Notes       string
+	Tags        []string
+}
+
+// ActivityEdit is one edit of a change set applied with EditAll.
+type ActivityEdit struct {
+	Activity Activity
+	Request  EditActivityRequest
+}
+
+// ChangeSet is a batch of removals, edits and additions applied with
+// ApplyChanges, in that order.
+type ChangeSet struct {
+	Remove []Activity
+	Edit   []ActivityEdit
+	Add    []AddActivityRequest
+}
+
 type ActivityFilter struct {
 	FromDate    *time.Time
 	ToDate      *time.Time
 	Project     *string
 	Description *string
 	IsRunning   *bool
+	// ProjectPrefix matches the projects that start with it; "acme/"
+	// selects acme/api and acme/api/auth.
+	ProjectPrefix *string
+	// ProjectRoot is the project that ProjectPrefix names without its
+	// trailing separator. It matches as well, so "acme/" also selects acme.
+	ProjectRoot *string
 }
 
 type Report struct {
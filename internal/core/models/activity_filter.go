@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/go-faster/errors"
@@ -8,6 +9,10 @@ import (
 	"github.com/kriuchkov/tock/internal/timeutil"
 )
 
+// ProjectWildcard ends a --project value that selects every project starting
+// with the rest of it, as in acme/...
+const ProjectWildcard = "..."
+
 type ActivityFilterOptions struct {
 	Now         time.Time
 	Today       bool
@@ -15,8 +20,17 @@ type ActivityFilterOptions struct {
 	Date        string
 	From        string
 	To          string
+	Period      string // named period such as this-week or last-7d
+	Since       string // start of an open range, such as 2w or monday
 	Project     string
 	Description string
+	// ProjectSeparator is projects.separator. A Project such as acme/...
+	// that ends with it before the wildcard also matches acme itself.
+	ProjectSeparator string
+	// TimeFormatter parses Date, From, To, Period and Since, which also
+	// accept days such as yesterday or monday. Nil reads them in English,
+	// with weeks starting on Monday.
+	TimeFormatter *timeutil.Formatter
 }
 
 func BuildActivityFilter(opts ActivityFilterOptions) (ActivityFilter, error) {
@@ -29,16 +43,35 @@ func BuildActivityFilter(opts ActivityFilterOptions) (ActivityFilter, error) {
 		now = time.Now()
 	}
 
+	tf := opts.TimeFormatter
+	if tf == nil {
+		tf = timeutil.NewFormatter("")
+	}
+	tf = tf.At(now)
+
 	filter := ActivityFilter{}
 
 	switch {
 	case opts.From != "" || opts.To != "":
-		fromDate, toDate, err := buildDateRange(opts.From, opts.To)
+		fromDate, toDate, err := buildDateRange(tf, opts.From, opts.To)
 		if err != nil {
 			return ActivityFilter{}, err
 		}
 		filter.FromDate = fromDate
 		filter.ToDate = toDate
+	case opts.Period != "":
+		start, end, err := tf.ParsePeriod(opts.Period)
+		if err != nil {
+			return ActivityFilter{}, err
+		}
+		filter.FromDate = &start
+		filter.ToDate = &end
+	case opts.Since != "":
+		start, err := tf.ParseSince(opts.Since)
+		if err != nil {
+			return ActivityFilter{}, errors.Wrap(err, "invalid --since")
+		}
+		filter.FromDate = &start
 	case opts.Today:
 		start, end := timeutil.LocalDayBounds(now)
 		filter.FromDate = &start
@@ -50,7 +83,7 @@ func BuildActivityFilter(opts ActivityFilterOptions) (ActivityFilter, error) {
 		filter.FromDate = &start
 		filter.ToDate = &end
 	case opts.Date != "":
-		parsedDate, err := time.ParseInLocation("2006-01-02", opts.Date, time.Local)
+		parsedDate, err := tf.ParseDate(opts.Date)
 		if err != nil {
 			return ActivityFilter{}, errors.Wrap(err, "invalid date format (use YYYY-MM-DD)")
 		}
@@ -59,7 +92,12 @@ func BuildActivityFilter(opts ActivityFilterOptions) (ActivityFilter, error) {
 		filter.ToDate = &end
 	}
 
-	if opts.Project != "" {
+	if prefix, ok := strings.CutSuffix(opts.Project, ProjectWildcard); ok {
+		filter.ProjectPrefix = &prefix
+		if root, cut := strings.CutSuffix(prefix, opts.ProjectSeparator); cut && opts.ProjectSeparator != "" && root != "" {
+			filter.ProjectRoot = &root
+		}
+	} else if opts.Project != "" {
 		filter.Project = &opts.Project
 	}
 	if opts.Description != "" {
@@ -69,6 +107,18 @@ func BuildActivityFilter(opts ActivityFilterOptions) (ActivityFilter, error) {
 	return filter, nil
 }
 
+// MatchesProjectPrefix reports whether project is selected by ProjectPrefix
+// and ProjectRoot. It is true when ProjectPrefix is not set.
+func (f ActivityFilter) MatchesProjectPrefix(project string) bool {
+	if f.ProjectPrefix == nil {
+		return true
+	}
+	if f.ProjectRoot != nil && project == *f.ProjectRoot {
+		return true
+	}
+	return strings.HasPrefix(project, *f.ProjectPrefix)
+}
+
 func validateDateFilters(opts ActivityFilterOptions) error {
 	dateFilters := 0
 	if opts.Today {
@@ -83,18 +133,24 @@ func validateDateFilters(opts ActivityFilterOptions) error {
 	if opts.From != "" || opts.To != "" {
 		dateFilters++
 	}
+	if opts.Period != "" {
+		dateFilters++
+	}
+	if opts.Since != "" {
+		dateFilters++
+	}
 	if dateFilters > 1 {
-		return errors.New("cannot specify multiple date filters (--today, --yesterday, --date, --from/--to are mutually exclusive)")
+		return errors.New("cannot specify multiple date filters (--today, --yesterday, --date, --from/--to, --period, --since are mutually exclusive)")
 	}
 
 	return nil
 }
 
-func buildDateRange(from, to string) (*time.Time, *time.Time, error) {
+func buildDateRange(tf *timeutil.Formatter, from, to string) (*time.Time, *time.Time, error) {
 	var fromDate, toDate *time.Time
 
 	if from != "" {
-		parsed, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		parsed, err := tf.ParseDate(from)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "invalid --from date format, use YYYY-MM-DD")
 		}
@@ -102,7 +158,7 @@ func buildDateRange(from, to string) (*time.Time, *time.Time, error) {
 	}
 
 	if to != "" {
-		parsed, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		parsed, err := tf.ParseDate(to)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "invalid --to date format, use YYYY-MM-DD")
 		}
@@ -36,6 +36,16 @@ func TestBuildActivityFilter(t *testing.T) {
 		assert.Equal(t, "refactor", *filter.Description)
 	})
 
+	t.Run("builds filter for a natural date", func(t *testing.T) {
+		filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{Now: now, Date: "friday"})
+		require.NoError(t, err)
+
+		require.NotNil(t, filter.FromDate)
+		require.NotNil(t, filter.ToDate)
+		assert.Equal(t, time.Date(2026, time.March, 13, 0, 0, 0, 0, time.Local), *filter.FromDate)
+		assert.Equal(t, time.Date(2026, time.March, 14, 0, 0, 0, 0, time.Local), *filter.ToDate)
+	})
+
 	t.Run("rejects invalid date", func(t *testing.T) {
 		_, err := models.BuildActivityFilter(models.ActivityFilterOptions{Date: "15-03-2026"})
 		require.Error(t, err)
@@ -104,7 +114,60 @@ func TestBuildActivityFilterDateRange(t *testing.T) {
 	}
 }
 
+func TestBuildActivityFilterPeriodAndSince(t *testing.T) {
+	// 2026-04-22 is a Wednesday.
+	now := time.Date(2026, time.April, 22, 10, 0, 0, 0, time.Local)
+	tf := timeutil.NewFormatter("24")
+
+	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{Period: "last-week", TimeFormatter: tf, Now: now})
+	require.NoError(t, err)
+	assert.Equal(t, datePointer(13), filter.FromDate)
+	assert.Equal(t, datePointer(20), filter.ToDate)
+
+	sunday := tf.WithCalendar(timeutil.Calendar{WeekStart: time.Sunday, FiscalYearStart: time.January})
+	filter, err = models.BuildActivityFilter(models.ActivityFilterOptions{Period: "this-week", TimeFormatter: sunday, Now: now})
+	require.NoError(t, err)
+	assert.Equal(t, datePointer(19), filter.FromDate)
+	assert.Equal(t, datePointer(26), filter.ToDate)
+
+	filter, err = models.BuildActivityFilter(models.ActivityFilterOptions{Since: "2w", TimeFormatter: tf, Now: now})
+	require.NoError(t, err)
+	assert.Equal(t, datePointer(8), filter.FromDate)
+	assert.Nil(t, filter.ToDate)
+
+	_, err = models.BuildActivityFilter(models.ActivityFilterOptions{Period: "someday", TimeFormatter: tf, Now: now})
+	require.ErrorContains(t, err, "invalid period")
+
+	_, err = models.BuildActivityFilter(models.ActivityFilterOptions{Since: "a while", TimeFormatter: tf, Now: now})
+	require.ErrorContains(t, err, "invalid --since")
+
+	_, err = models.BuildActivityFilter(models.ActivityFilterOptions{Period: "ytd", Since: "2w"})
+	require.ErrorContains(t, err, "cannot specify multiple date filters")
+}
+
 func datePointer(day int) *time.Time {
 	date := time.Date(2026, time.April, day, 0, 0, 0, 0, time.Local)
 	return &date
 }
+
+func TestBuildActivityFilterProjectWildcard(t *testing.T) {
+	filter, err := models.BuildActivityFilter(models.ActivityFilterOptions{Project: "acme/..."})
+	require.NoError(t, err)
+	assert.Nil(t, filter.Project)
+	require.NotNil(t, filter.ProjectPrefix)
+	assert.Equal(t, "acme/", *filter.ProjectPrefix)
+	assert.Nil(t, filter.ProjectRoot, "without a separator there is no root")
+
+	filter, err = models.BuildActivityFilter(models.ActivityFilterOptions{Project: "acme/...", ProjectSeparator: "/"})
+	require.NoError(t, err)
+	require.NotNil(t, filter.ProjectRoot)
+	assert.Equal(t, "acme", *filter.ProjectRoot)
+	for project, want := range map[string]bool{"acme": true, "acme/api": true, "acme/api/auth": true, "acme-corp": false, "acm": false} {
+		assert.Equal(t, want, filter.MatchesProjectPrefix(project), project)
+	}
+
+	filter, err = models.BuildActivityFilter(models.ActivityFilterOptions{Project: "acme/api"})
+	require.NoError(t, err)
+	assert.Nil(t, filter.ProjectPrefix)
+	assert.Equal(t, "acme/api", *filter.Project)
+}
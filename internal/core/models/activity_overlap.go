@@ -0,0 +1,86 @@
+package models
+
+import (
+	"container/heap"
+	"slices"
+	"time"
+)
+
+// Deduplicate recomputes the durations of the report so that time covered by
+// several activities at once, such as an on-call timer running alongside
+// focused work, counts only once. Overlapping time goes to the activity that
+// started last, which is the one in the foreground. Running activities count
+// until now. The activities themselves are left as they are.
+//
+// Activities are swept once in start order while a heap keeps the running
+// ones by start time, so the foreground is always on top.
+func (r *Report) Deduplicate(now time.Time) {
+	ends := make([]time.Time, len(r.Activities))
+	order := make([]int, len(r.Activities))
+	bounds := make([]time.Time, 0, 2*len(r.Activities))
+	for i, act := range r.Activities {
+		ends[i] = now
+		if act.EndTime != nil {
+			ends[i] = *act.EndTime
+		}
+		order[i] = i
+		bounds = append(bounds, act.StartTime, ends[i])
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return r.Activities[a].StartTime.Compare(r.Activities[b].StartTime)
+	})
+	slices.SortFunc(bounds, func(a, b time.Time) int { return a.Compare(b) })
+	bounds = slices.CompactFunc(bounds, func(a, b time.Time) bool { return a.Equal(b) })
+
+	active := &foregroundHeap{activities: r.Activities}
+	next := 0
+	byProject := make(map[string]time.Duration, len(r.ByProject))
+	var total time.Duration
+	for i := 1; i < len(bounds); i++ {
+		from, to := bounds[i-1], bounds[i]
+		for ; next < len(order) && !r.Activities[order[next]].StartTime.After(from); next++ {
+			heap.Push(active, order[next])
+		}
+		for active.Len() > 0 && !ends[active.indexes[0]].After(from) {
+			heap.Pop(active)
+		}
+		if active.Len() == 0 {
+			continue
+		}
+		byProject[r.Activities[active.indexes[0]].Project] += to.Sub(from)
+		total += to.Sub(from)
+	}
+
+	for name, project := range r.ByProject {
+		project.Duration = byProject[name]
+		r.ByProject[name] = project
+	}
+	r.TotalDuration = total
+}
+
+// foregroundHeap holds indexes into activities with the one started last on
+// top; of activities started together, the later one in the report wins.
+type foregroundHeap struct {
+	activities []Activity
+	indexes    []int
+}
+
+func (h *foregroundHeap) Len() int { return len(h.indexes) }
+
+func (h *foregroundHeap) Less(i, j int) bool {
+	a, b := h.indexes[i], h.indexes[j]
+	if c := h.activities[a].StartTime.Compare(h.activities[b].StartTime); c != 0 {
+		return c > 0
+	}
+	return a > b
+}
+
+func (h *foregroundHeap) Swap(i, j int) { h.indexes[i], h.indexes[j] = h.indexes[j], h.indexes[i] }
+
+func (h *foregroundHeap) Push(x any) { h.indexes = append(h.indexes, x.(int)) } //nolint:errcheck // only ints are pushed
+
+func (h *foregroundHeap) Pop() any {
+	last := h.indexes[len(h.indexes)-1]
+	h.indexes = h.indexes[:len(h.indexes)-1]
+	return last
+}
@@ -0,0 +1,91 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kriuchkov/tock/internal/core/models"
+)
+
+func overlapActivity(project string, startHour, startMinute, endHour, endMinute int) models.Activity {
+	start := time.Date(2026, time.March, 2, startHour, startMinute, 0, 0, time.Local)
+	act := models.Activity{Project: project, StartTime: start}
+	if endHour >= 0 {
+		end := time.Date(2026, time.March, 2, endHour, endMinute, 0, 0, time.Local)
+		act.EndTime = &end
+	}
+	return act
+}
+
+func newOverlapReport(activities ...models.Activity) *models.Report {
+	report := &models.Report{ByProject: map[string]models.ProjectReport{}}
+	for _, act := range activities {
+		report.Activities = append(report.Activities, act)
+		report.TotalDuration += act.Duration()
+		project := report.ByProject[act.Project]
+		project.ProjectName = act.Project
+		project.Duration += act.Duration()
+		project.Activities = append(project.Activities, act)
+		report.ByProject[act.Project] = project
+	}
+	return report
+}
+
+func TestReportDeduplicateGivesOverlapToForeground(t *testing.T) {
+	report := newOverlapReport(
+		overlapActivity("on-call", 9, 0, 17, 0),
+		overlapActivity("api", 10, 0, 11, 30),
+		overlapActivity("web", 11, 0, 12, 0),
+	)
+	assert.Equal(t, 10*time.Hour+30*time.Minute, report.TotalDuration)
+
+	report.Deduplicate(time.Date(2026, time.March, 2, 18, 0, 0, 0, time.Local))
+
+	assert.Equal(t, 8*time.Hour, report.TotalDuration)
+	assert.Equal(t, 6*time.Hour, report.ByProject["on-call"].Duration)
+	assert.Equal(t, time.Hour, report.ByProject["api"].Duration)
+	assert.Equal(t, time.Hour, report.ByProject["web"].Duration)
+	assert.Len(t, report.Activities, 3, "activities are kept as they are")
+}
+
+func TestReportDeduplicateCountsRunningActivitiesUntilNow(t *testing.T) {
+	report := newOverlapReport(
+		overlapActivity("on-call", 9, 0, -1, 0),
+		overlapActivity("api", 9, 30, 10, 0),
+	)
+
+	report.Deduplicate(time.Date(2026, time.March, 2, 10, 30, 0, 0, time.Local))
+
+	assert.Equal(t, 90*time.Minute, report.TotalDuration)
+	assert.Equal(t, time.Hour, report.ByProject["on-call"].Duration)
+	assert.Equal(t, 30*time.Minute, report.ByProject["api"].Duration)
+}
+
+func TestReportDeduplicateWithoutOverlapKeepsDurations(t *testing.T) {
+	report := newOverlapReport(
+		overlapActivity("api", 9, 0, 10, 0),
+		overlapActivity("web", 11, 0, 11, 45),
+	)
+
+	report.Deduplicate(time.Date(2026, time.March, 2, 18, 0, 0, 0, time.Local))
+
+	assert.Equal(t, 105*time.Minute, report.TotalDuration)
+	assert.Equal(t, time.Hour, report.ByProject["api"].Duration)
+}
+
+func TestReportDeduplicateReturnsToEarlierForegroundWhenLaterEnds(t *testing.T) {
+	report := newOverlapReport(
+		overlapActivity("web", 11, 0, 11, 30),
+		overlapActivity("on-call", 9, 0, 17, 0),
+		overlapActivity("api", 10, 0, 12, 0),
+	)
+
+	report.Deduplicate(time.Date(2026, time.March, 2, 18, 0, 0, 0, time.Local))
+
+	assert.Equal(t, 8*time.Hour, report.TotalDuration)
+	assert.Equal(t, 6*time.Hour, report.ByProject["on-call"].Duration)
+	assert.Equal(t, 90*time.Minute, report.ByProject["api"].Duration)
+	assert.Equal(t, 30*time.Minute, report.ByProject["web"].Duration)
+}
@@ -254,6 +254,205 @@ func (_c *MockActivityResolver_AddTags_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ApplyChanges provides a mock function for the type MockActivityResolver
+func (_mock *MockActivityResolver) ApplyChanges(ctx context.Context, set models.ChangeSet) error {
+	ret := _mock.Called(ctx, set)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyChanges")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.ChangeSet) error); ok {
+		r0 = returnFunc(ctx, set)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockActivityResolver_ApplyChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyChanges'
+type MockActivityResolver_ApplyChanges_Call struct {
+	*mock.Call
+}
+
+// ApplyChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - set models.ChangeSet
+func (_e *MockActivityResolver_Expecter) ApplyChanges(ctx interface{}, set interface{}) *MockActivityResolver_ApplyChanges_Call {
+	return &MockActivityResolver_ApplyChanges_Call{Call: _e.mock.On("ApplyChanges", ctx, set)}
+}
+
+func (_c *MockActivityResolver_ApplyChanges_Call) Run(run func(ctx context.Context, set models.ChangeSet)) *MockActivityResolver_ApplyChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.ChangeSet
+		if args[1] != nil {
+			arg1 = args[1].(models.ChangeSet)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockActivityResolver_ApplyChanges_Call) Return(err error) *MockActivityResolver_ApplyChanges_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockActivityResolver_ApplyChanges_Call) RunAndReturn(run func(ctx context.Context, set models.ChangeSet) error) *MockActivityResolver_ApplyChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Edit provides a mock function for the type MockActivityResolver
+func (_mock *MockActivityResolver) Edit(ctx context.Context, activity models.Activity, req models.EditActivityRequest) (*models.Activity, error) {
+	ret := _mock.Called(ctx, activity, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Edit")
+	}
+
+	var r0 *models.Activity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Activity, models.EditActivityRequest) (*models.Activity, error)); ok {
+		return returnFunc(ctx, activity, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Activity, models.EditActivityRequest) *models.Activity); ok {
+		r0 = returnFunc(ctx, activity, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Activity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Activity, models.EditActivityRequest) error); ok {
+		r1 = returnFunc(ctx, activity, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockActivityResolver_Edit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Edit'
+type MockActivityResolver_Edit_Call struct {
+	*mock.Call
+}
+
+// Edit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - activity models.Activity
+//   - req models.EditActivityRequest
+func (_e *MockActivityResolver_Expecter) Edit(ctx interface{}, activity interface{}, req interface{}) *MockActivityResolver_Edit_Call {
+	return &MockActivityResolver_Edit_Call{Call: _e.mock.On("Edit", ctx, activity, req)}
+}
+
+func (_c *MockActivityResolver_Edit_Call) Run(run func(ctx context.Context, activity models.Activity, req models.EditActivityRequest)) *MockActivityResolver_Edit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Activity
+		if args[1] != nil {
+			arg1 = args[1].(models.Activity)
+		}
+		var arg2 models.EditActivityRequest
+		if args[2] != nil {
+			arg2 = args[2].(models.EditActivityRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockActivityResolver_Edit_Call) Return(activity1 *models.Activity, err error) *MockActivityResolver_Edit_Call {
+	_c.Call.Return(activity1, err)
+	return _c
+}
+
+func (_c *MockActivityResolver_Edit_Call) RunAndReturn(run func(ctx context.Context, activity models.Activity, req models.EditActivityRequest) (*models.Activity, error)) *MockActivityResolver_Edit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditAll provides a mock function for the type MockActivityResolver
+func (_mock *MockActivityResolver) EditAll(ctx context.Context, edits []models.ActivityEdit) ([]models.Activity, error) {
+	ret := _mock.Called(ctx, edits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditAll")
+	}
+
+	var r0 []models.Activity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.ActivityEdit) ([]models.Activity, error)); ok {
+		return returnFunc(ctx, edits)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.ActivityEdit) []models.Activity); ok {
+		r0 = returnFunc(ctx, edits)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Activity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []models.ActivityEdit) error); ok {
+		r1 = returnFunc(ctx, edits)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockActivityResolver_EditAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditAll'
+type MockActivityResolver_EditAll_Call struct {
+	*mock.Call
+}
+
+// EditAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - edits []models.ActivityEdit
+func (_e *MockActivityResolver_Expecter) EditAll(ctx interface{}, edits interface{}) *MockActivityResolver_EditAll_Call {
+	return &MockActivityResolver_EditAll_Call{Call: _e.mock.On("EditAll", ctx, edits)}
+}
+
+func (_c *MockActivityResolver_EditAll_Call) Run(run func(ctx context.Context, edits []models.ActivityEdit)) *MockActivityResolver_EditAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.ActivityEdit
+		if args[1] != nil {
+			arg1 = args[1].([]models.ActivityEdit)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockActivityResolver_EditAll_Call) Return(activitys []models.Activity, err error) *MockActivityResolver_EditAll_Call {
+	_c.Call.Return(activitys, err)
+	return _c
+}
+
+func (_c *MockActivityResolver_EditAll_Call) RunAndReturn(run func(ctx context.Context, edits []models.ActivityEdit) ([]models.Activity, error)) *MockActivityResolver_EditAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLast provides a mock function for the type MockActivityResolver
 func (_mock *MockActivityResolver) GetLast(ctx context.Context) (*models.Activity, error) {
 	ret := _mock.Called(ctx)
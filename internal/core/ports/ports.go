@@ -18,6 +18,13 @@ type ActivityResolver interface {
 	AddNote(ctx context.Context, activity models.Activity, note string) (*models.Activity, error)
 	AddTags(ctx context.Context, activity models.Activity, tags []string) (*models.Activity, error)
 	Remove(ctx context.Context, activity models.Activity) error
+	Edit(ctx context.Context, activity models.Activity, req models.EditActivityRequest) (*models.Activity, error)
+	// EditAll applies the edits as one change set, which listeners receive
+	// in a single notification.
+	EditAll(ctx context.Context, edits []models.ActivityEdit) ([]models.Activity, error)
+	// ApplyChanges applies a mixed change set, which listeners likewise
+	// receive in a single notification.
+	ApplyChanges(ctx context.Context, set models.ChangeSet) error
 }
 
 type ActivityRepository interface {
@@ -32,3 +39,11 @@ type NotesRepository interface {
 	Get(ctx context.Context, activityID string, date time.Time) (string, []string, error)
 	Delete(ctx context.Context, activityID string, date time.Time) error
 }
+
+// ActivityChangeListener is notified after the service changed activities.
+// Changes holds every activity touched by one operation, the one the
+// operation was about first; Start, for example, also reports the running
+// activities it stopped.
+type ActivityChangeListener interface {
+	ActivityChanged(ctx context.Context, changes []models.ActivityChange) error
+}
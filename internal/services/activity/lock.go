@@ -0,0 +1,146 @@
+package activity
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
+	"github.com/kriuchkov/tock/internal/core/models"
+	"github.com/kriuchkov/tock/internal/core/ports"
+)
+
+const lockTimeLayout = "2006-01-02 15:04"
+
+type lockedService struct {
+	ports.ActivityResolver
+
+	lockedBefore time.Time
+}
+
+// WithLock returns svc with the period before lockedBefore closed: changes
+// that add, edit or remove activities starting in it, or that stop an
+// activity at a time in it, fail with ErrPeriodLocked. Stopping an activity
+// that started in the period at a later time is still allowed, so a timer
+// left running over the boundary can be stopped.
+func WithLock(svc ports.ActivityResolver, lockedBefore time.Time) ports.ActivityResolver {
+	return &lockedService{ActivityResolver: svc, lockedBefore: lockedBefore}
+}
+
+func (s *lockedService) check(times ...time.Time) error {
+	for _, t := range times {
+		if t.Before(s.lockedBefore) {
+			return errors.Wrapf(coreErrors.ErrPeriodLocked, "%s is before %s",
+				t.Format(lockTimeLayout), s.lockedBefore.Format(lockTimeLayout))
+		}
+	}
+	return nil
+}
+
+func (s *lockedService) Start(ctx context.Context, req models.StartActivityRequest) (*models.Activity, error) {
+	// Running activities are stopped at the start time, so checking it
+	// covers them as well.
+	if err := s.check(orNow(req.StartTime)); err != nil {
+		return nil, err
+	}
+	return s.ActivityResolver.Start(ctx, req)
+}
+
+func (s *lockedService) Stop(ctx context.Context, req models.StopActivityRequest) (*models.Activity, error) {
+	if err := s.check(orNow(req.EndTime)); err != nil {
+		return nil, err
+	}
+	return s.ActivityResolver.Stop(ctx, req)
+}
+
+func (s *lockedService) Add(ctx context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+	if err := s.check(req.StartTime); err != nil {
+		return nil, err
+	}
+	return s.ActivityResolver.Add(ctx, req)
+}
+
+func (s *lockedService) AddNote(ctx context.Context, activity models.Activity, note string) (*models.Activity, error) {
+	if err := s.check(activity.StartTime); err != nil {
+		return nil, err
+	}
+	return s.ActivityResolver.AddNote(ctx, activity, note)
+}
+
+func (s *lockedService) AddTags(ctx context.Context, activity models.Activity, tags []string) (*models.Activity, error) {
+	if err := s.check(activity.StartTime); err != nil {
+		return nil, err
+	}
+	return s.ActivityResolver.AddTags(ctx, activity, tags)
+}
+
+func (s *lockedService) Remove(ctx context.Context, activity models.Activity) error {
+	if err := s.check(activity.StartTime); err != nil {
+		return err
+	}
+	return s.ActivityResolver.Remove(ctx, activity)
+}
+
+func (s *lockedService) Edit(
+	ctx context.Context,
+	activity models.Activity,
+	req models.EditActivityRequest,
+) (*models.Activity, error) {
+	if err := s.check(editTimes(activity, req)...); err != nil {
+		return nil, err
+	}
+	return s.ActivityResolver.Edit(ctx, activity, req)
+}
+
+// EditAll checks every edit before applying any, so a change set touching a
+// locked activity is refused as a whole instead of being half applied.
+func (s *lockedService) EditAll(ctx context.Context, edits []models.ActivityEdit) ([]models.Activity, error) {
+	for _, e := range edits {
+		if err := s.check(editTimes(e.Activity, e.Request)...); err != nil {
+			return nil, errors.Wrapf(err, "%s: %s", e.Activity.Project, e.Activity.Description)
+		}
+	}
+	return s.ActivityResolver.EditAll(ctx, edits)
+}
+
+// ApplyChanges checks every change of the set before applying any, as
+// EditAll does.
+func (s *lockedService) ApplyChanges(ctx context.Context, set models.ChangeSet) error {
+	for _, act := range set.Remove {
+		if err := s.check(act.StartTime); err != nil {
+			return errors.Wrapf(err, "%s: %s", act.Project, act.Description)
+		}
+	}
+	for _, e := range set.Edit {
+		if err := s.check(editTimes(e.Activity, e.Request)...); err != nil {
+			return errors.Wrapf(err, "%s: %s", e.Activity.Project, e.Activity.Description)
+		}
+	}
+	for _, req := range set.Add {
+		if err := s.check(req.StartTime); err != nil {
+			return errors.Wrapf(err, "%s: %s", req.Project, req.Description)
+		}
+	}
+	return s.ActivityResolver.ApplyChanges(ctx, set)
+}
+
+func editTimes(activity models.Activity, req models.EditActivityRequest) []time.Time {
+	times := []time.Time{activity.StartTime}
+	if !req.StartTime.IsZero() {
+		times = append(times, req.StartTime)
+	}
+	if req.EndTime != nil {
+		times = append(times, *req.EndTime)
+	}
+	return times
+}
+
+// orNow returns t, or the current time when t is zero, as the service does
+// for start and end times that were not given.
+func orNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
@@ -0,0 +1,64 @@
+package activity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreErrors "github.com/kriuchkov/tock/internal/core/errors"
+	"github.com/kriuchkov/tock/internal/core/models"
+	portsmocks "github.com/kriuchkov/tock/internal/core/ports/mocks"
+	"github.com/kriuchkov/tock/internal/services/activity"
+)
+
+func TestWithLock_RejectsChangesInLockedPeriod(t *testing.T) {
+	ctx := context.Background()
+	lockedBefore := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)
+	locked := models.Activity{Project: "api", Description: "tests", StartTime: lockedBefore.Add(-2 * time.Hour)}
+	lockedEnd := lockedBefore.Add(-time.Hour)
+	open := models.Activity{Project: "api", Description: "docs", StartTime: lockedBefore.Add(time.Hour)}
+
+	// The mock has no expectations: any call that gets through fails the test.
+	svc := activity.WithLock(portsmocks.NewMockActivityResolver(t), lockedBefore)
+
+	_, err := svc.Start(ctx, models.StartActivityRequest{Project: "api", StartTime: locked.StartTime})
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	_, err = svc.Stop(ctx, models.StopActivityRequest{EndTime: lockedEnd})
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	_, err = svc.Add(ctx, models.AddActivityRequest{StartTime: locked.StartTime, EndTime: lockedEnd})
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	_, err = svc.AddNote(ctx, locked, "late note")
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	_, err = svc.AddTags(ctx, locked, []string{"late"})
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	require.ErrorIs(t, svc.Remove(ctx, locked), coreErrors.ErrPeriodLocked)
+	_, err = svc.Edit(ctx, locked, models.EditActivityRequest{Project: "web"})
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked)
+	_, err = svc.Edit(ctx, open, models.EditActivityRequest{Project: "api", StartTime: locked.StartTime})
+	require.ErrorIs(t, err, coreErrors.ErrPeriodLocked, "moving an activity into the period")
+	assert.ErrorContains(t, err, "2026-09-30 22:00 is before 2026-10-01 00:00")
+}
+
+func TestWithLock_AllowsChangesAfterLockedPeriod(t *testing.T) {
+	ctx := context.Background()
+	lockedBefore := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)
+	open := models.Activity{Project: "api", Description: "docs", StartTime: lockedBefore.Add(time.Hour)}
+	end := lockedBefore.Add(2 * time.Hour)
+
+	inner := portsmocks.NewMockActivityResolver(t)
+	inner.EXPECT().Stop(mock.Anything, models.StopActivityRequest{EndTime: end}).Return(&open, nil)
+	inner.EXPECT().Remove(mock.Anything, open).Return(nil)
+	inner.EXPECT().Edit(mock.Anything, open, mock.Anything).Return(&open, nil)
+	svc := activity.WithLock(inner, lockedBefore)
+
+	// A timer running over the boundary can still be stopped after it.
+	_, err := svc.Stop(ctx, models.StopActivityRequest{EndTime: end})
+	require.NoError(t, err)
+	require.NoError(t, svc.Remove(ctx, open))
+	_, err = svc.Edit(ctx, open, models.EditActivityRequest{Project: "web", EndTime: &end})
+	require.NoError(t, err)
+}
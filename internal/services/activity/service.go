@@ -16,12 +16,32 @@ import (
 type service struct {
 	repo      ports.ActivityRepository
 	notesRepo ports.NotesRepository
+	listeners []ports.ActivityChangeListener
 }
 
-func NewService(repo ports.ActivityRepository, notesRepo ports.NotesRepository) ports.ActivityResolver {
-	return &service{repo: repo, notesRepo: notesRepo}
+// NewService returns the activity service. Listeners are notified, in order,
+// after every operation that changed activities.
+func NewService(
+	repo ports.ActivityRepository,
+	notesRepo ports.NotesRepository,
+	listeners ...ports.ActivityChangeListener,
+) ports.ActivityResolver {
+	return &service{repo: repo, notesRepo: notesRepo, listeners: listeners}
 }
 
+func (s *service) notify(ctx context.Context, changes ...models.ActivityChange) error {
+	for _, listener := range s.listeners {
+		if err := listener.ActivityChanged(ctx, changes); err != nil {
+			return errors.Wrap(err, "notify change listener")
+		}
+	}
+	return nil
+}
+
+// Start starts an activity and stops the running ones at its start time,
+// unless the request asks to run it in parallel with them. A parallel start
+// is refused in the start minute of a running activity, which the plaintext
+// backends would overwrite, since they store one activity per minute.
 func (s *service) Start(ctx context.Context, req models.StartActivityRequest) (*models.Activity, error) {
 	isRunning := true
 	running, err := s.repo.Find(ctx, models.ActivityFilter{IsRunning: &isRunning})
@@ -34,7 +54,19 @@ func (s *service) Start(ctx context.Context, req models.StartActivityRequest) (*
 		startTime = time.Now()
 	}
 
+	if req.Parallel {
+		for _, act := range running {
+			if act.StartTime.Truncate(time.Minute).Equal(startTime.Truncate(time.Minute)) {
+				return nil, errors.Wrapf(coreErrors.ErrActivityAlreadyStarted, "%s: %s started at %s",
+					act.Project, act.Description, act.StartTime.Format("2006-01-02 15:04"))
+			}
+		}
+		running = nil
+	}
+
+	var stopped []models.ActivityChange
 	for _, act := range running {
+		before := s.snapshot(ctx, act)
 		stopTime := startTime
 		if stopTime.Before(act.StartTime) {
 			stopTime = time.Now()
@@ -43,6 +75,9 @@ func (s *service) Start(ctx context.Context, req models.StartActivityRequest) (*
 		if saveErr := s.repo.Save(ctx, act); saveErr != nil {
 			return nil, errors.Wrap(saveErr, "stop running activity")
 		}
+		after := before
+		after.EndTime = &stopTime
+		stopped = append(stopped, models.ActivityChange{Op: models.ChangeStop, Before: &before, After: &after})
 	}
 
 	newActivity := models.Activity{
@@ -63,6 +98,11 @@ func (s *service) Start(ctx context.Context, req models.StartActivityRequest) (*
 		}
 	}
 
+	created := newActivity
+	changes := append([]models.ActivityChange{{Op: models.ChangeStart, After: &created}}, stopped...)
+	if err = s.notify(ctx, changes...); err != nil {
+		return nil, err
+	}
 	return &newActivity, nil
 }
 
@@ -77,13 +117,23 @@ func (s *service) Stop(ctx context.Context, req models.StopActivityRequest) (*mo
 		return nil, coreErrors.ErrNoActiveActivity
 	}
 
-	// Find the latest running activity
+	// Find the requested or else the latest running activity
 	var last *models.Activity
 	for i := range running {
+		if req.Target != nil {
+			if running[i].StartTime.Equal(req.Target.StartTime) &&
+				running[i].Project == req.Target.Project && running[i].Description == req.Target.Description {
+				last = &running[i]
+			}
+			continue
+		}
 		if last == nil || running[i].StartTime.After(last.StartTime) {
 			last = &running[i]
 		}
 	}
+	if last == nil {
+		return nil, errors.Wrapf(coreErrors.ErrNoActiveActivity, "%s: %s", req.Target.Project, req.Target.Description)
+	}
 
 	endTime := req.EndTime
 	if endTime.IsZero() {
@@ -94,6 +144,7 @@ func (s *service) Stop(ctx context.Context, req models.StopActivityRequest) (*mo
 		return nil, errors.New("end time cannot be before start time")
 	}
 
+	before := s.snapshot(ctx, *last)
 	last.EndTime = &endTime
 	// Update notes/tags if provided
 	if req.Notes != "" {
@@ -112,10 +163,35 @@ func (s *service) Stop(ctx context.Context, req models.StopActivityRequest) (*mo
 			return nil, errors.Wrap(err, "save notes")
 		}
 	}
+
+	after := before
+	after.EndTime = &endTime
+	if req.Notes != "" {
+		after.Notes = req.Notes
+	}
+	if len(req.Tags) > 0 {
+		after.Tags = req.Tags
+	}
+	if err = s.notify(ctx, models.ActivityChange{Op: models.ChangeStop, Before: &before, After: &after}); err != nil {
+		return nil, err
+	}
 	return last, nil
 }
 
 func (s *service) Add(ctx context.Context, req models.AddActivityRequest) (*models.Activity, error) {
+	added, err := s.add(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	created := *added
+	if err = s.notify(ctx, models.ActivityChange{Op: models.ChangeAdd, After: &created}); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+func (s *service) add(ctx context.Context, req models.AddActivityRequest) (*models.Activity, error) {
 	newActivity := models.Activity{
 		Description: req.Description,
 		Project:     req.Project,
@@ -134,7 +210,6 @@ func (s *service) Add(ctx context.Context, req models.AddActivityRequest) (*mode
 			return nil, errors.Wrap(err, "save notes")
 		}
 	}
-
 	return &newActivity, nil
 }
 
@@ -262,6 +337,8 @@ func (s *service) AddNote(ctx context.Context, activity models.Activity, note st
 		return nil, err
 	}
 
+	before := activity
+	before.Notes, before.Tags = existingNotes, existingTags
 	updated := activity
 	updated.Notes = joinNotes(existingNotes, note)
 	updated.Tags = existingTags
@@ -269,6 +346,11 @@ func (s *service) AddNote(ctx context.Context, activity models.Activity, note st
 	if err = s.notesRepo.Save(ctx, updated.ID(), updated.StartTime, updated.Notes, updated.Tags); err != nil {
 		return nil, errors.Wrap(err, "save note")
 	}
+
+	after := updated
+	if err = s.notify(ctx, models.ActivityChange{Op: models.ChangeNote, Before: &before, After: &after}); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
@@ -284,6 +366,8 @@ func (s *service) AddTags(ctx context.Context, activity models.Activity, tags []
 		return nil, err
 	}
 
+	before := activity
+	before.Notes, before.Tags = existingNotes, existingTags
 	updated := activity
 	updated.Notes = existingNotes
 	updated.Tags = mergeTags(existingTags, tags)
@@ -291,10 +375,137 @@ func (s *service) AddTags(ctx context.Context, activity models.Activity, tags []
 	if err = s.notesRepo.Save(ctx, updated.ID(), updated.StartTime, updated.Notes, updated.Tags); err != nil {
 		return nil, errors.Wrap(err, "save tags")
 	}
+
+	after := updated
+	if err = s.notify(ctx, models.ActivityChange{Op: models.ChangeTag, Before: &before, After: &after}); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Edit replaces an activity's fields with the requested state. The activity is
+// matched by its original start time; when the start time changes the old entry
+// is removed before the updated one is saved so repositories that upsert by
+// start time do not keep a stale copy; it is restored if that save fails.
+func (s *service) Edit(
+	ctx context.Context,
+	activity models.Activity,
+	req models.EditActivityRequest,
+) (*models.Activity, error) {
+	before := s.snapshot(ctx, activity)
+	updated, err := s.edit(ctx, activity, req)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *updated
+	if err = s.notify(ctx, models.ActivityChange{Op: models.ChangeEdit, Before: &before, After: &after}); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// EditAll applies the edits in order and notifies the listeners once, so a
+// bulk change is journaled, audited and committed as one. When an edit fails,
+// the edits applied before it are still reported to the listeners.
+func (s *service) EditAll(ctx context.Context, edits []models.ActivityEdit) ([]models.Activity, error) {
+	updated := make([]models.Activity, 0, len(edits))
+	changes := make([]models.ActivityChange, 0, len(edits))
+	for _, e := range edits {
+		before := s.snapshot(ctx, e.Activity)
+		act, err := s.edit(ctx, e.Activity, e.Request)
+		if err != nil {
+			if len(changes) > 0 {
+				if notifyErr := s.notify(ctx, changes...); notifyErr != nil {
+					return updated, errors.Wrapf(err, "edit activity (%v)", notifyErr)
+				}
+			}
+			return updated, err
+		}
+		after := *act
+		updated = append(updated, after)
+		changes = append(changes, models.ActivityChange{Op: models.ChangeEdit, Before: &before, After: &after})
+	}
+
+	if len(changes) > 0 {
+		if err := s.notify(ctx, changes...); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+func (s *service) edit(
+	ctx context.Context,
+	activity models.Activity,
+	req models.EditActivityRequest,
+) (*models.Activity, error) {
+	updated := activity
+	updated.Description = req.Description
+	updated.Project = req.Project
+	updated.Notes = strings.TrimSpace(req.Notes)
+	updated.Tags = mergeTags(nil, req.Tags)
+	if !req.StartTime.IsZero() {
+		updated.StartTime = req.StartTime
+	}
+	updated.EndTime = nil
+	if req.EndTime != nil {
+		endTime := *req.EndTime
+		if endTime.Before(updated.StartTime) {
+			return nil, errors.New("end time cannot be before start time")
+		}
+		updated.EndTime = &endTime
+	}
+
+	startChanged := !updated.StartTime.Equal(activity.StartTime)
+	if startChanged {
+		if err := s.repo.Remove(ctx, activity); err != nil {
+			return nil, errors.Wrap(err, "remove original activity")
+		}
+	}
+
+	if err := s.repo.Save(ctx, updated); err != nil {
+		if startChanged {
+			// Put the original back so a failed save does not lose the activity.
+			if restoreErr := s.repo.Save(ctx, activity); restoreErr != nil {
+				return nil, errors.Wrapf(err, "save activity (restore original: %v)", restoreErr)
+			}
+		}
+		return nil, errors.Wrap(err, "save activity")
+	}
+
+	if s.notesRepo == nil {
+		return &updated, nil
+	}
+
+	if startChanged {
+		if err := s.notesRepo.Delete(ctx, activity.ID(), activity.StartTime); err != nil {
+			return nil, errors.Wrap(err, "delete notes")
+		}
+	}
+
+	if updated.Notes == "" && len(updated.Tags) == 0 {
+		if err := s.notesRepo.Delete(ctx, updated.ID(), updated.StartTime); err != nil {
+			return nil, errors.Wrap(err, "delete notes")
+		}
+		return &updated, nil
+	}
+
+	if err := s.notesRepo.Save(ctx, updated.ID(), updated.StartTime, updated.Notes, updated.Tags); err != nil {
+		return nil, errors.Wrap(err, "save notes")
+	}
 	return &updated, nil
 }
 
 func (s *service) Remove(ctx context.Context, activity models.Activity) error {
+	before := s.snapshot(ctx, activity)
+	if err := s.remove(ctx, activity); err != nil {
+		return err
+	}
+	return s.notify(ctx, models.ActivityChange{Op: models.ChangeRemove, Before: &before})
+}
+
+func (s *service) remove(ctx context.Context, activity models.Activity) error {
 	if err := s.repo.Remove(ctx, activity); err != nil {
 		return err
 	}
@@ -307,6 +518,64 @@ func (s *service) Remove(ctx context.Context, activity models.Activity) error {
 	return nil
 }
 
+// ApplyChanges removes, edits and then adds the activities of the set, and
+// notifies the listeners once, as EditAll does. When a change fails, the
+// changes applied before it are still reported to the listeners.
+func (s *service) ApplyChanges(ctx context.Context, set models.ChangeSet) error {
+	changes, err := s.applyChanges(ctx, set)
+	if len(changes) > 0 {
+		if notifyErr := s.notify(ctx, changes...); notifyErr != nil {
+			if err != nil {
+				return errors.Wrapf(err, "apply changes (%v)", notifyErr)
+			}
+			return notifyErr
+		}
+	}
+	return err
+}
+
+func (s *service) applyChanges(ctx context.Context, set models.ChangeSet) ([]models.ActivityChange, error) {
+	changes := make([]models.ActivityChange, 0, len(set.Remove)+len(set.Edit)+len(set.Add))
+	for _, act := range set.Remove {
+		before := s.snapshot(ctx, act)
+		if err := s.remove(ctx, act); err != nil {
+			return changes, errors.Wrapf(err, "remove %q", act.Description)
+		}
+		changes = append(changes, models.ActivityChange{Op: models.ChangeRemove, Before: &before})
+	}
+	for _, e := range set.Edit {
+		before := s.snapshot(ctx, e.Activity)
+		updated, err := s.edit(ctx, e.Activity, e.Request)
+		if err != nil {
+			return changes, errors.Wrapf(err, "edit %q", e.Activity.Description)
+		}
+		changes = append(changes, models.ActivityChange{Op: models.ChangeEdit, Before: &before, After: updated})
+	}
+	for _, req := range set.Add {
+		added, err := s.add(ctx, req)
+		if err != nil {
+			return changes, errors.Wrapf(err, "add %q", req.Description)
+		}
+		changes = append(changes, models.ActivityChange{Op: models.ChangeAdd, After: added})
+	}
+	return changes, nil
+}
+
+// snapshot returns the activity with the notes and tags stored for it, so
+// change listeners see its complete state. Without listeners nobody looks
+// at it, and the notes are not read.
+func (s *service) snapshot(ctx context.Context, activity models.Activity) models.Activity {
+	if s.notesRepo == nil || len(s.listeners) == 0 {
+		return activity
+	}
+	notes, tags, err := s.loadStoredNotes(ctx, activity)
+	if err != nil {
+		return activity
+	}
+	activity.Notes, activity.Tags = notes, tags
+	return activity
+}
+
 // loadStoredNotes returns the authoritative notes/tags for an activity,
 // preferring values persisted in the notes repository over whatever the
 // passed-in activity carries.
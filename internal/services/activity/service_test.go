@@ -574,3 +574,228 @@ func TestService_AddNote_ReturnsErrorWhenNotesRepoMissing(t *testing.T) {
 	_, err := svc.AddNote(context.Background(), models.Activity{StartTime: time.Now()}, "note")
 	require.ErrorIs(t, err, coreErrors.ErrNotesUnavailable)
 }
+
+func TestService_Edit_SameStartUpdatesInPlace(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	notesRepo := new(portsmocks.MockNotesRepository)
+	svc := activity.NewService(repo, notesRepo)
+
+	start := time.Date(2026, 3, 16, 10, 15, 0, 0, time.Local)
+	end := start.Add(time.Hour)
+	act := models.Activity{Project: "Work", Description: "Review PR", StartTime: start, EndTime: &end}
+	newEnd := start.Add(90 * time.Minute)
+
+	repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(a models.Activity) bool {
+		return a.Project == "Ops" && a.Description == "Deploy" && a.StartTime.Equal(start) &&
+			a.EndTime != nil && a.EndTime.Equal(newEnd)
+	})).Return(nil).Once()
+	notesRepo.On("Save", mock.Anything, act.ID(), start, "shipped", []string{"release"}).Return(nil)
+
+	updated, err := svc.Edit(context.Background(), act, models.EditActivityRequest{
+		Project:     "Ops",
+		Description: "Deploy",
+		StartTime:   start,
+		EndTime:     &newEnd,
+		Notes:       " shipped ",
+		Tags:        []string{"release", "release"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Ops", updated.Project)
+	assert.Equal(t, []string{"release"}, updated.Tags)
+	notesRepo.AssertExpectations(t)
+}
+
+func TestService_Edit_MovedStartReplacesOriginal(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	notesRepo := new(portsmocks.MockNotesRepository)
+	svc := activity.NewService(repo, notesRepo)
+
+	start := time.Date(2026, 3, 16, 10, 15, 0, 0, time.Local)
+	end := start.Add(time.Hour)
+	act := models.Activity{Project: "Work", Description: "Review PR", StartTime: start, EndTime: &end, Notes: "old"}
+	newStart := start.Add(-30 * time.Minute)
+
+	repo.EXPECT().Remove(mock.Anything, act).Return(nil).Once()
+	repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(a models.Activity) bool {
+		return a.StartTime.Equal(newStart) && a.EndTime == nil
+	})).Return(nil).Once()
+	notesRepo.On("Delete", mock.Anything, act.ID(), start).Return(nil)
+	notesRepo.On("Delete", mock.Anything, mock.AnythingOfType("string"), newStart).Return(nil)
+
+	updated, err := svc.Edit(context.Background(), act, models.EditActivityRequest{
+		Project:     "Work",
+		Description: "Review PR",
+		StartTime:   newStart,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, updated.EndTime)
+	assert.Empty(t, updated.Notes)
+	notesRepo.AssertExpectations(t)
+}
+
+func TestService_Edit_RejectsEndBeforeStart(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	svc := activity.NewService(repo, nil)
+
+	start := time.Date(2026, 3, 16, 10, 15, 0, 0, time.Local)
+	end := start.Add(-time.Minute)
+
+	_, err := svc.Edit(context.Background(), models.Activity{StartTime: start}, models.EditActivityRequest{
+		StartTime: start,
+		EndTime:   &end,
+	})
+	require.Error(t, err)
+}
+
+type recordingListener struct {
+	calls [][]models.ActivityChange
+	err   error
+}
+
+func (l *recordingListener) ActivityChanged(_ context.Context, changes []models.ActivityChange) error {
+	l.calls = append(l.calls, changes)
+	return l.err
+}
+
+func TestService_NotifiesChangeListeners(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	listener := &recordingListener{}
+	svc := activity.NewService(repo, nil, listener)
+
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	running := models.Activity{Project: "old", Description: "running", StartTime: start.Add(-time.Hour)}
+	repo.EXPECT().Find(mock.Anything, mock.Anything).Return([]models.Activity{running}, nil)
+	repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	repo.EXPECT().Remove(mock.Anything, mock.Anything).Return(nil)
+
+	started, err := svc.Start(context.Background(), models.StartActivityRequest{Project: "api", Description: "fix tests", StartTime: start})
+	require.NoError(t, err)
+	require.Len(t, listener.calls, 1)
+	changes := listener.calls[0]
+	require.Len(t, changes, 2)
+	assert.Equal(t, models.ChangeStart, changes[0].Op)
+	assert.Nil(t, changes[0].Before)
+	assert.Equal(t, "api", changes[0].After.Project)
+	assert.Equal(t, models.ChangeStop, changes[1].Op)
+	assert.Nil(t, changes[1].Before.EndTime)
+	require.NotNil(t, changes[1].After.EndTime)
+	assert.True(t, changes[1].After.EndTime.Equal(start))
+
+	require.NoError(t, svc.Remove(context.Background(), *started))
+	require.Len(t, listener.calls, 2)
+	assert.Equal(t, []models.ActivityChange{{Op: models.ChangeRemove, Before: started}}, listener.calls[1])
+}
+
+func TestService_ReturnsChangeListenerErrors(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	svc := activity.NewService(repo, nil, &recordingListener{err: errors.New("commit failed")})
+
+	repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+
+	end := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	_, err := svc.Add(context.Background(), models.AddActivityRequest{
+		Project: "api", Description: "review", StartTime: end.Add(-time.Hour), EndTime: end,
+	})
+	require.ErrorContains(t, err, "commit failed")
+}
+
+func TestService_ChangeSnapshotsIncludeStoredNotes(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	notesRepo := portsmocks.NewMockNotesRepository(t)
+	listener := &recordingListener{}
+	svc := activity.NewService(repo, notesRepo, listener)
+
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	running := models.Activity{Project: "api", Description: "fix tests", StartTime: start}
+	repo.EXPECT().Find(mock.Anything, mock.Anything).Return([]models.Activity{running}, nil)
+	repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+	notesRepo.EXPECT().Get(mock.Anything, running.ID(), start).Return("flaky", []string{"ci"}, nil)
+
+	end := start.Add(time.Hour)
+	_, err := svc.Stop(context.Background(), models.StopActivityRequest{EndTime: end})
+	require.NoError(t, err)
+
+	require.Len(t, listener.calls, 1)
+	change := listener.calls[0][0]
+	assert.Equal(t, "flaky", change.Before.Notes)
+	assert.Equal(t, []string{"ci"}, change.Before.Tags)
+	assert.Nil(t, change.Before.EndTime)
+	assert.Equal(t, "flaky", change.After.Notes)
+	assert.True(t, change.After.EndTime.Equal(end))
+}
+
+func TestService_StartParallelKeepsRunningActivities(t *testing.T) {
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	onCall := models.Activity{Project: "on-call", Description: "pager", StartTime: start}
+
+	repo := portsmocks.NewMockActivityRepository(t)
+	svc := activity.NewService(repo, nil)
+	repo.EXPECT().Find(mock.Anything, mock.Anything).Return([]models.Activity{onCall}, nil)
+
+	// Only the new activity is saved: nothing gets stopped.
+	repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(a models.Activity) bool {
+		return a.Project == "api" && a.EndTime == nil
+	})).Return(nil).Once()
+
+	_, err := svc.Start(context.Background(), models.StartActivityRequest{
+		Project: "api", Description: "focus", StartTime: start.Add(time.Hour), Parallel: true,
+	})
+	require.NoError(t, err)
+
+	// The start minute of a running activity is taken.
+	_, err = svc.Start(context.Background(), models.StartActivityRequest{
+		Project: "api", Description: "focus", StartTime: start.Add(30 * time.Second), Parallel: true,
+	})
+	require.ErrorIs(t, err, coreErrors.ErrActivityAlreadyStarted)
+	assert.ErrorContains(t, err, "on-call: pager")
+}
+
+func TestService_StopTarget(t *testing.T) {
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)
+	onCall := models.Activity{Project: "on-call", Description: "pager", StartTime: start}
+	focus := models.Activity{Project: "api", Description: "fix tests", StartTime: start.Add(time.Hour)}
+
+	repo := portsmocks.NewMockActivityRepository(t)
+	svc := activity.NewService(repo, nil)
+	repo.EXPECT().Find(mock.Anything, mock.Anything).Return([]models.Activity{onCall, focus}, nil)
+	repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(a models.Activity) bool {
+		return a.Project == "on-call" && a.EndTime != nil
+	})).Return(nil).Once()
+
+	end := start.Add(2 * time.Hour)
+	stopped, err := svc.Stop(context.Background(), models.StopActivityRequest{EndTime: end, Target: &onCall})
+	require.NoError(t, err)
+	assert.Equal(t, "on-call", stopped.Project)
+
+	other := models.Activity{Project: "web", Description: "css", StartTime: start.Add(-time.Hour)}
+	_, err = svc.Stop(context.Background(), models.StopActivityRequest{EndTime: end, Target: &other})
+	require.ErrorIs(t, err, coreErrors.ErrNoActiveActivity)
+	assert.ErrorContains(t, err, "web: css")
+
+	// A target with the start of a running activity must be that activity.
+	sameStart := models.Activity{Project: "web", Description: "css", StartTime: focus.StartTime}
+	_, err = svc.Stop(context.Background(), models.StopActivityRequest{EndTime: end, Target: &sameStart})
+	require.ErrorIs(t, err, coreErrors.ErrNoActiveActivity)
+}
+
+func TestService_Edit_MovedStartRestoresOriginalWhenSaveFails(t *testing.T) {
+	repo := portsmocks.NewMockActivityRepository(t)
+	svc := activity.NewService(repo, nil)
+
+	start := time.Date(2026, 3, 16, 10, 15, 0, 0, time.Local)
+	act := models.Activity{Project: "Work", Description: "Review PR", StartTime: start}
+	newStart := start.Add(-30 * time.Minute)
+
+	repo.EXPECT().Remove(mock.Anything, act).Return(nil).Once()
+	repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(a models.Activity) bool {
+		return a.StartTime.Equal(newStart)
+	})).Return(errors.New("disk full")).Once()
+	repo.EXPECT().Save(mock.Anything, act).Return(nil).Once()
+
+	_, err := svc.Edit(context.Background(), act, models.EditActivityRequest{
+		Project:     "Work",
+		Description: "Review PR",
+		StartTime:   newStart,
+	})
+	require.ErrorContains(t, err, "disk full")
+}
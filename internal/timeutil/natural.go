@@ -0,0 +1,343 @@
+package timeutil
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// Unit is the unit of an amount in relative input such as 10 min ago.
+type Unit int
+
+const (
+	UnitMinute Unit = iota
+	UnitHour
+	UnitDay
+	UnitWeek
+)
+
+// Vocabulary holds the words accepted in natural time input. All words are
+// lower case; input is matched case-insensitively.
+type Vocabulary struct {
+	Now       []string
+	Today     []string
+	Yesterday []string
+	Tomorrow  []string
+	Ago       []string // marks an offset into the past: 10 min ago
+	In        []string // marks an offset into the future: in 2 days
+	Last      []string // before a weekday: last friday
+	Next      []string // before a weekday: next monday
+	Units     map[string]Unit
+	Weekdays  map[string]time.Weekday
+}
+
+// englishWords are the words of the default vocabulary, by localization key.
+var englishWords = map[string]string{
+	"time.input.now":               "now",
+	"time.input.today":             "today",
+	"time.input.yesterday":         "yesterday",
+	"time.input.tomorrow":          "tomorrow",
+	"time.input.ago":               "ago",
+	"time.input.in":                "in",
+	"time.input.last":              "last",
+	"time.input.next":              "next",
+	"time.input.unit.minute":       "m,min,mins,minute,minutes",
+	"time.input.unit.hour":         "h,hr,hrs,hour,hours",
+	"time.input.unit.day":          "d,day,days",
+	"time.input.unit.week":         "w,wk,week,weeks",
+	"time.input.weekday.monday":    "monday,mon",
+	"time.input.weekday.tuesday":   "tuesday,tue,tues",
+	"time.input.weekday.wednesday": "wednesday,wed",
+	"time.input.weekday.thursday":  "thursday,thu,thurs",
+	"time.input.weekday.friday":    "friday,fri",
+	"time.input.weekday.saturday":  "saturday,sat",
+	"time.input.weekday.sunday":    "sunday,sun",
+}
+
+var defaultVocabulary = NewVocabulary(func(key string) string { return englishWords[key] })
+
+// amountPattern matches one amount of relative input, such as 1h or 30 min.
+var amountPattern = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*([^\s\d.,]+)`)
+
+// NewVocabulary builds a vocabulary from the time.input.* keys of a
+// message catalog, such as localization.Localizer.Text. Each key holds
+// comma-separated spellings.
+func NewVocabulary(text func(key string) string) *Vocabulary {
+	words := func(key string) []string {
+		var result []string
+		for word := range strings.SplitSeq(text(key), ",") {
+			if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+				result = append(result, word)
+			}
+		}
+		return result
+	}
+
+	v := &Vocabulary{
+		Now:       words("time.input.now"),
+		Today:     words("time.input.today"),
+		Yesterday: words("time.input.yesterday"),
+		Tomorrow:  words("time.input.tomorrow"),
+		Ago:       words("time.input.ago"),
+		In:        words("time.input.in"),
+		Last:      words("time.input.last"),
+		Next:      words("time.input.next"),
+		Units:     make(map[string]Unit),
+		Weekdays:  make(map[string]time.Weekday),
+	}
+	units := map[string]Unit{
+		"time.input.unit.minute": UnitMinute,
+		"time.input.unit.hour":   UnitHour,
+		"time.input.unit.day":    UnitDay,
+		"time.input.unit.week":   UnitWeek,
+	}
+	for key, unit := range units {
+		for _, word := range words(key) {
+			v.Units[word] = unit
+		}
+	}
+	weekdays := map[string]time.Weekday{
+		"time.input.weekday.monday":    time.Monday,
+		"time.input.weekday.tuesday":   time.Tuesday,
+		"time.input.weekday.wednesday": time.Wednesday,
+		"time.input.weekday.thursday":  time.Thursday,
+		"time.input.weekday.friday":    time.Friday,
+		"time.input.weekday.saturday":  time.Saturday,
+		"time.input.weekday.sunday":    time.Sunday,
+	}
+	for key, weekday := range weekdays {
+		for _, word := range words(key) {
+			v.Weekdays[word] = weekday
+		}
+	}
+	return v
+}
+
+// WithVocabulary returns a copy of f that reads natural input with v.
+func (f *Formatter) WithVocabulary(v *Vocabulary) *Formatter {
+	c := *f
+	c.vocab = v
+	return &c
+}
+
+// At returns a copy of f that resolves relative input, such as yesterday or
+// 10 min ago, against now instead of the current time.
+func (f *Formatter) At(now time.Time) *Formatter {
+	c := *f
+	c.now = now
+	return &c
+}
+
+func (f *Formatter) reference() time.Time {
+	if f.now.IsZero() {
+		return time.Now()
+	}
+	return f.now
+}
+
+func (f *Formatter) vocabulary() *Vocabulary {
+	if f.vocab == nil {
+		return defaultVocabulary
+	}
+	return f.vocab
+}
+
+// ParseDate parses a day: YYYY-MM-DD, today, yesterday, tomorrow, a weekday
+// (the latest one up to today), last or next followed by a weekday, or an
+// offset such as 3 days ago or -2d. It returns the start of the day.
+func (f *Formatter) ParseDate(input string) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if day, err := time.ParseInLocation(time.DateOnly, input, time.Local); err == nil {
+		return day, nil
+	}
+
+	now := f.reference()
+	today, _ := LocalDayBounds(now)
+	v := f.vocabulary()
+	lower := strings.ToLower(input)
+	fields := strings.Fields(lower)
+
+	switch {
+	case slices.Contains(v.Today, lower):
+		return today, nil
+	case slices.Contains(v.Yesterday, lower):
+		return today.AddDate(0, 0, -1), nil
+	case slices.Contains(v.Tomorrow, lower):
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if weekday, ok := v.Weekdays[lower]; ok {
+		return today.AddDate(0, 0, -weekdayDistance(weekday, today.Weekday())), nil
+	}
+	if len(fields) == 2 {
+		if weekday, ok := v.Weekdays[fields[1]]; ok {
+			switch {
+			case slices.Contains(v.Last, fields[0]):
+				back := weekdayDistance(weekday, today.Weekday())
+				if back == 0 {
+					back = 7
+				}
+				return today.AddDate(0, 0, -back), nil
+			case slices.Contains(v.Next, fields[0]):
+				ahead := weekdayDistance(today.Weekday(), weekday)
+				if ahead == 0 {
+					ahead = 7
+				}
+				return today.AddDate(0, 0, ahead), nil
+			}
+		}
+	}
+
+	if t, ok := f.parseRelative(lower, now); ok {
+		day, _ := LocalDayBounds(t)
+		return day, nil
+	}
+
+	return time.Time{}, errors.Errorf(
+		"invalid date %q: expected YYYY-MM-DD, a day such as yesterday or monday, or an offset such as 3 days ago", input)
+}
+
+// ParseDuration parses a duration such as 1h30m, 90 min or 2 hours.
+func (f *Formatter) ParseDuration(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+	d, err := time.ParseDuration(input)
+	if err == nil {
+		return d, nil
+	}
+	if o, ok := f.vocabulary().parseOffset(strings.ToLower(input)); ok {
+		return time.Duration(o.days)*24*time.Hour + o.duration, nil
+	}
+	return 0, err
+}
+
+// parseNatural parses relative input such as -15m or 10 min ago, and a day
+// followed by a clock time, such as yesterday 14:00 or monday 9:30.
+// Relative times are truncated to the minute.
+func (f *Formatter) parseNatural(input string) (time.Time, bool) {
+	if t, ok := f.parseRelative(strings.ToLower(input), f.reference()); ok {
+		return t.Truncate(time.Minute), true
+	}
+
+	fields := strings.Fields(input)
+	for i := 1; i < len(fields); i++ {
+		day, err := f.ParseDate(strings.Join(fields[:i], " "))
+		if err != nil {
+			continue
+		}
+		clock, err := f.ParseTime(strings.Join(fields[i:], " "))
+		if err != nil {
+			continue
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local), true
+	}
+	return time.Time{}, false
+}
+
+// parseRelative parses now, or an offset from now: -15m, +1h, 10 min ago
+// or in 2 days. Input must be lower case.
+func (f *Formatter) parseRelative(input string, now time.Time) (time.Time, bool) {
+	v := f.vocabulary()
+	input = strings.TrimSpace(input)
+	if slices.Contains(v.Now, input) {
+		return now, true
+	}
+
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	first, last := fields[0], fields[len(fields)-1]
+
+	var sign int
+	var rest string
+	switch {
+	case strings.HasPrefix(input, "-"):
+		sign, rest = -1, input[1:]
+	case strings.HasPrefix(input, "+"):
+		sign, rest = 1, input[1:]
+	case len(fields) > 1 && slices.Contains(v.Ago, last):
+		sign, rest = -1, strings.Join(fields[:len(fields)-1], " ")
+	case len(fields) > 1 && slices.Contains(v.Ago, first):
+		sign, rest = -1, strings.Join(fields[1:], " ")
+	case len(fields) > 1 && slices.Contains(v.In, first):
+		sign, rest = 1, strings.Join(fields[1:], " ")
+	case len(fields) > 1 && slices.Contains(v.In, last):
+		sign, rest = 1, strings.Join(fields[:len(fields)-1], " ")
+	default:
+		return time.Time{}, false
+	}
+
+	o, ok := v.parseOffset(rest)
+	if !ok {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, 0, sign*o.days).Add(time.Duration(sign) * o.duration), true
+}
+
+// offset is a relative amount of time. Whole days are kept apart so that
+// they follow the calendar across daylight saving changes.
+type offset struct {
+	days     int
+	duration time.Duration
+}
+
+// parseOffset parses amounts such as 15m, 1h30m, 90 min or 2 days. Input
+// must be lower case.
+func (v *Vocabulary) parseOffset(input string) (offset, bool) {
+	var o offset
+	matches := amountPattern.FindAllStringSubmatchIndex(input, -1)
+	if len(matches) == 0 {
+		return o, false
+	}
+
+	pos := 0
+	for _, m := range matches {
+		if strings.TrimSpace(input[pos:m[0]]) != "" {
+			return o, false
+		}
+		pos = m[1]
+
+		amount, err := strconv.ParseFloat(strings.Replace(input[m[2]:m[3]], ",", ".", 1), 64)
+		if err != nil {
+			return o, false
+		}
+		unit, ok := v.Units[input[m[4]:m[5]]]
+		if !ok {
+			return o, false
+		}
+
+		whole := amount == float64(int(amount))
+		switch unit {
+		case UnitMinute:
+			o.duration += time.Duration(amount * float64(time.Minute))
+		case UnitHour:
+			o.duration += time.Duration(amount * float64(time.Hour))
+		case UnitDay:
+			if whole {
+				o.days += int(amount)
+			} else {
+				o.duration += time.Duration(amount * float64(24*time.Hour))
+			}
+		case UnitWeek:
+			if whole {
+				o.days += 7 * int(amount)
+			} else {
+				o.duration += time.Duration(amount * float64(7*24*time.Hour))
+			}
+		}
+	}
+	if strings.TrimSpace(input[pos:]) != "" {
+		return o, false
+	}
+	return o, true
+}
+
+// weekdayDistance returns how many days it takes to get from one weekday to
+// the next to, from 0 to 6.
+func weekdayDistance(from, to time.Weekday) int {
+	return (int(to) - int(from) + 7) % 7
+}
@@ -0,0 +1,142 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// naturalNow is a Wednesday.
+var naturalNow = time.Date(2026, time.April, 22, 10, 37, 45, 0, time.Local)
+
+func TestParseTimeWithDateNatural(t *testing.T) {
+	f := NewFormatter("24").At(naturalNow)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"now", time.Date(2026, time.April, 22, 10, 37, 0, 0, time.Local)},
+		{"-15m", time.Date(2026, time.April, 22, 10, 22, 0, 0, time.Local)},
+		{"-1h30m", time.Date(2026, time.April, 22, 9, 7, 0, 0, time.Local)},
+		{"+5m", time.Date(2026, time.April, 22, 10, 42, 0, 0, time.Local)},
+		{"10 min ago", time.Date(2026, time.April, 22, 10, 27, 0, 0, time.Local)},
+		{"1 hour 30 minutes ago", time.Date(2026, time.April, 22, 9, 7, 0, 0, time.Local)},
+		{"2 days ago", time.Date(2026, time.April, 20, 10, 37, 0, 0, time.Local)},
+		{"in 1h", time.Date(2026, time.April, 22, 11, 37, 0, 0, time.Local)},
+		{"yesterday 14:00", time.Date(2026, time.April, 21, 14, 0, 0, 0, time.Local)},
+		{"Monday 09:30", time.Date(2026, time.April, 20, 9, 30, 0, 0, time.Local)},
+		{"last wed 08:00", time.Date(2026, time.April, 15, 8, 0, 0, 0, time.Local)},
+		{"3 days ago 18:15", time.Date(2026, time.April, 19, 18, 15, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := f.ParseTimeWithDate(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, input := range []string{"soon", "10 parsecs ago", "-15", "yesterday", "yesterday 3PM"} {
+		_, err := f.ParseTimeWithDate(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestParseTimeWithDateNatural12Hour(t *testing.T) {
+	f := NewFormatter("12").At(naturalNow)
+
+	got, err := f.ParseTimeWithDate("yesterday 3pm")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.April, 21, 15, 0, 0, 0, time.Local), got)
+}
+
+func TestParseDate(t *testing.T) {
+	f := NewFormatter("24").At(naturalNow)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2026-03-01", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.Local)},
+		{"today", time.Date(2026, time.April, 22, 0, 0, 0, 0, time.Local)},
+		{"Yesterday", time.Date(2026, time.April, 21, 0, 0, 0, 0, time.Local)},
+		{"tomorrow", time.Date(2026, time.April, 23, 0, 0, 0, 0, time.Local)},
+		{"monday", time.Date(2026, time.April, 20, 0, 0, 0, 0, time.Local)},
+		{"wednesday", time.Date(2026, time.April, 22, 0, 0, 0, 0, time.Local)},
+		{"thu", time.Date(2026, time.April, 16, 0, 0, 0, 0, time.Local)},
+		{"last wednesday", time.Date(2026, time.April, 15, 0, 0, 0, 0, time.Local)},
+		{"last monday", time.Date(2026, time.April, 20, 0, 0, 0, 0, time.Local)},
+		{"next wednesday", time.Date(2026, time.April, 29, 0, 0, 0, 0, time.Local)},
+		{"next fri", time.Date(2026, time.April, 24, 0, 0, 0, 0, time.Local)},
+		{"3 days ago", time.Date(2026, time.April, 19, 0, 0, 0, 0, time.Local)},
+		{"-1w", time.Date(2026, time.April, 15, 0, 0, 0, 0, time.Local)},
+		{"in 2 days", time.Date(2026, time.April, 24, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := f.ParseDate(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := f.ParseDate("22.04.2026")
+	require.ErrorContains(t, err, "expected YYYY-MM-DD")
+}
+
+func TestParseDuration(t *testing.T) {
+	f := NewFormatter("24")
+
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"1h30m", 90 * time.Minute},
+		{"90 min", 90 * time.Minute},
+		{"2 hours", 2 * time.Hour},
+		{"1.5h", 90 * time.Minute},
+		{"1 hour 15 minutes", 75 * time.Minute},
+		{"1d", 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := f.ParseDuration(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := f.ParseDuration("a while")
+	require.ErrorContains(t, err, "time: invalid duration")
+}
+
+func TestVocabularyLocalizesNaturalInput(t *testing.T) {
+	words := map[string]string{
+		"time.input.yesterday":      "gestern",
+		"time.input.ago":            "vor",
+		"time.input.unit.minute":    "min,minuten",
+		"time.input.weekday.monday": "montag,mo",
+	}
+	f := NewFormatter("24").At(naturalNow).WithVocabulary(NewVocabulary(func(key string) string { return words[key] }))
+
+	got, err := f.ParseTimeWithDate("vor 10 Minuten")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.April, 22, 10, 27, 0, 0, time.Local), got)
+
+	got, err = f.ParseTimeWithDate("gestern 14:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.April, 21, 14, 0, 0, 0, time.Local), got)
+
+	day, err := f.ParseDate("Montag")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.April, 20, 0, 0, 0, 0, time.Local), day)
+
+	_, err = f.ParseDate("yesterday")
+	require.Error(t, err)
+}
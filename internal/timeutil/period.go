@@ -0,0 +1,182 @@
+package timeutil
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// Periods lists the named periods accepted by ParsePeriod. last-Nd and
+// last-Nw are accepted for any N as well.
+var Periods = []string{
+	"today", "yesterday",
+	"this-week", "last-week",
+	"this-month", "last-month",
+	"this-quarter", "last-quarter",
+	"this-year", "last-year", "ytd",
+	"last-7d", "last-30d",
+}
+
+// Calendar holds the first day of the week and the first month of the
+// fiscal year, which weeks, quarters and years of named periods start on.
+type Calendar struct {
+	WeekStart       time.Weekday
+	FiscalYearStart time.Month
+}
+
+// DefaultCalendar starts weeks on Monday and years in January.
+var DefaultCalendar = Calendar{WeekStart: time.Monday, FiscalYearStart: time.January}
+
+// ParseCalendar reads the week start as an English weekday name (monday or
+// mon) and the fiscal year start as an English month name (april or apr) or
+// number (4). Empty values keep the defaults.
+func ParseCalendar(weekStart, fiscalYearStart string) (Calendar, error) {
+	c := DefaultCalendar
+
+	if weekStart = strings.ToLower(strings.TrimSpace(weekStart)); weekStart != "" {
+		weekday, ok := defaultVocabulary.Weekdays[weekStart]
+		if !ok {
+			return c, errors.Errorf("invalid week_start %q: use a weekday such as monday or sunday", weekStart)
+		}
+		c.WeekStart = weekday
+	}
+
+	if fiscalYearStart = strings.ToLower(strings.TrimSpace(fiscalYearStart)); fiscalYearStart != "" {
+		month, ok := parseMonth(fiscalYearStart)
+		if !ok {
+			return c, errors.Errorf("invalid fiscal_year_start %q: use a month such as january or 4", fiscalYearStart)
+		}
+		c.FiscalYearStart = month
+	}
+	return c, nil
+}
+
+func parseMonth(value string) (time.Month, bool) {
+	if n, err := strconv.Atoi(value); err == nil {
+		return time.Month(n), n >= 1 && n <= 12
+	}
+	for month := time.January; month <= time.December; month++ {
+		name := strings.ToLower(month.String())
+		if value == name || value == name[:3] {
+			return month, true
+		}
+	}
+	return 0, false
+}
+
+// StartOfWeek returns local midnight of the first day of t's week.
+func (c Calendar) StartOfWeek(t time.Time) time.Time {
+	day, _ := LocalDayBounds(t)
+	return day.AddDate(0, 0, -weekdayDistance(c.WeekStart, day.Weekday()))
+}
+
+// StartOfYear returns local midnight of the first day of t's fiscal year.
+func (c Calendar) StartOfYear(t time.Time) time.Time {
+	start := c.FiscalYearStart
+	if start < time.January || start > time.December {
+		start = time.January
+	}
+	year := t.Year()
+	if t.Month() < start {
+		year--
+	}
+	return time.Date(year, start, 1, 0, 0, 0, 0, time.Local)
+}
+
+// StartOfQuarter returns local midnight of the first day of t's fiscal
+// quarter.
+func (c Calendar) StartOfQuarter(t time.Time) time.Time {
+	yearStart := c.StartOfYear(t)
+	months := (t.Year()-yearStart.Year())*12 + int(t.Month()-yearStart.Month())
+	return yearStart.AddDate(0, months/3*3, 0)
+}
+
+// WithCalendar returns a copy of f whose named periods follow c.
+func (f *Formatter) WithCalendar(c Calendar) *Formatter {
+	cp := *f
+	cp.calendar = &c
+	return &cp
+}
+
+// Calendar returns the week and fiscal year start of f.
+func (f *Formatter) Calendar() Calendar {
+	if f.calendar == nil {
+		return DefaultCalendar
+	}
+	return *f.calendar
+}
+
+// ParsePeriod returns the start of a named period and the start of the day
+// after it. ytd and last-Nd or last-Nw end today; this-week, this-month,
+// this-quarter and this-year run to their end.
+func (f *Formatter) ParsePeriod(name string) (time.Time, time.Time, error) {
+	c := f.Calendar()
+	today, tomorrow := LocalDayBounds(f.reference())
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	switch name {
+	case "today":
+		return today, tomorrow, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this-week":
+		start := c.StartOfWeek(today)
+		return start, start.AddDate(0, 0, 7), nil
+	case "last-week":
+		end := c.StartOfWeek(today)
+		return end.AddDate(0, 0, -7), end, nil
+	case "this-month":
+		start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 1, 0), nil
+	case "last-month":
+		end := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+		return end.AddDate(0, -1, 0), end, nil
+	case "this-quarter":
+		start := c.StartOfQuarter(today)
+		return start, start.AddDate(0, 3, 0), nil
+	case "last-quarter":
+		end := c.StartOfQuarter(today)
+		return end.AddDate(0, -3, 0), end, nil
+	case "this-year":
+		start := c.StartOfYear(today)
+		return start, start.AddDate(1, 0, 0), nil
+	case "last-year":
+		end := c.StartOfYear(today)
+		return end.AddDate(-1, 0, 0), end, nil
+	case "ytd":
+		return c.StartOfYear(today), tomorrow, nil
+	}
+
+	if count, ok := strings.CutPrefix(name, "last-"); ok && len(count) > 1 {
+		n, err := strconv.Atoi(count[:len(count)-1])
+		if err == nil && n > 0 {
+			switch count[len(count)-1] {
+			case 'd':
+				return tomorrow.AddDate(0, 0, -n), tomorrow, nil
+			case 'w':
+				return tomorrow.AddDate(0, 0, -7*n), tomorrow, nil
+			}
+		}
+	}
+
+	return time.Time{}, time.Time{}, errors.Errorf(
+		"invalid period %q: use one of %s, or last-Nd or last-Nw", name, strings.Join(Periods, ", "))
+}
+
+// ParseSince parses the start of an open range: an amount back from now
+// such as 2w, 10d or 3h, or any day accepted by ParseDate. Amounts in whole
+// days or weeks start at midnight.
+func (f *Formatter) ParseSince(input string) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if o, ok := f.vocabulary().parseOffset(strings.ToLower(input)); ok {
+		now := f.reference()
+		if o.duration == 0 {
+			day, _ := LocalDayBounds(now)
+			return day.AddDate(0, 0, -o.days), nil
+		}
+		return now.AddDate(0, 0, -o.days).Add(-o.duration), nil
+	}
+	return f.ParseDate(input)
+}
@@ -0,0 +1,126 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestParsePeriod(t *testing.T) {
+	f := NewFormatter("24").At(naturalNow)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{"today", day(2026, time.April, 22), day(2026, time.April, 23)},
+		{"yesterday", day(2026, time.April, 21), day(2026, time.April, 22)},
+		{"this-week", day(2026, time.April, 20), day(2026, time.April, 27)},
+		{"last-week", day(2026, time.April, 13), day(2026, time.April, 20)},
+		{"this-month", day(2026, time.April, 1), day(2026, time.May, 1)},
+		{"last-month", day(2026, time.March, 1), day(2026, time.April, 1)},
+		{"this-quarter", day(2026, time.April, 1), day(2026, time.July, 1)},
+		{"last-quarter", day(2026, time.January, 1), day(2026, time.April, 1)},
+		{"this-year", day(2026, time.January, 1), day(2027, time.January, 1)},
+		{"last-year", day(2025, time.January, 1), day(2026, time.January, 1)},
+		{"ytd", day(2026, time.January, 1), day(2026, time.April, 23)},
+		{"last-7d", day(2026, time.April, 16), day(2026, time.April, 23)},
+		{"Last-2w", day(2026, time.April, 9), day(2026, time.April, 23)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := f.ParsePeriod(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+
+	for _, name := range []string{"", "next-week", "last-0d", "last-7y", "last-d"} {
+		_, _, err := f.ParsePeriod(name)
+		require.ErrorContains(t, err, "invalid period", name)
+	}
+}
+
+func TestParsePeriodFollowsCalendar(t *testing.T) {
+	c, err := ParseCalendar("sunday", "april")
+	require.NoError(t, err)
+	f := NewFormatter("24").At(naturalNow).WithCalendar(c)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{"this-week", day(2026, time.April, 19), day(2026, time.April, 26)},
+		{"last-week", day(2026, time.April, 12), day(2026, time.April, 19)},
+		{"this-quarter", day(2026, time.April, 1), day(2026, time.July, 1)},
+		{"last-quarter", day(2026, time.January, 1), day(2026, time.April, 1)},
+		{"ytd", day(2026, time.April, 1), day(2026, time.April, 23)},
+		{"last-year", day(2025, time.April, 1), day(2026, time.April, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := f.ParsePeriod(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+
+	// February falls in the fourth quarter of a fiscal year starting in
+	// November of the year before.
+	c, err = ParseCalendar("", "11")
+	require.NoError(t, err)
+	assert.Equal(t, day(2025, time.November, 1), c.StartOfYear(day(2026, time.February, 10)))
+	assert.Equal(t, day(2026, time.February, 1), c.StartOfQuarter(day(2026, time.February, 10)))
+}
+
+func TestParseSince(t *testing.T) {
+	f := NewFormatter("24").At(naturalNow)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2w", day(2026, time.April, 8)},
+		{"10d", day(2026, time.April, 12)},
+		{"3h", time.Date(2026, time.April, 22, 7, 37, 45, 0, time.Local)},
+		{"2026-04-01", day(2026, time.April, 1)},
+		{"monday", day(2026, time.April, 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := f.ParseSince(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := f.ParseSince("a while")
+	require.ErrorContains(t, err, "expected YYYY-MM-DD")
+}
+
+func TestParseCalendar(t *testing.T) {
+	c, err := ParseCalendar("", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCalendar, c)
+
+	c, err = ParseCalendar(" Sun ", "Oct")
+	require.NoError(t, err)
+	assert.Equal(t, Calendar{WeekStart: time.Sunday, FiscalYearStart: time.October}, c)
+
+	_, err = ParseCalendar("someday", "")
+	require.ErrorContains(t, err, "invalid week_start")
+
+	_, err = ParseCalendar("", "13")
+	require.ErrorContains(t, err, "invalid fiscal_year_start")
+}
@@ -28,7 +28,10 @@ const (
 
 // Formatter handles time formatting and parsing.
 type Formatter struct {
-	format TimeFormat
+	format   TimeFormat
+	vocab    *Vocabulary
+	calendar *Calendar
+	now      time.Time // zero means the current time
 }
 
 // NewFormatter creates a new Formatter with the given format string.
@@ -70,7 +73,7 @@ func (f *Formatter) ParseTime(input string) (time.Time, error) {
 	// Try 24-hour format first (always supported as fallback)
 	parsed, err := time.ParseInLocation(layout24Hour, input, time.Local)
 	if err == nil {
-		now := time.Now()
+		now := f.reference()
 		return time.Date(now.Year(), now.Month(), now.Day(),
 			parsed.Hour(), parsed.Minute(), 0, 0, time.Local), nil
 	}
@@ -89,7 +92,7 @@ func (f *Formatter) ParseTime(input string) (time.Time, error) {
 			// Try original case
 			parsed, err = time.ParseInLocation(layout, input, time.Local)
 			if err == nil {
-				now := time.Now()
+				now := f.reference()
 				return time.Date(now.Year(), now.Month(), now.Day(),
 					parsed.Hour(), parsed.Minute(), 0, 0, time.Local), nil
 			}
@@ -99,7 +102,7 @@ func (f *Formatter) ParseTime(input string) (time.Time, error) {
 			upperLayout := strings.ToUpper(layout)
 			parsed, err = time.ParseInLocation(upperLayout, upperInput, time.Local)
 			if err == nil {
-				now := time.Now()
+				now := f.reference()
 				return time.Date(now.Year(), now.Month(), now.Day(),
 					parsed.Hour(), parsed.Minute(), 0, 0, time.Local), nil
 			}
@@ -110,7 +113,8 @@ func (f *Formatter) ParseTime(input string) (time.Time, error) {
 }
 
 // ParseTimeWithDate parses time that may include a date
-// Supports: "HH:MM", "YYYY-MM-DD HH:MM" (and 12hr equivalents).
+// Supports: "HH:MM", "YYYY-MM-DD HH:MM" (and 12hr equivalents), relative
+// input such as "-15m" or "10 min ago", and "yesterday 14:00" or "monday 09:30".
 func (f *Formatter) ParseTimeWithDate(input string) (time.Time, error) {
 	input = strings.TrimSpace(input)
 
@@ -159,7 +163,11 @@ func (f *Formatter) ParseTimeWithDate(input string) (time.Time, error) {
 		}
 	}
 
-	return time.Time{}, errors.New("invalid time format (use HH:MM or YYYY-MM-DD HH:MM)")
+	if parsed, ok := f.parseNatural(input); ok {
+		return parsed, nil
+	}
+
+	return time.Time{}, errors.New("invalid time format (use HH:MM, YYYY-MM-DD HH:MM, -15m, 10 min ago or yesterday HH:MM)")
 }
 
 // FormatDuration formats a duration using Go's time layout constants.